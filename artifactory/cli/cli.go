@@ -20,11 +20,16 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildappend"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildclean"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildcollectenv"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/builddiff"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/builddiscard"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/builddockercreate"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildexport"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildimport"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpromote"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildpublish"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildsbom"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildscan"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/buildverify"
 	copydocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/copy"
 	curldocs "github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/curl"
 	"github.com/jfrog/jfrog-cli-artifactory/artifactory/docs/delete"
@@ -248,6 +253,51 @@ func GetCommands() []components.Command {
 			Action:      buildDiscardCmd,
 			Category:    buildCategory,
 		},
+		{
+			Name:        "build-diff",
+			Flags:       flagkit.GetCommandFlags(flagkit.BuildDiff),
+			Aliases:     []string{"bdf"},
+			Description: builddiff.GetDescription(),
+			Arguments:   builddiff.GetArguments(),
+			Action:      buildDiffCmd,
+			Category:    buildCategory,
+		},
+		{
+			Name:        "build-verify",
+			Flags:       flagkit.GetCommandFlags(flagkit.BuildVerify),
+			Aliases:     []string{"bver"},
+			Description: buildverify.GetDescription(),
+			Arguments:   buildverify.GetArguments(),
+			Action:      buildVerifyCmd,
+			Category:    buildCategory,
+		},
+		{
+			Name:        "build-sbom",
+			Flags:       flagkit.GetCommandFlags(flagkit.BuildSbom),
+			Aliases:     []string{"bsbom"},
+			Description: buildsbom.GetDescription(),
+			Arguments:   buildsbom.GetArguments(),
+			Action:      buildSbomCmd,
+			Category:    buildCategory,
+		},
+		{
+			Name:        "build-export",
+			Flags:       flagkit.GetCommandFlags(flagkit.BuildExport),
+			Aliases:     []string{"bex"},
+			Description: buildexport.GetDescription(),
+			Arguments:   buildexport.GetArguments(),
+			Action:      buildExportCmd,
+			Category:    buildCategory,
+		},
+		{
+			Name:        "build-import",
+			Flags:       flagkit.GetCommandFlags(flagkit.BuildImport),
+			Aliases:     []string{"bim"},
+			Description: buildimport.GetDescription(),
+			Arguments:   buildimport.GetArguments(),
+			Action:      buildImportCmd,
+			Category:    buildCategory,
+		},
 		{
 			Name:        "git-lfs-clean",
 			Flags:       flagkit.GetCommandFlags(flagkit.GitLfsClean),
@@ -1257,6 +1307,17 @@ func buildPublishCmd(c *components.Context) error {
 	buildPublishCmd.SetDotGitPath(c.GetStringFlagValue("dot-git-path"))
 	buildPublishCmd.SetConfigFilePath(c.GetStringFlagValue("git-config-file-path"))
 	buildPublishCmd.SetDepExcludeScopes(c.GetStringsArrFlagValue("dep-exclude-scopes"))
+	buildPublishCmd.SetProvenance(c.GetBoolFlagValue("provenance"))
+	buildPublishCmd.SetProvenanceOut(c.GetStringFlagValue("provenance-out"))
+	signingKey := c.GetStringFlagValue("signing-key")
+	if signingKey == "" {
+		signingKey = os.Getenv("EVD_SIGNING_KEY_PATH")
+	}
+	keyAlias := c.GetStringFlagValue("key-alias")
+	if keyAlias == "" {
+		keyAlias = os.Getenv("EVD_KEY_ALIAS")
+	}
+	buildPublishCmd.SetSigningKey(signingKey).SetKeyAlias(keyAlias)
 
 	err = commands.Exec(buildPublishCmd)
 	if buildPublishCmd.IsDetailedSummary() {
@@ -1434,6 +1495,92 @@ func buildDiscardCmd(c *components.Context) error {
 	return commands.Exec(buildDiscardCmd)
 }
 
+func buildDiffCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() < 2 || c.GetNumberOfArgs() > 3 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildDiffCmd := buildinfo.NewBuildDiffCommand().
+		SetServerDetails(rtDetails).
+		SetBuildName(c.GetArgumentAt(0)).
+		SetBuildNumber(c.GetArgumentAt(1)).
+		SetProject(common.GetProject(c)).
+		SetOutputFormat(c.GetStringFlagValue(flagkit.Format))
+	if c.GetNumberOfArgs() == 3 {
+		buildDiffCmd.SetBaseBuildNumber(c.GetArgumentAt(2))
+	}
+	return commands.Exec(buildDiffCmd)
+}
+
+func buildVerifyCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildVerifyCmd := buildinfo.NewBuildVerifyCommand().
+		SetServerDetails(rtDetails).
+		SetBuildName(c.GetArgumentAt(0)).
+		SetBuildNumber(c.GetArgumentAt(1)).
+		SetProject(common.GetProject(c))
+	return commands.Exec(buildVerifyCmd)
+}
+
+func buildSbomCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildSbomCmd := buildinfo.NewBuildSbomCommand().
+		SetServerDetails(rtDetails).
+		SetBuildConfiguration(common.CreateBuildConfiguration(c)).
+		SetFormat(c.GetStringFlagValue(flagkit.Format)).
+		SetLocal(c.GetBoolFlagValue("local"))
+	return commands.Exec(buildSbomCmd)
+}
+
+func buildExportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() > 2 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildExportCmd := buildinfo.NewBuildExportCommand().
+		SetServerDetails(rtDetails).
+		SetBuildConfiguration(common.CreateBuildConfiguration(c)).
+		SetPublished(c.GetBoolFlagValue("published")).
+		SetIncludeArtifacts(c.GetBoolFlagValue("include-artifacts")).
+		SetOutputPath(c.GetStringFlagValue("output"))
+	return commands.Exec(buildExportCmd)
+}
+
+func buildImportCmd(c *components.Context) error {
+	if c.GetNumberOfArgs() != 1 {
+		return common.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := common.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildImportCmd := buildinfo.NewBuildImportCommand().
+		SetServerDetails(rtDetails).
+		SetArchivePath(c.GetArgumentAt(0)).
+		SetProject(common.GetProject(c)).
+		SetRepoMapping(c.GetStringFlagValue("repo-mapping")).
+		SetOnConflict(c.GetStringFlagValue("on-conflict"))
+	return commands.Exec(buildImportCmd)
+}
+
 func gitLfsCleanCmd(c *components.Context) error {
 	if c.GetNumberOfArgs() > 1 {
 		return common.WrongNumberOfArgumentsHandler(c)
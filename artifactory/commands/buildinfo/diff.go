@@ -0,0 +1,378 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	BuildDiffFormatTable    = "table"
+	BuildDiffFormatJson     = "json"
+	BuildDiffFormatMarkdown = "markdown"
+
+	buildDiffAdded   = "added"
+	buildDiffRemoved = "removed"
+	buildDiffChanged = "changed"
+)
+
+// BuildDiff lists what changed between two published runs of a build, from the run it is compared to (From)
+// to the compared run (To).
+type BuildDiff struct {
+	BuildName    string           `json:"build_name"`
+	FromNumber   string           `json:"from_number"`
+	ToNumber     string           `json:"to_number"`
+	Artifacts    []BuildDiffEntry `json:"artifacts"`
+	Dependencies []BuildDiffEntry `json:"dependencies"`
+	Environment  []BuildDiffEntry `json:"environment"`
+	Vcs          []BuildDiffEntry `json:"vcs"`
+}
+
+// BuildDiffEntry is a single difference. From is empty for added entries and To is empty for removed entries.
+type BuildDiffEntry struct {
+	Change string `json:"change" col-name:"Change"`
+	Name   string `json:"name" col-name:"Name"`
+	From   string `json:"from,omitempty" col-name:"From"`
+	To     string `json:"to,omitempty" col-name:"To"`
+}
+
+type BuildDiffCommand struct {
+	serverDetails   *config.ServerDetails
+	buildName       string
+	buildNumber     string
+	baseBuildNumber string
+	project         string
+	format          string
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{}
+}
+
+func (bdc *BuildDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiffCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetBuildName(buildName string) *BuildDiffCommand {
+	bdc.buildName = buildName
+	return bdc
+}
+
+// SetBuildNumber sets the build run to compare.
+func (bdc *BuildDiffCommand) SetBuildNumber(buildNumber string) *BuildDiffCommand {
+	bdc.buildNumber = buildNumber
+	return bdc
+}
+
+// SetBaseBuildNumber sets the build run to compare to. If empty, the run published before the compared run is used.
+func (bdc *BuildDiffCommand) SetBaseBuildNumber(baseBuildNumber string) *BuildDiffCommand {
+	bdc.baseBuildNumber = baseBuildNumber
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetProject(project string) *BuildDiffCommand {
+	bdc.project = project
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetOutputFormat(format string) *BuildDiffCommand {
+	bdc.format = format
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	switch bdc.format {
+	case "", BuildDiffFormatTable, BuildDiffFormatJson, BuildDiffFormatMarkdown:
+	default:
+		return errorutils.CheckErrorf("unsupported build diff format '%s'. Possible values: %s, %s, %s", bdc.format, BuildDiffFormatTable, BuildDiffFormatJson, BuildDiffFormatMarkdown)
+	}
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	baseBuildNumber := bdc.baseBuildNumber
+	if baseBuildNumber == "" {
+		if baseBuildNumber, err = bdc.getPreviousBuildNumber(servicesManager); err != nil {
+			return err
+		}
+	}
+	base, err := getPublishedBuildInfo(servicesManager, bdc.buildName, baseBuildNumber, bdc.project)
+	if err != nil {
+		return err
+	}
+	build, err := getPublishedBuildInfo(servicesManager, bdc.buildName, bdc.buildNumber, bdc.project)
+	if err != nil {
+		return err
+	}
+
+	diff := diffBuilds(bdc.buildName, base, build)
+	switch bdc.format {
+	case BuildDiffFormatJson:
+		content, err := json.Marshal(diff)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	case BuildDiffFormatMarkdown:
+		log.Output(renderBuildDiffMarkdown(diff))
+		return nil
+	default:
+		return printBuildDiffTables(diff)
+	}
+}
+
+func (bdc *BuildDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+// getPreviousBuildNumber returns the number of the run published right before the compared run.
+func (bdc *BuildDiffCommand) getPreviousBuildNumber(servicesManager artifactory.ArtifactoryServicesManager) (string, error) {
+	runs, found, err := servicesManager.GetBuildRuns(services.BuildInfoParams{BuildName: bdc.buildName, ProjectKey: bdc.project})
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", errorutils.CheckErrorf("build '%s' was not found", bdc.buildName)
+	}
+	return previousBuildNumber(runs, bdc.buildName, bdc.buildNumber)
+}
+
+// previousBuildNumber returns the run that started right before the given run.
+func previousBuildNumber(runs *buildinfo.BuildRuns, buildName, buildNumber string) (string, error) {
+	sorted := artUtils.SortBuildRuns(runs.BuildsNumbers)
+	for i, run := range sorted {
+		if strings.TrimPrefix(run.Uri, "/") != buildNumber {
+			continue
+		}
+		if i+1 == len(sorted) {
+			return "", errorutils.CheckErrorf("build %s/%s is the first run of the build, so there is no previous run to compare it to", buildName, buildNumber)
+		}
+		return strings.TrimPrefix(sorted[i+1].Uri, "/"), nil
+	}
+	return "", errorutils.CheckErrorf("build %s/%s was not found", buildName, buildNumber)
+}
+
+// getPublishedBuildInfo returns the build-info of a published build run, or an error if the run doesn't exist.
+func getPublishedBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, project string) (*buildinfo.BuildInfo, error) {
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found", buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+// diffBuilds compares the artifacts, dependencies, environment variables and VCS revisions of two runs of a build.
+func diffBuilds(buildName string, base, build *buildinfo.BuildInfo) *BuildDiff {
+	baseVersions, baseChecksums := dependencyVersions(base)
+	versions, checksums := dependencyVersions(build)
+	dependencies := diffValues(baseVersions, versions)
+	// A dependency can be rebuilt without changing its version. Such a change is only visible in its checksum.
+	for _, key := range sortedKeys(baseChecksums, checksums) {
+		baseChecksum, inBase := baseChecksums[key]
+		checksum, inBuild := checksums[key]
+		// Checksums of different types can't be compared.
+		if inBase && inBuild && len(baseChecksum) == len(checksum) && baseChecksum != checksum {
+			name, version := splitDependencyId(key)
+			dependencies = append(dependencies, BuildDiffEntry{Change: buildDiffChanged, Name: name,
+				From: strings.TrimSpace(version + " " + baseChecksum), To: strings.TrimSpace(version + " " + checksum)})
+		}
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		return dependencies[i].Name < dependencies[j].Name
+	})
+
+	return &BuildDiff{
+		BuildName:    buildName,
+		FromNumber:   base.Number,
+		ToNumber:     build.Number,
+		Artifacts:    diffValues(artifactChecksums(base), artifactChecksums(build)),
+		Dependencies: dependencies,
+		Environment:  diffValues(environmentVariables(base), environmentVariables(build)),
+		Vcs:          diffValues(vcsRevisions(base), vcsRevisions(build)),
+	}
+}
+
+// diffValues compares two maps by key. Keys found in both maps with different values are changed.
+func diffValues(from, to map[string]string) []BuildDiffEntry {
+	var entries []BuildDiffEntry
+	for _, key := range sortedKeys(from, to) {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inFrom:
+			entries = append(entries, BuildDiffEntry{Change: buildDiffAdded, Name: key, To: toValue})
+		case !inTo:
+			entries = append(entries, BuildDiffEntry{Change: buildDiffRemoved, Name: key, From: fromValue})
+		case fromValue != toValue:
+			entries = append(entries, BuildDiffEntry{Change: buildDiffChanged, Name: key, From: fromValue, To: toValue})
+		}
+	}
+	return entries
+}
+
+func sortedKeys(maps ...map[string]string) []string {
+	keysSet := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			keysSet[key] = true
+		}
+	}
+	keys := make([]string, 0, len(keysSet))
+	for key := range keysSet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// artifactChecksums returns the checksum of each artifact of the build, by its path.
+func artifactChecksums(build *buildinfo.BuildInfo) map[string]string {
+	checksums := map[string]string{}
+	for _, module := range build.Modules {
+		for _, artifact := range module.Artifacts {
+			name := artifact.Path
+			if name == "" {
+				name = artifact.Name
+			}
+			checksums[name] = preferredChecksum(artifact.Checksum)
+		}
+	}
+	return checksums
+}
+
+// dependencyVersions returns the versions of each dependency of the build by its name, and the checksum of each dependency by its ID.
+// A dependency used by several modules may have several versions.
+func dependencyVersions(build *buildinfo.BuildInfo) (versions, checksums map[string]string) {
+	versionsSets := map[string]map[string]bool{}
+	checksums = map[string]string{}
+	for _, module := range build.Modules {
+		for _, dependency := range module.Dependencies {
+			name, version := splitDependencyId(dependency.Id)
+			if versionsSets[name] == nil {
+				versionsSets[name] = map[string]bool{}
+			}
+			if version != "" {
+				versionsSets[name][version] = true
+			}
+			checksums[dependency.Id] = preferredChecksum(dependency.Checksum)
+		}
+	}
+	versions = map[string]string{}
+	for name, versionsSet := range versionsSets {
+		var sortedVersions []string
+		for version := range versionsSet {
+			sortedVersions = append(sortedVersions, version)
+		}
+		sort.Strings(sortedVersions)
+		versions[name] = strings.Join(sortedVersions, ", ")
+	}
+	return versions, checksums
+}
+
+// splitDependencyId splits a dependency ID such as "org.slf4j:slf4j-api:1.7.36" or "lodash:4.17.21" into its name and version.
+// IDs without a version are returned as is.
+func splitDependencyId(id string) (name, version string) {
+	separator := strings.LastIndex(id, ":")
+	if separator <= 0 {
+		return id, ""
+	}
+	return id[:separator], id[separator+1:]
+}
+
+func preferredChecksum(checksum buildinfo.Checksum) string {
+	if checksum.Sha256 != "" {
+		return checksum.Sha256
+	}
+	return checksum.Sha1
+}
+
+// environmentVariables returns the environment variables collected by build-collect-env.
+func environmentVariables(build *buildinfo.BuildInfo) map[string]string {
+	variables := map[string]string{}
+	for key, value := range build.Properties {
+		if strings.HasPrefix(key, buildinfo.BuildInfoEnvPrefix) {
+			variables[strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix)] = value
+		}
+	}
+	return variables
+}
+
+// vcsRevisions returns the revision and branch of each repository of the build, by its URL.
+func vcsRevisions(build *buildinfo.BuildInfo) map[string]string {
+	revisions := map[string]string{}
+	for _, vcs := range build.VcsList {
+		revision := vcs.Revision
+		if vcs.Branch != "" {
+			revision = fmt.Sprintf("%s (%s)", revision, vcs.Branch)
+		}
+		revisions[vcs.Url] = revision
+	}
+	return revisions
+}
+
+type buildDiffSection struct {
+	title   string
+	entries []BuildDiffEntry
+}
+
+func (diff *BuildDiff) sections() []buildDiffSection {
+	return []buildDiffSection{
+		{"Artifacts", diff.Artifacts},
+		{"Dependencies", diff.Dependencies},
+		{"Environment variables", diff.Environment},
+		{"VCS", diff.Vcs},
+	}
+}
+
+func printBuildDiffTables(diff *BuildDiff) error {
+	log.Info(fmt.Sprintf("Comparing build %s/%s to %s/%s.", diff.BuildName, diff.ToNumber, diff.BuildName, diff.FromNumber))
+	for _, section := range diff.sections() {
+		if err := coreutils.PrintTable(section.entries, section.title, "No changes", false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderBuildDiffMarkdown renders the diff as Markdown tables, to be posted as a pull request comment.
+func renderBuildDiffMarkdown(diff *BuildDiff) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Build %s %s compared to %s\n", diff.BuildName, diff.ToNumber, diff.FromNumber))
+	for _, section := range diff.sections() {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", section.title))
+		if len(section.entries) == 0 {
+			sb.WriteString("No changes.\n")
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| Change | Name | %s | %s |\n| --- | --- | --- | --- |\n", diff.FromNumber, diff.ToNumber))
+		for _, entry := range section.entries {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", entry.Change, escapeMarkdownCell(entry.Name), escapeMarkdownCell(entry.From), escapeMarkdownCell(entry.To)))
+		}
+	}
+	return sb.String()
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ").Replace(value)
+}
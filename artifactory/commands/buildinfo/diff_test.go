@@ -0,0 +1,119 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffBuilds(t *testing.T) {
+	base := &buildinfo.BuildInfo{
+		Number: "41",
+		Modules: []buildinfo.Module{{
+			Id: "org:app:1.0",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.0.jar", Path: "org/app/1.0/app-1.0.jar", Checksum: buildinfo.Checksum{Sha256: "sha-app"}},
+				{Name: "app-1.0.pom", Path: "org/app/1.0/app-1.0.pom", Checksum: buildinfo.Checksum{Sha256: "sha-pom"}},
+				{Name: "notes.txt", Checksum: buildinfo.Checksum{Sha1: "sha1-notes"}},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j"}},
+				{Id: "lodash:4.17.20", Checksum: buildinfo.Checksum{Sha1: "sha1-lodash-20"}},
+				{Id: "commons-io:commons-io:2.11.0", Checksum: buildinfo.Checksum{Sha1: "sha1-io"}},
+				{Id: "internal-lib:1.0", Checksum: buildinfo.Checksum{Sha1: "sha1-internal-old"}},
+			},
+		}},
+		Properties: buildinfo.Env{"buildInfo.env.JAVA_VERSION": "17", "buildInfo.env.CI": "true", "other.property": "a"},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/org/app.git", Revision: "abc123", Branch: "main"}},
+	}
+	build := &buildinfo.BuildInfo{
+		Number: "42",
+		Modules: []buildinfo.Module{{
+			Id: "org:app:1.0",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.0.jar", Path: "org/app/1.0/app-1.0.jar", Checksum: buildinfo.Checksum{Sha256: "sha-app-rebuilt"}},
+				{Name: "app-1.0.pom", Path: "org/app/1.0/app-1.0.pom", Checksum: buildinfo.Checksum{Sha256: "sha-pom"}},
+				{Name: "app-1.0-sources.jar", Path: "org/app/1.0/app-1.0-sources.jar", Checksum: buildinfo.Checksum{Sha256: "sha-sources"}},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j"}},
+				{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha1: "sha1-lodash-21"}},
+				// Checksums of different types are not compared.
+				{Id: "commons-io:commons-io:2.11.0", Checksum: buildinfo.Checksum{Sha256: "sha256-io"}},
+				{Id: "internal-lib:1.0", Checksum: buildinfo.Checksum{Sha1: "sha1-internal-new"}},
+				{Id: "guava", Checksum: buildinfo.Checksum{Sha1: "sha1-guava"}},
+			},
+		}},
+		Properties: buildinfo.Env{"buildInfo.env.JAVA_VERSION": "21", "buildInfo.env.CI": "true", "buildInfo.env.RELEASE": "yes", "other.property": "b"},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/org/app.git", Revision: "def456", Branch: "main"}},
+	}
+
+	diff := diffBuilds("my-app", base, build)
+	assert.Equal(t, "41", diff.FromNumber)
+	assert.Equal(t, "42", diff.ToNumber)
+	assert.Equal(t, []BuildDiffEntry{
+		{Change: buildDiffRemoved, Name: "notes.txt", From: "sha1-notes"},
+		{Change: buildDiffAdded, Name: "org/app/1.0/app-1.0-sources.jar", To: "sha-sources"},
+		{Change: buildDiffChanged, Name: "org/app/1.0/app-1.0.jar", From: "sha-app", To: "sha-app-rebuilt"},
+	}, diff.Artifacts)
+	assert.Equal(t, []BuildDiffEntry{
+		{Change: buildDiffAdded, Name: "guava"},
+		{Change: buildDiffChanged, Name: "internal-lib", From: "1.0 sha1-internal-old", To: "1.0 sha1-internal-new"},
+		{Change: buildDiffChanged, Name: "lodash", From: "4.17.20", To: "4.17.21"},
+	}, diff.Dependencies)
+	assert.Equal(t, []BuildDiffEntry{
+		{Change: buildDiffChanged, Name: "JAVA_VERSION", From: "17", To: "21"},
+		{Change: buildDiffAdded, Name: "RELEASE", To: "yes"},
+	}, diff.Environment)
+	assert.Equal(t, []BuildDiffEntry{
+		{Change: buildDiffChanged, Name: "https://github.com/org/app.git", From: "abc123 (main)", To: "def456 (main)"},
+	}, diff.Vcs)
+}
+
+func TestPreviousBuildNumber(t *testing.T) {
+	// The runs are compared by start time, whatever order the API returns them in.
+	runs := &buildinfo.BuildRuns{BuildsNumbers: []buildinfo.BuildRun{
+		{Uri: "/41", Started: "2026-05-02T10:00:00.000+0000"},
+		{Uri: "/39", Started: "2026-05-01T10:00:00.000+0000"},
+		{Uri: "/42", Started: "2026-05-03T10:00:00.000+0000"},
+	}}
+	previous, err := previousBuildNumber(runs, "my-app", "41")
+	require.NoError(t, err)
+	assert.Equal(t, "39", previous)
+
+	_, err = previousBuildNumber(runs, "my-app", "39")
+	assert.ErrorContains(t, err, "build my-app/39 is the first run of the build")
+	_, err = previousBuildNumber(runs, "my-app", "40")
+	assert.EqualError(t, err, "build my-app/40 was not found")
+}
+
+func TestRenderBuildDiffMarkdown(t *testing.T) {
+	diff := &BuildDiff{
+		BuildName:  "my-app",
+		FromNumber: "41",
+		ToNumber:   "42",
+		Artifacts:  []BuildDiffEntry{{Change: buildDiffAdded, Name: "app|1.jar", To: "sha-app"}},
+	}
+	assert.Equal(t, `## Build my-app 42 compared to 41
+
+### Artifacts
+
+| Change | Name | 41 | 42 |
+| --- | --- | --- | --- |
+| added | app\|1.jar |  | sha-app |
+
+### Dependencies
+
+No changes.
+
+### Environment variables
+
+No changes.
+
+### VCS
+
+No changes.
+`, renderBuildDiffMarkdown(diff))
+}
@@ -0,0 +1,230 @@
+package buildinfo
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildArchiveManifest  = "manifest.json"
+	buildArchiveBuildInfo = "build-info.json"
+	// Artifacts are stored under this directory, at their original repository and path.
+	buildArchiveArtifactsDir = "artifacts/"
+
+	buildArchiveSourceLocal     = "local"
+	buildArchiveSourcePublished = "published"
+)
+
+// BuildArchiveManifest describes the content of a build archive created by 'build-export'.
+// Every file of the archive, except for the manifest itself, is listed with its checksum.
+type BuildArchiveManifest struct {
+	BuildName   string             `json:"build_name"`
+	BuildNumber string             `json:"build_number"`
+	Project     string             `json:"project,omitempty"`
+	Source      string             `json:"source"`
+	Exported    string             `json:"exported"`
+	Files       []BuildArchiveFile `json:"files"`
+}
+
+type BuildArchiveFile struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// BuildExportCommand packages a build-info, and optionally its artifacts, into a single archive,
+// which 'build-import' publishes to another Artifactory instance.
+type BuildExportCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	published          bool
+	includeArtifacts   bool
+	outputPath         string
+}
+
+func NewBuildExportCommand() *BuildExportCommand {
+	return &BuildExportCommand{}
+}
+
+func (bec *BuildExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildExportCommand {
+	bec.serverDetails = serverDetails
+	return bec
+}
+
+func (bec *BuildExportCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildExportCommand {
+	bec.buildConfiguration = buildConfiguration
+	return bec
+}
+
+// SetPublished exports the build-info published to Artifactory, instead of the build-info collected locally.
+func (bec *BuildExportCommand) SetPublished(published bool) *BuildExportCommand {
+	bec.published = published
+	return bec
+}
+
+// SetIncludeArtifacts downloads the artifacts of the build from Artifactory into the archive.
+func (bec *BuildExportCommand) SetIncludeArtifacts(includeArtifacts bool) *BuildExportCommand {
+	bec.includeArtifacts = includeArtifacts
+	return bec
+}
+
+// SetOutputPath sets the path of the archive. The default is <build name>-<build number>.zip in the current directory.
+func (bec *BuildExportCommand) SetOutputPath(outputPath string) *BuildExportCommand {
+	bec.outputPath = outputPath
+	return bec
+}
+
+func (bec *BuildExportCommand) Run() (err error) {
+	if err = bec.buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	buildName, err := bec.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bec.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := bec.buildConfiguration.GetProject()
+
+	var servicesManager artifactory.ArtifactoryServicesManager
+	if bec.published || bec.includeArtifacts {
+		if servicesManager, err = utils.CreateServiceManager(bec.serverDetails, -1, 0, false); err != nil {
+			return err
+		}
+	}
+	manifest := &BuildArchiveManifest{BuildName: buildName, BuildNumber: buildNumber, Project: project, Source: buildArchiveSourceLocal}
+	var buildInfo *buildinfo.BuildInfo
+	if bec.published {
+		manifest.Source = buildArchiveSourcePublished
+		buildInfo, err = getPublishedBuildInfo(servicesManager, buildName, buildNumber, project)
+	} else {
+		buildInfo, err = getLocalBuildInfo(buildName, buildNumber, project)
+	}
+	if err != nil {
+		return err
+	}
+
+	outputPath := bec.outputPath
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("%s-%s.zip", strings.ReplaceAll(buildName, "/", "_"), strings.ReplaceAll(buildNumber, "/", "_"))
+	}
+	archiveFile, err := os.Create(outputPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archiveFile.Close()))
+		if err != nil {
+			// Don't leave a partial archive behind.
+			_ = os.Remove(outputPath)
+		}
+	}()
+	if err = writeBuildArchive(archiveFile, manifest, buildInfo, servicesManager, bec.includeArtifacts); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Build %s/%s exported to %s.", buildName, buildNumber, outputPath))
+	return nil
+}
+
+func (bec *BuildExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bec.serverDetails, nil
+}
+
+func (bec *BuildExportCommand) CommandName() string {
+	return "rt_build_export"
+}
+
+// writeBuildArchive writes the build-info, the artifacts if requested, and finally the manifest into the archive.
+// The downloaded artifacts are checked against the SHA-256 checksums recorded in the build-info.
+func writeBuildArchive(writer io.Writer, manifest *BuildArchiveManifest, buildInfo *buildinfo.BuildInfo,
+	servicesManager artifactory.ArtifactoryServicesManager, includeArtifacts bool) (err error) {
+	zipWriter := zip.NewWriter(writer)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipWriter.Close()))
+	}()
+	manifest.Exported = time.Now().UTC().Format(time.RFC3339)
+	manifest.Files = []BuildArchiveFile{}
+
+	content, err := json.MarshalIndent(buildInfo, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := addBuildArchiveFile(zipWriter, buildArchiveBuildInfo, strings.NewReader(string(content)))
+	if err != nil {
+		return err
+	}
+	manifest.Files = append(manifest.Files, file)
+
+	if includeArtifacts {
+		for _, module := range buildInfo.Modules {
+			for _, artifact := range module.Artifacts {
+				artifactPath := constructArtifactPath(artifact)
+				if artifactPath == "" {
+					log.Warn(fmt.Sprintf("Skipping artifact '%s' of module '%s' since its repository isn't recorded in the build-info.", artifact.Name, module.Id))
+					continue
+				}
+				if file, err = downloadToBuildArchive(zipWriter, servicesManager, artifactPath, artifact.Sha256); err != nil {
+					return err
+				}
+				manifest.Files = append(manifest.Files, file)
+			}
+		}
+	}
+
+	content, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = addBuildArchiveFile(zipWriter, buildArchiveManifest, strings.NewReader(string(content)))
+	return err
+}
+
+func downloadToBuildArchive(zipWriter *zip.Writer, servicesManager artifactory.ArtifactoryServicesManager, artifactPath, expectedSha256 string) (file BuildArchiveFile, err error) {
+	log.Info("Exporting " + artifactPath)
+	reader, err := servicesManager.ReadRemoteFile(artifactPath)
+	if err != nil {
+		return file, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	if file, err = addBuildArchiveFile(zipWriter, buildArchiveArtifactsDir+artifactPath, reader); err != nil {
+		return file, err
+	}
+	if expectedSha256 != "" && file.Sha256 != expectedSha256 {
+		return file, errorutils.CheckErrorf("the SHA-256 checksum of '%s' is %s, while the build-info records %s", artifactPath, file.Sha256, expectedSha256)
+	}
+	return file, nil
+}
+
+// addBuildArchiveFile adds a file to the archive and returns its manifest entry.
+func addBuildArchiveFile(zipWriter *zip.Writer, path string, reader io.Reader) (BuildArchiveFile, error) {
+	entry, err := zipWriter.Create(path)
+	if err != nil {
+		return BuildArchiveFile{}, errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(entry, hash), reader)
+	if err != nil {
+		return BuildArchiveFile{}, errorutils.CheckError(err)
+	}
+	return BuildArchiveFile{Path: path, Sha256: hex.EncodeToString(hash.Sum(nil)), Size: size}, nil
+}
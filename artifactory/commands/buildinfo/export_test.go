@@ -0,0 +1,126 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTransferServicesManagerMock serves the files of a source instance, and records what is imported to a target instance.
+type buildTransferServicesManagerMock struct {
+	artifactory.EmptyArtifactoryServicesManager
+	files         map[string]string
+	buildRuns     *buildinfo.BuildRuns
+	uploaded      map[string]string
+	uploadedProps []string
+	deletedRuns   int
+	published     *buildinfo.BuildInfo
+}
+
+func (tsm *buildTransferServicesManagerMock) ReadRemoteFile(readPath string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(tsm.files[readPath])), nil
+}
+
+func (tsm *buildTransferServicesManagerMock) GetBuildRuns(services.BuildInfoParams) (*buildinfo.BuildRuns, bool, error) {
+	return tsm.buildRuns, tsm.buildRuns != nil, nil
+}
+
+func (tsm *buildTransferServicesManagerMock) UploadFiles(_ artifactory.UploadServiceOptions, params ...services.UploadParams) (int, int, error) {
+	tsm.uploaded = map[string]string{}
+	for _, uploadParams := range params {
+		content, err := os.ReadFile(uploadParams.Pattern)
+		if err != nil {
+			return 0, 0, err
+		}
+		tsm.uploaded[uploadParams.Target] = string(content)
+		tsm.uploadedProps = append(tsm.uploadedProps, uploadParams.BuildProps)
+	}
+	return len(params), 0, nil
+}
+
+func (tsm *buildTransferServicesManagerMock) DeleteBuildInfo(_ *buildinfo.BuildInfo, _ string, numberOfBuildOccurrencesToBeDeleted int) error {
+	tsm.deletedRuns = numberOfBuildOccurrencesToBeDeleted
+	return nil
+}
+
+func (tsm *buildTransferServicesManagerMock) PublishBuildInfo(build *buildinfo.BuildInfo, _ string) (*clientutils.Sha256Summary, error) {
+	tsm.published = build
+	return nil, nil
+}
+
+const testJarContent = "jar content"
+
+func newTestTransferBuildInfo(jarSha256 string) *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "my-app",
+		Number:  "3",
+		Started: "2026-03-01T10:00:00.000+0200",
+		Modules: []buildinfo.Module{{
+			Id: "org.jfrog:app:1.0",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.0.jar", Path: "org/jfrog/app/1.0/app-1.0.jar", OriginalDeploymentRepo: "libs-release-local", Checksum: buildinfo.Checksum{Sha256: jarSha256}},
+				{Name: "notes.txt", Path: "notes.txt", OriginalDeploymentRepo: "generic-local"},
+				{Name: "unknown.bin"},
+			},
+		}},
+	}
+}
+
+// writeTestBuildArchive exports the test build with its artifacts, and returns the path of the archive.
+func writeTestBuildArchive(t *testing.T) string {
+	manager := &buildTransferServicesManagerMock{files: map[string]string{
+		"libs-release-local/org/jfrog/app/1.0/app-1.0.jar": testJarContent,
+		"generic-local/notes.txt":                          "notes",
+	}}
+	archivePath := filepath.Join(t.TempDir(), "my-app-3.zip")
+	archiveFile, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, archiveFile.Close())
+	}()
+	manifest := &BuildArchiveManifest{BuildName: "my-app", BuildNumber: "3", Project: "my-project", Source: buildArchiveSourceLocal}
+	require.NoError(t, writeBuildArchive(archiveFile, manifest, newTestTransferBuildInfo(sha256Hex(testJarContent)), manager, true))
+	return archivePath
+}
+
+func sha256Hex(content string) string {
+	checksum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(checksum[:])
+}
+
+func TestWriteBuildArchive(t *testing.T) {
+	archive, err := openBuildArchive(writeTestBuildArchive(t))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, archive.reader.Close())
+	}()
+
+	assert.Equal(t, "my-project", archive.manifest.Project)
+	var paths []string
+	for _, file := range archive.manifest.Files {
+		paths = append(paths, file.Path)
+	}
+	// The artifact without a repository can't be downloaded, but is kept in the build-info.
+	assert.Equal(t, []string{"build-info.json", "artifacts/libs-release-local/org/jfrog/app/1.0/app-1.0.jar", "artifacts/generic-local/notes.txt"}, paths)
+	assert.Equal(t, int64(len(testJarContent)), archive.manifest.Files[1].Size)
+	assert.Equal(t, "my-app", archive.buildInfo.Name)
+	assert.Len(t, archive.buildInfo.Modules[0].Artifacts, 3)
+}
+
+func TestWriteBuildArchiveChecksumMismatch(t *testing.T) {
+	manager := &buildTransferServicesManagerMock{files: map[string]string{"libs-release-local/org/jfrog/app/1.0/app-1.0.jar": "overwritten"}}
+	manifest := &BuildArchiveManifest{BuildName: "my-app", BuildNumber: "3"}
+	err := writeBuildArchive(io.Discard, manifest, newTestTransferBuildInfo(sha256Hex(testJarContent)), manager, true)
+	assert.ErrorContains(t, err, "the SHA-256 checksum of 'libs-release-local/org/jfrog/app/1.0/app-1.0.jar' is")
+}
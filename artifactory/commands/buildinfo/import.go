@@ -0,0 +1,348 @@
+package buildinfo
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	BuildImportConflictFail      = "fail"
+	BuildImportConflictSkip      = "skip"
+	BuildImportConflictOverwrite = "overwrite"
+)
+
+// BuildImportCommand publishes a build archive created by 'build-export': the artifacts in the archive are uploaded
+// to their repositories, or to the repositories they are mapped to, and the build-info is published.
+type BuildImportCommand struct {
+	serverDetails *config.ServerDetails
+	archivePath   string
+	project       string
+	repoMapping   string
+	onConflict    string
+}
+
+func NewBuildImportCommand() *BuildImportCommand {
+	return &BuildImportCommand{}
+}
+
+func (bic *BuildImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildImportCommand {
+	bic.serverDetails = serverDetails
+	return bic
+}
+
+func (bic *BuildImportCommand) SetArchivePath(archivePath string) *BuildImportCommand {
+	bic.archivePath = archivePath
+	return bic
+}
+
+// SetProject sets the project to publish the build to. The default is the project the build was exported from.
+func (bic *BuildImportCommand) SetProject(project string) *BuildImportCommand {
+	bic.project = project
+	return bic
+}
+
+// SetRepoMapping maps the repositories of the exported artifacts to repositories of the target instance,
+// in the form of "source-repo:target-repo;...". Unmapped repositories are kept as is.
+func (bic *BuildImportCommand) SetRepoMapping(repoMapping string) *BuildImportCommand {
+	bic.repoMapping = repoMapping
+	return bic
+}
+
+// SetOnConflict sets what to do when the build number already exists: fail (default), skip or overwrite.
+func (bic *BuildImportCommand) SetOnConflict(onConflict string) *BuildImportCommand {
+	bic.onConflict = onConflict
+	return bic
+}
+
+func (bic *BuildImportCommand) Run() (err error) {
+	switch bic.onConflict {
+	case "", BuildImportConflictFail, BuildImportConflictSkip, BuildImportConflictOverwrite:
+	default:
+		return errorutils.CheckErrorf("unsupported conflict handling '%s'. Possible values: %s, %s, %s", bic.onConflict,
+			BuildImportConflictFail, BuildImportConflictSkip, BuildImportConflictOverwrite)
+	}
+	repoMapping, err := parseRepoMapping(bic.repoMapping)
+	if err != nil {
+		return err
+	}
+	archive, err := openBuildArchive(bic.archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(archive.reader.Close()))
+	}()
+	servicesManager, err := utils.CreateServiceManager(bic.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	project := bic.project
+	if project == "" {
+		project = archive.manifest.Project
+	}
+	return importBuild(servicesManager, archive, project, repoMapping, bic.onConflict)
+}
+
+func (bic *BuildImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bic.serverDetails, nil
+}
+
+func (bic *BuildImportCommand) CommandName() string {
+	return "rt_build_import"
+}
+
+// parseRepoMapping parses a repository mapping in the form of "source-repo:target-repo;...".
+func parseRepoMapping(repoMapping string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(repoMapping, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		source, target, found := strings.Cut(pair, ":")
+		source, target = strings.TrimSpace(source), strings.TrimSpace(target)
+		if !found || source == "" || target == "" || strings.Contains(target, ":") {
+			return nil, errorutils.CheckErrorf("invalid repository mapping '%s'. The expected format is \"source-repo:target-repo;...\"", pair)
+		}
+		mapping[source] = target
+	}
+	return mapping, nil
+}
+
+// buildArchive is a build archive whose content was verified against its manifest.
+type buildArchive struct {
+	reader    *zip.ReadCloser
+	manifest  *BuildArchiveManifest
+	buildInfo *buildinfo.BuildInfo
+	files     map[string]*zip.File
+}
+
+// openBuildArchive opens a build archive and verifies that its files match the checksums of the manifest.
+// Nothing is imported from an archive that was modified after it was exported.
+func openBuildArchive(archivePath string) (archive *buildArchive, err error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = reader.Close()
+		}
+	}()
+	archive = &buildArchive{reader: reader, manifest: &BuildArchiveManifest{}, files: map[string]*zip.File{}}
+	for _, file := range reader.File {
+		archive.files[file.Name] = file
+	}
+	manifestFile, found := archive.files[buildArchiveManifest]
+	if !found {
+		return nil, errorutils.CheckErrorf("'%s' is not a build archive: %s is missing", archivePath, buildArchiveManifest)
+	}
+	if err = readBuildArchiveJson(manifestFile, archive.manifest); err != nil {
+		return nil, err
+	}
+
+	listed := map[string]bool{buildArchiveManifest: true}
+	for _, manifestEntry := range archive.manifest.Files {
+		listed[manifestEntry.Path] = true
+		file, found := archive.files[manifestEntry.Path]
+		if !found {
+			return nil, errorutils.CheckErrorf("the build archive is incomplete: '%s' is listed in the manifest but missing", manifestEntry.Path)
+		}
+		checksum, err := buildArchiveFileSha256(file)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != manifestEntry.Sha256 {
+			return nil, errorutils.CheckErrorf("the build archive is corrupted: the SHA-256 checksum of '%s' is %s, while the manifest records %s", manifestEntry.Path, checksum, manifestEntry.Sha256)
+		}
+	}
+	for name := range archive.files {
+		if !listed[name] {
+			return nil, errorutils.CheckErrorf("the build archive was modified: '%s' is not listed in the manifest", name)
+		}
+	}
+	if !listed[buildArchiveBuildInfo] {
+		return nil, errorutils.CheckErrorf("'%s' is not a build archive: %s is missing", archivePath, buildArchiveBuildInfo)
+	}
+
+	archive.buildInfo = &buildinfo.BuildInfo{}
+	if err = readBuildArchiveJson(archive.files[buildArchiveBuildInfo], archive.buildInfo); err != nil {
+		return nil, err
+	}
+	if archive.buildInfo.Name != archive.manifest.BuildName || archive.buildInfo.Number != archive.manifest.BuildNumber {
+		return nil, errorutils.CheckErrorf("the build archive is inconsistent: the manifest describes build %s/%s, while the build-info is of build %s/%s",
+			archive.manifest.BuildName, archive.manifest.BuildNumber, archive.buildInfo.Name, archive.buildInfo.Number)
+	}
+	return archive, nil
+}
+
+func readBuildArchiveJson(file *zip.File, target interface{}) (err error) {
+	reader, err := file.Open()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	if err = json.NewDecoder(reader).Decode(target); err != nil {
+		return errorutils.CheckErrorf("failed to parse %s in the build archive: %s", file.Name, err.Error())
+	}
+	return nil
+}
+
+func buildArchiveFileSha256(file *zip.File) (checksum string, err error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	hash := sha256.New()
+	// #nosec G110 -- the content is only hashed, never written to disk
+	if _, err = io.Copy(hash, reader); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// importBuild uploads the artifacts of the archive and publishes its build-info.
+// An existing build with the same number fails the import, unless onConflict is skip or overwrite.
+func importBuild(servicesManager artifactory.ArtifactoryServicesManager, archive *buildArchive, project string, repoMapping map[string]string, onConflict string) error {
+	buildInfo := archive.buildInfo
+	buildRuns, found, err := servicesManager.GetBuildRuns(services.BuildInfoParams{BuildName: buildInfo.Name, ProjectKey: project})
+	if err != nil {
+		return err
+	}
+	existingRuns := 0
+	if found {
+		existingRuns = CalculateBuildNumberFrequency(buildRuns)[buildInfo.Number]
+	}
+	if existingRuns > 0 {
+		switch onConflict {
+		case BuildImportConflictSkip:
+			log.Info(fmt.Sprintf("Build %s/%s already exists in Artifactory. Skipping the import.", buildInfo.Name, buildInfo.Number))
+			return nil
+		case BuildImportConflictOverwrite:
+			log.Info(fmt.Sprintf("Build %s/%s already exists in Artifactory and will be overwritten.", buildInfo.Name, buildInfo.Number))
+		default:
+			return errorutils.CheckErrorf("build %s/%s already exists in Artifactory. Use --on-conflict=%s to keep it, or --on-conflict=%s to replace it",
+				buildInfo.Name, buildInfo.Number, BuildImportConflictSkip, BuildImportConflictOverwrite)
+		}
+	}
+
+	if err = uploadBuildArchiveArtifacts(servicesManager, archive, repoMapping); err != nil {
+		return err
+	}
+	if existingRuns > 0 {
+		if err = servicesManager.DeleteBuildInfo(buildInfo, project, existingRuns); err != nil {
+			return err
+		}
+	}
+	if _, err = servicesManager.PublishBuildInfo(buildInfo, project); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Build %s/%s imported.", buildInfo.Name, buildInfo.Number))
+	return nil
+}
+
+// uploadBuildArchiveArtifacts uploads the artifacts of the archive to their mapped repositories, and rewrites the
+// repositories of all the artifacts of the build-info accordingly.
+func uploadBuildArchiveArtifacts(servicesManager artifactory.ArtifactoryServicesManager, archive *buildArchive, repoMapping map[string]string) (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+
+	buildInfo := archive.buildInfo
+	buildProps := "build.name=" + buildInfo.Name + ";build.number=" + buildInfo.Number
+	if started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started); err == nil {
+		buildProps += ";build.timestamp=" + strconv.FormatInt(started.UnixMilli(), 10)
+	}
+	var uploads []services.UploadParams
+	for i := range buildInfo.Modules {
+		artifacts := buildInfo.Modules[i].Artifacts
+		for j := range artifacts {
+			artifactPath := constructArtifactPath(artifacts[j])
+			if artifactPath == "" {
+				continue
+			}
+			targetRepo := artifacts[j].OriginalDeploymentRepo
+			if mappedRepo, found := repoMapping[targetRepo]; found {
+				targetRepo = mappedRepo
+			}
+			artifacts[j].OriginalDeploymentRepo = targetRepo
+			file, found := archive.files[buildArchiveArtifactsDir+artifactPath]
+			if !found {
+				continue
+			}
+			localPath := filepath.Join(tempDir, strconv.Itoa(len(uploads)), path.Base(artifactPath))
+			if err = extractBuildArchiveFile(file, localPath); err != nil {
+				return err
+			}
+			uploadParams := services.NewUploadParams()
+			uploadParams.Pattern = localPath
+			uploadParams.Target = constructArtifactPath(artifacts[j])
+			uploadParams.Flat = true
+			uploadParams.BuildProps = buildProps
+			uploads = append(uploads, uploadParams)
+		}
+	}
+	if len(uploads) == 0 {
+		return nil
+	}
+	uploaded, failed, err := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, uploads...)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to upload %d of %d artifacts of build %s/%s", failed, failed+uploaded, buildInfo.Name, buildInfo.Number)
+	}
+	log.Info(fmt.Sprintf("Uploaded %d artifacts of build %s/%s.", uploaded, buildInfo.Name, buildInfo.Number))
+	return nil
+}
+
+func extractBuildArchiveFile(file *zip.File, localPath string) (err error) {
+	if err = os.MkdirAll(filepath.Dir(localPath), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(localFile.Close()))
+	}()
+	// #nosec G110 -- the file was verified against the manifest of the archive
+	_, err = io.Copy(localFile, reader)
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,116 @@
+package buildinfo
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepoMapping(t *testing.T) {
+	mapping, err := parseRepoMapping("libs-release-local:central-libs-local; generic-local : central-generic-local;")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"libs-release-local": "central-libs-local", "generic-local": "central-generic-local"}, mapping)
+
+	mapping, err = parseRepoMapping("")
+	require.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	for _, invalid := range []string{"libs-release-local", "libs-release-local:", ":central-libs-local", "a:b:c"} {
+		_, err = parseRepoMapping(invalid)
+		assert.ErrorContains(t, err, "invalid repository mapping", invalid)
+	}
+}
+
+func TestImportBuild(t *testing.T) {
+	archive, err := openBuildArchive(writeTestBuildArchive(t))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, archive.reader.Close())
+	}()
+
+	manager := &buildTransferServicesManagerMock{}
+	require.NoError(t, importBuild(manager, archive, "my-project", map[string]string{"libs-release-local": "central-libs-local"}, ""))
+	assert.Equal(t, map[string]string{
+		"central-libs-local/org/jfrog/app/1.0/app-1.0.jar": testJarContent,
+		"generic-local/notes.txt":                          "notes",
+	}, manager.uploaded)
+	assert.Equal(t, []string{"build.name=my-app;build.number=3;build.timestamp=1772352000000", "build.name=my-app;build.number=3;build.timestamp=1772352000000"}, manager.uploadedProps)
+	require.NotNil(t, manager.published)
+	artifacts := manager.published.Modules[0].Artifacts
+	assert.Equal(t, "central-libs-local", artifacts[0].OriginalDeploymentRepo)
+	assert.Equal(t, "generic-local", artifacts[1].OriginalDeploymentRepo)
+	assert.Empty(t, artifacts[2].OriginalDeploymentRepo)
+	assert.Zero(t, manager.deletedRuns)
+}
+
+func TestImportBuildConflict(t *testing.T) {
+	buildRuns := &buildinfo.BuildRuns{BuildsNumbers: []buildinfo.BuildRun{{Uri: "/3"}, {Uri: "/2"}, {Uri: "/3"}}}
+	testCases := []struct {
+		onConflict  string
+		expectedErr string
+		published   bool
+		deletedRuns int
+	}{
+		{onConflict: "", expectedErr: "build my-app/3 already exists in Artifactory"},
+		{onConflict: BuildImportConflictFail, expectedErr: "build my-app/3 already exists in Artifactory"},
+		{onConflict: BuildImportConflictSkip},
+		{onConflict: BuildImportConflictOverwrite, published: true, deletedRuns: 2},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.onConflict, func(t *testing.T) {
+			archive, err := openBuildArchive(writeTestBuildArchive(t))
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, archive.reader.Close())
+			}()
+
+			manager := &buildTransferServicesManagerMock{buildRuns: buildRuns}
+			err = importBuild(manager, archive, "", nil, testCase.onConflict)
+			if testCase.expectedErr != "" {
+				assert.ErrorContains(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.published, manager.published != nil)
+			assert.Equal(t, testCase.published, manager.uploaded != nil)
+			assert.Equal(t, testCase.deletedRuns, manager.deletedRuns)
+		})
+	}
+}
+
+func TestOpenModifiedBuildArchive(t *testing.T) {
+	archivePath := writeTestBuildArchive(t)
+	// Rewrite the archive, replacing the content of the notes.
+	reader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	modifiedPath := filepath.Join(t.TempDir(), "modified.zip")
+	modifiedFile, err := os.Create(modifiedPath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(modifiedFile)
+	for _, file := range reader.File {
+		entry, err := writer.Create(file.Name)
+		require.NoError(t, err)
+		if file.Name == "artifacts/generic-local/notes.txt" {
+			_, err = entry.Write([]byte("tampered"))
+			require.NoError(t, err)
+			continue
+		}
+		content, err := file.Open()
+		require.NoError(t, err)
+		_, err = io.Copy(entry, content)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, modifiedFile.Close())
+	require.NoError(t, reader.Close())
+
+	_, err = openBuildArchive(modifiedPath)
+	assert.ErrorContains(t, err, "the build archive is corrupted: the SHA-256 checksum of 'artifacts/generic-local/notes.txt'")
+}
@@ -0,0 +1,248 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/cienv"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	SlsaProvenancePredicateType = "https://slsa.dev/provenance/v1"
+
+	provenanceBuildType       = "https://jfrog.com/build-info/provenance/v1"
+	provenanceBuilderIdPrefix = "https://jfrog.com/jfrog-cli/builder/"
+	// localBuilder is the builder of builds published outside a supported CI environment.
+	localBuilder = "local"
+)
+
+// createProvenance writes the SLSA provenance of the published build to the provenance output file,
+// and attaches it as evidence to the artifacts and to the build when --provenance is set.
+func (bpc *BuildPublishCommand) createProvenance(buildInfo *buildinfo.BuildInfo) error {
+	if !bpc.provenance && bpc.provenanceOut == "" {
+		return nil
+	}
+	statement := newProvenanceStatement(buildInfo, bpc.buildConfiguration.GetProject(), cienv.GetCIVcsInfo())
+	if bpc.provenanceOut != "" {
+		if err := bpc.writeProvenance(statement); err != nil {
+			return err
+		}
+	}
+	if !bpc.provenance {
+		return nil
+	}
+	return bpc.attachProvenanceEvidence(buildInfo, statement)
+}
+
+// writeProvenance writes the provenance statement to the provenance output file.
+// The statement is wrapped in a signed DSSE envelope if a signing key is provided.
+func (bpc *BuildPublishCommand) writeProvenance(statement *formats.InTotoStatement) error {
+	if bpc.signingKey == "" {
+		content, err := json.MarshalIndent(statement, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = os.WriteFile(bpc.provenanceOut, append(content, '\n'), 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Info("Unsigned build provenance written to " + bpc.provenanceOut)
+		return nil
+	}
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	envelope, err := dsse.SignEnvelope(dsse.InTotoPayloadType, payload, bpc.signingKey, bpc.keyAlias)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = envelope.Save(bpc.provenanceOut); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Signed build provenance written to " + bpc.provenanceOut)
+	return nil
+}
+
+// attachProvenanceEvidence attaches the provenance predicate as signed evidence to each artifact of the build with
+// a known repository and SHA-256 checksum, and to the build itself.
+// An evidence that can't be created doesn't prevent the others from being created, and all the errors are returned.
+func (bpc *BuildPublishCommand) attachProvenanceEvidence(buildInfo *buildinfo.BuildInfo, statement *formats.InTotoStatement) (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	predicate, err := json.Marshal(statement.Predicate)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	predicatePath := filepath.Join(tempDir, "provenance.json")
+	if err = os.WriteFile(predicatePath, predicate, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	var evidenceErrors []error
+	attached := 0
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			artifactPath := constructArtifactPath(artifact)
+			if artifactPath == "" || artifact.Sha256 == "" {
+				log.Warn(fmt.Sprintf("Provenance: skipping artifact '%s' since its repository or SHA-256 checksum is not recorded in the build-info.", artifact.Name))
+				continue
+			}
+			if evidenceErr := evidenceutils.CreateEvidence(bpc.serverDetails, evidenceutils.CreateEvidenceOpts{
+				SubjectRepoPath: artifactPath,
+				SubjectSHA256:   artifact.Sha256,
+				PredicatePath:   predicatePath,
+				PredicateType:   SlsaProvenancePredicateType,
+				KeyPath:         bpc.signingKey,
+				KeyAlias:        bpc.keyAlias,
+			}); evidenceErr != nil {
+				evidenceErrors = append(evidenceErrors, fmt.Errorf("failed to attach the provenance to '%s': %w", artifactPath, evidenceErr))
+				continue
+			}
+			attached++
+		}
+	}
+	buildName, buildNumber := buildInfo.Name, buildInfo.Number
+	if evidenceErr := evidenceutils.CreateBuildEvidence(bpc.serverDetails, evidenceutils.CreateBuildEvidenceOpts{
+		Project:       bpc.buildConfiguration.GetProject(),
+		BuildName:     buildName,
+		BuildNumber:   buildNumber,
+		PredicatePath: predicatePath,
+		PredicateType: SlsaProvenancePredicateType,
+		KeyPath:       bpc.signingKey,
+		KeyAlias:      bpc.keyAlias,
+	}); evidenceErr != nil {
+		evidenceErrors = append(evidenceErrors, fmt.Errorf("failed to attach the provenance to build '%s/%s': %w", buildName, buildNumber, evidenceErr))
+	} else {
+		log.Info(fmt.Sprintf("Provenance attached as evidence to build '%s/%s' and to %d artifacts.", buildName, buildNumber, attached))
+	}
+	return errorutils.CheckError(errors.Join(evidenceErrors...))
+}
+
+// newProvenanceStatement derives an in-toto statement with a SLSA v1 provenance predicate from the build-info.
+// The subjects are the artifacts of the build with a recorded repository and a SHA-256 checksum, which are the artifacts
+// the provenance is attached to as evidence.
+// The resolved dependencies are the VCS revisions of the build, followed by the dependencies of its modules.
+// The builder is the CI provider detected in the environment.
+func newProvenanceStatement(buildInfo *buildinfo.BuildInfo, project string, ciVcsInfo cienv.CIVcsInfo) *formats.InTotoStatement {
+	statement := &formats.InTotoStatement{
+		Type:          dsse.InTotoStatementType,
+		Subject:       []formats.InTotoSubject{},
+		PredicateType: SlsaProvenancePredicateType,
+	}
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			artifactPath := constructArtifactPath(artifact)
+			if artifactPath == "" || artifact.Sha256 == "" {
+				continue
+			}
+			statement.Subject = append(statement.Subject, formats.InTotoSubject{Name: artifactPath, Digest: map[string]string{"sha256": artifact.Sha256}})
+		}
+	}
+
+	externalParameters := map[string]string{"buildName": buildInfo.Name, "buildNumber": buildInfo.Number}
+	if project != "" {
+		externalParameters["project"] = project
+	}
+	if buildInfo.BuildUrl != "" {
+		externalParameters["buildUrl"] = buildInfo.BuildUrl
+	}
+	statement.Predicate.BuildDefinition = formats.SlsaBuildDefinition{
+		BuildType:            provenanceBuildType,
+		ExternalParameters:   externalParameters,
+		ResolvedDependencies: append(provenanceVcsDependencies(buildInfo.VcsList, ciVcsInfo), provenanceBuildDependencies(buildInfo.Modules)...),
+	}
+
+	builder := localBuilder
+	if ciVcsInfo.Provider != "" {
+		builder = ciVcsInfo.Provider
+	}
+	statement.Predicate.RunDetails.Builder = formats.SlsaBuilder{
+		Id:      provenanceBuilderIdPrefix + builder,
+		Version: map[string]string{coreutils.GetCliUserAgentName(): coreutils.GetCliUserAgentVersion()},
+	}
+	metadata := &formats.SlsaRunMetadata{InvocationId: buildInfo.BuildUrl}
+	if started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started); err == nil {
+		metadata.StartedOn = started.UTC().Format(time.RFC3339)
+	}
+	if *metadata != (formats.SlsaRunMetadata{}) {
+		statement.Predicate.RunDetails.Metadata = metadata
+	}
+	return statement
+}
+
+// provenanceVcsDependencies returns the VCS revisions recorded in the build-info, and the revision detected in the CI environment.
+func provenanceVcsDependencies(vcsList []buildinfo.Vcs, ciVcsInfo cienv.CIVcsInfo) []formats.SlsaResourceDescriptor {
+	if ciVcsInfo.Revision != "" {
+		vcsList = append(slices.Clone(vcsList), buildinfo.Vcs{Url: ciVcsInfo.Url, Revision: ciVcsInfo.Revision, Branch: ciVcsInfo.Branch})
+	}
+	var dependencies []formats.SlsaResourceDescriptor
+	revisions := map[string]bool{}
+	for _, vcs := range vcsList {
+		if vcs.Revision == "" || revisions[vcs.Url+"@"+vcs.Revision] {
+			continue
+		}
+		revisions[vcs.Url+"@"+vcs.Revision] = true
+		uri := vcs.Url
+		if uri != "" {
+			if !strings.HasPrefix(uri, "git+") {
+				uri = "git+" + uri
+			}
+			if vcs.Branch != "" {
+				uri += "@refs/heads/" + vcs.Branch
+			}
+		}
+		dependencies = append(dependencies, formats.SlsaResourceDescriptor{Uri: uri, Digest: map[string]string{"gitCommit": vcs.Revision}})
+	}
+	return dependencies
+}
+
+// provenanceBuildDependencies returns the dependencies of the modules, identified by their package URLs when they have one.
+// A dependency used by several modules is returned once.
+func provenanceBuildDependencies(modules []buildinfo.Module) []formats.SlsaResourceDescriptor {
+	var dependencies []formats.SlsaResourceDescriptor
+	added := map[string]bool{}
+	for _, module := range modules {
+		for _, dependency := range module.Dependencies {
+			_, _, purl := packageCoordinates(module.Type, dependency.Id, true)
+			ref := sbomRef(sbomDependency, dependency.Id, purl)
+			if added[ref] {
+				continue
+			}
+			added[ref] = true
+			dependencies = append(dependencies, formats.SlsaResourceDescriptor{Name: dependency.Id, Uri: purl, Digest: provenanceDigest(dependency.Checksum)})
+		}
+	}
+	return dependencies
+}
+
+func provenanceDigest(checksum buildinfo.Checksum) map[string]string {
+	digest := map[string]string{}
+	for algorithm, value := range map[string]string{"sha256": checksum.Sha256, "sha1": checksum.Sha1, "md5": checksum.Md5} {
+		if value != "" {
+			digest[algorithm] = value
+		}
+	}
+	if len(digest) == 0 {
+		return nil
+	}
+	return digest
+}
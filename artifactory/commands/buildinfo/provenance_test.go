@@ -0,0 +1,83 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/cienv"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvenanceStatement(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{
+		Name:     "my-app",
+		Number:   "12",
+		Started:  "2026-03-01T10:00:00.000+0200",
+		BuildUrl: "https://github.com/jfrog/my-app/actions/runs/42",
+		VcsList: []buildinfo.Vcs{
+			{Url: "https://github.com/jfrog/my-app.git", Revision: "abc123", Branch: "main"},
+			{Url: "https://github.com/jfrog/my-app.git", Revision: "abc123", Branch: "main"},
+		},
+		Modules: []buildinfo.Module{
+			{
+				Id:   "org.jfrog:app:1.0",
+				Type: buildinfo.Maven,
+				Artifacts: []buildinfo.Artifact{
+					{Name: "app-1.0.jar", Path: "org/jfrog/app/1.0/app-1.0.jar", OriginalDeploymentRepo: "libs-release-local", Checksum: buildinfo.Checksum{Sha1: "sha1-app", Sha256: "sha256-app"}},
+					{Name: "app-1.0.pom", Path: "org/jfrog/app/1.0/app-1.0.pom", Checksum: buildinfo.Checksum{Sha1: "sha1-pom"}},
+					// The evidence can't be attached without the repository, so the artifact isn't a subject.
+					{Name: "app-1.0-sources.jar", Path: "org/jfrog/app/1.0/app-1.0-sources.jar", Checksum: buildinfo.Checksum{Sha256: "sha256-sources"}},
+				},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j", Sha256: "sha256-slf4j"}},
+					{Id: "junit:junit:4.13.2"},
+				},
+			},
+			{
+				Id:   "org.jfrog:core:1.0",
+				Type: buildinfo.Maven,
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j", Sha256: "sha256-slf4j"}},
+				},
+			},
+		},
+	}
+	ciVcsInfo := cienv.CIVcsInfo{Provider: "github", Org: "jfrog", Repo: "my-app", Url: "https://github.com/jfrog/my-app", Revision: "def456", Branch: "feature"}
+
+	statement := newProvenanceStatement(buildInfo, "my-project", ciVcsInfo)
+	assert.Equal(t, "https://in-toto.io/Statement/v1", statement.Type)
+	assert.Equal(t, SlsaProvenancePredicateType, statement.PredicateType)
+	// The pom has no SHA-256 checksum, so it can't be a subject.
+	assert.Equal(t, []formats.InTotoSubject{{Name: "libs-release-local/org/jfrog/app/1.0/app-1.0.jar", Digest: map[string]string{"sha256": "sha256-app"}}}, statement.Subject)
+
+	buildDefinition := statement.Predicate.BuildDefinition
+	assert.Equal(t, map[string]string{"buildName": "my-app", "buildNumber": "12", "project": "my-project", "buildUrl": buildInfo.BuildUrl}, buildDefinition.ExternalParameters)
+	assert.Equal(t, []formats.SlsaResourceDescriptor{
+		{Uri: "git+https://github.com/jfrog/my-app.git@refs/heads/main", Digest: map[string]string{"gitCommit": "abc123"}},
+		{Uri: "git+https://github.com/jfrog/my-app@refs/heads/feature", Digest: map[string]string{"gitCommit": "def456"}},
+		{Name: "org.slf4j:slf4j-api:1.7.36", Uri: "pkg:maven/org.slf4j/slf4j-api@1.7.36", Digest: map[string]string{"sha1": "sha1-slf4j", "sha256": "sha256-slf4j"}},
+		{Name: "junit:junit:4.13.2", Uri: "pkg:maven/junit/junit@4.13.2"},
+	}, buildDefinition.ResolvedDependencies)
+
+	runDetails := statement.Predicate.RunDetails
+	assert.Equal(t, "https://jfrog.com/jfrog-cli/builder/github", runDetails.Builder.Id)
+	require.NotNil(t, runDetails.Metadata)
+	assert.Equal(t, formats.SlsaRunMetadata{InvocationId: buildInfo.BuildUrl, StartedOn: "2026-03-01T08:00:00Z"}, *runDetails.Metadata)
+}
+
+func TestNewProvenanceStatementOutsideCi(t *testing.T) {
+	statement := newProvenanceStatement(&buildinfo.BuildInfo{Name: "my-app", Number: "1"}, "", cienv.CIVcsInfo{})
+	assert.Empty(t, statement.Subject)
+	assert.NotNil(t, statement.Subject)
+	assert.Equal(t, map[string]string{"buildName": "my-app", "buildNumber": "1"}, statement.Predicate.BuildDefinition.ExternalParameters)
+	assert.Empty(t, statement.Predicate.BuildDefinition.ResolvedDependencies)
+	assert.Equal(t, "https://jfrog.com/jfrog-cli/builder/local", statement.Predicate.RunDetails.Builder.Id)
+	assert.Nil(t, statement.Predicate.RunDetails.Metadata)
+}
+
+func TestBuildPublishProvenanceRequiresSigningKey(t *testing.T) {
+	err := NewBuildPublishCommand().SetProvenance(true).Run()
+	assert.ErrorContains(t, err, "requires a signing key")
+}
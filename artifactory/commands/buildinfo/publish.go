@@ -35,6 +35,10 @@ type BuildPublishCommand struct {
 	collectGitInfo     bool
 	collectEnv         bool
 	depExcludeScopes   []string
+	provenance         bool
+	provenanceOut      string
+	signingKey         string
+	keyAlias           string
 	BuildAddGitCommand
 }
 
@@ -109,11 +113,37 @@ func (bpc *BuildPublishCommand) SetDepExcludeScopes(scopes []string) *BuildPubli
 	return bpc
 }
 
+// SetProvenance attaches a SLSA provenance of the build as signed evidence to its artifacts and to the build itself.
+func (bpc *BuildPublishCommand) SetProvenance(provenance bool) *BuildPublishCommand {
+	bpc.provenance = provenance
+	return bpc
+}
+
+// SetProvenanceOut sets the path of a local file to which the SLSA provenance of the build is written.
+func (bpc *BuildPublishCommand) SetProvenanceOut(provenanceOut string) *BuildPublishCommand {
+	bpc.provenanceOut = provenanceOut
+	return bpc
+}
+
+// SetSigningKey sets the path of the private key that signs the provenance.
+func (bpc *BuildPublishCommand) SetSigningKey(signingKey string) *BuildPublishCommand {
+	bpc.signingKey = signingKey
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) SetKeyAlias(keyAlias string) *BuildPublishCommand {
+	bpc.keyAlias = keyAlias
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) ServerDetails() (*config.ServerDetails, error) {
 	return bpc.serverDetails, nil
 }
 
-func (bpc *BuildPublishCommand) Run() error {
+func (bpc *BuildPublishCommand) Run() (err error) {
+	if bpc.provenance && bpc.signingKey == "" {
+		return errorutils.CheckErrorf("the provenance evidence requires a signing key. Provide the --signing-key flag or set the EVD_SIGNING_KEY_PATH environment variable")
+	}
 	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, bpc.config.DryRun)
 	if err != nil {
 		return err
@@ -211,6 +241,13 @@ func (bpc *BuildPublishCommand) Run() error {
 	// Note: This never returns an error - it only logs warnings on failure
 	bpc.setCIVcsPropsOnArtifacts(servicesManager, buildInfo)
 
+	// The build is already published, so a provenance failure doesn't skip the cleanup, the summary and the output.
+	// It is returned once they are done.
+	provenanceErr := bpc.createProvenance(buildInfo)
+	defer func() {
+		err = errors.Join(err, provenanceErr)
+	}()
+
 	majorVersion, err := utils.GetRtMajorVersion(servicesManager)
 	if err != nil {
 		return err
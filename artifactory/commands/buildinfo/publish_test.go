@@ -134,15 +134,9 @@ func TestPrintBuildInfoLink(t *testing.T) {
 
 	for i := range linkTypes {
 		buildPubConf := &BuildPublishCommand{
-			linkTypes[i].buildInfoConf,
-			&linkTypes[i].serverDetails,
-			nil,
-			true,
-			nil,
-			false,
-			false,
-			nil,
-			BuildAddGitCommand{},
+			buildConfiguration: linkTypes[i].buildInfoConf,
+			serverDetails:      &linkTypes[i].serverDetails,
+			detailedSummary:    true,
 		}
 		buildPubComService, err := buildPubConf.getBuildInfoUiUrl(linkTypes[i].majorVersion, linkTypes[i].buildTime)
 		assert.NoError(t, err)
@@ -0,0 +1,473 @@
+package buildinfo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	SbomFormatCycloneDxJson = "cyclonedx-json"
+	SbomFormatSpdxJson      = "spdx-json"
+
+	sbomModule     = "module"
+	sbomArtifact   = "artifact"
+	sbomDependency = "dependency"
+)
+
+type BuildSbomCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	format             string
+	local              bool
+}
+
+func NewBuildSbomCommand() *BuildSbomCommand {
+	return &BuildSbomCommand{}
+}
+
+func (bsc *BuildSbomCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSbomCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildSbomCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+// SetFormat sets the SBOM format: cyclonedx-json (default) or spdx-json.
+func (bsc *BuildSbomCommand) SetFormat(format string) *BuildSbomCommand {
+	bsc.format = format
+	return bsc
+}
+
+// SetLocal generates the SBOM from the build-info collected locally, before it is published.
+func (bsc *BuildSbomCommand) SetLocal(local bool) *BuildSbomCommand {
+	bsc.local = local
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) Run() error {
+	switch bsc.format {
+	case "", SbomFormatCycloneDxJson, SbomFormatSpdxJson:
+	default:
+		return errorutils.CheckErrorf("unsupported SBOM format '%s'. Possible values: %s, %s", bsc.format, SbomFormatCycloneDxJson, SbomFormatSpdxJson)
+	}
+	if err := bsc.buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+
+	buildInfo, err := bsc.getBuildInfo(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	buildInfo.Name, buildInfo.Number = buildName, buildNumber
+
+	graph := newSbomGraph(buildInfo)
+	var document interface{} = graph.toCycloneDx()
+	if bsc.format == SbomFormatSpdxJson {
+		document = graph.toSpdx()
+	}
+	content, err := marshalSbom(document)
+	if err != nil {
+		return err
+	}
+	log.Output(content)
+	return nil
+}
+
+func (bsc *BuildSbomCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSbomCommand) CommandName() string {
+	return "rt_build_sbom"
+}
+
+func (bsc *BuildSbomCommand) getBuildInfo(buildName, buildNumber string) (*buildinfo.BuildInfo, error) {
+	if bsc.local {
+		return getLocalBuildInfo(buildName, buildNumber, bsc.buildConfiguration.GetProject())
+	}
+	servicesManager, err := utils.CreateServiceManager(bsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	return getPublishedBuildInfo(servicesManager, buildName, buildNumber, bsc.buildConfiguration.GetProject())
+}
+
+// getLocalBuildInfo returns the build-info collected by the build commands, which 'build-publish' would publish.
+func getLocalBuildInfo(buildName, buildNumber, project string) (*buildinfo.BuildInfo, error) {
+	localBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, project)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	buildInfo, err := localBuild.ToBuildInfo()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(buildInfo.Modules) == 0 {
+		return nil, errorutils.CheckErrorf("no build-info was collected locally for build %s/%s", buildName, buildNumber)
+	}
+	return buildInfo, nil
+}
+
+// marshalSbom is similar to json.MarshalIndent with EscapeHTML false, so package URL qualifiers keep their '&'.
+func marshalSbom(document interface{}) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// sbomComponent is a module, an artifact or a dependency of the build.
+type sbomComponent struct {
+	ref        string
+	kind       string
+	name       string
+	version    string
+	purl       string
+	moduleType buildinfo.ModuleType
+	checksum   buildinfo.Checksum
+	scopes     []string
+	// module is the reference of the module that produced the artifact.
+	module string
+}
+
+// sbomGraph is the content of the build, before it is written in one of the SBOM formats.
+type sbomGraph struct {
+	buildName       string
+	buildNumber     string
+	timestamp       string
+	components      []*sbomComponent
+	componentsByRef map[string]*sbomComponent
+	// dependsOn lists the direct dependencies of the modules and the dependencies, by reference.
+	dependsOn map[string][]string
+}
+
+// newSbomGraph maps the modules, artifacts and dependencies of the build to SBOM components.
+// The relationships between the dependencies are taken from their RequestedBy paths, whose first element is the direct parent.
+// A dependency without RequestedBy, or requested by the module itself, is a direct dependency of the module.
+func newSbomGraph(buildInfo *buildinfo.BuildInfo) *sbomGraph {
+	graph := &sbomGraph{buildName: buildInfo.Name, buildNumber: buildInfo.Number, componentsByRef: map[string]*sbomComponent{}, dependsOn: map[string][]string{}}
+	timestamp := time.Now()
+	if started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started); err == nil {
+		timestamp = started
+	}
+	graph.timestamp = timestamp.UTC().Format(time.RFC3339)
+
+	for _, module := range buildInfo.Modules {
+		name, version, purl := packageCoordinates(module.Type, module.Id, false)
+		moduleRef := sbomRef(sbomModule, module.Id, purl)
+		graph.add(&sbomComponent{ref: moduleRef, kind: sbomModule, name: name, version: version, purl: purl, moduleType: module.Type})
+		for _, artifact := range module.Artifacts {
+			artifactPath := artifact.Path
+			if artifactPath == "" {
+				artifactPath = artifact.Name
+			}
+			graph.add(&sbomComponent{ref: sbomRef(sbomArtifact, module.Id+"/"+artifactPath, ""), kind: sbomArtifact, name: artifactPath,
+				checksum: artifact.Checksum, module: moduleRef})
+		}
+
+		dependencyRefs := map[string]string{}
+		for _, dependency := range module.Dependencies {
+			name, version, purl := packageCoordinates(module.Type, dependency.Id, true)
+			dependencyRefs[dependency.Id] = sbomRef(sbomDependency, dependency.Id, purl)
+			graph.add(&sbomComponent{ref: dependencyRefs[dependency.Id], kind: sbomDependency, name: name, version: version, purl: purl,
+				moduleType: module.Type, checksum: dependency.Checksum, scopes: dependency.Scopes})
+		}
+		for _, dependency := range module.Dependencies {
+			if len(dependency.RequestedBy) == 0 {
+				graph.addDependsOn(moduleRef, dependencyRefs[dependency.Id])
+			}
+			for _, requestedBy := range dependency.RequestedBy {
+				parentRef := moduleRef
+				if len(requestedBy) > 0 && dependencyRefs[requestedBy[0]] != "" {
+					parentRef = dependencyRefs[requestedBy[0]]
+				}
+				graph.addDependsOn(parentRef, dependencyRefs[dependency.Id])
+			}
+		}
+	}
+	return graph
+}
+
+// add adds a component to the graph. A dependency used by several modules is added once, with the scopes of all modules.
+// A module of the build may also be a dependency of another module, in which case it is kept as a module.
+func (graph *sbomGraph) add(component *sbomComponent) {
+	if existing, found := graph.componentsByRef[component.ref]; found {
+		if component.kind == sbomModule {
+			existing.kind, existing.moduleType = sbomModule, component.moduleType
+		}
+		for _, scope := range component.scopes {
+			if !slices.Contains(existing.scopes, scope) {
+				existing.scopes = append(existing.scopes, scope)
+			}
+		}
+		return
+	}
+	graph.componentsByRef[component.ref] = component
+	graph.components = append(graph.components, component)
+}
+
+func (graph *sbomGraph) addDependsOn(parentRef, ref string) {
+	if parentRef != ref && !slices.Contains(graph.dependsOn[parentRef], ref) {
+		graph.dependsOn[parentRef] = append(graph.dependsOn[parentRef], ref)
+	}
+}
+
+// sbomRef returns the reference of a component in the SBOM. Components are referenced by their package URL when they have one.
+func sbomRef(kind, id, purl string) string {
+	if purl != "" {
+		return purl
+	}
+	return kind + ":" + id
+}
+
+// packageCoordinates returns the name, version and package URL of a module or dependency, according to the module type.
+// The package URL is empty for types without a package URL, and for the dependencies of Docker images and Helm charts,
+// which are layers rather than packages.
+func packageCoordinates(moduleType buildinfo.ModuleType, id string, dependency bool) (name, version, purl string) {
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		parts := strings.Split(id, ":")
+		if len(parts) < 3 {
+			return id, "", ""
+		}
+		return parts[0] + ":" + parts[1], parts[2], packageUrl("maven", parts[0]+"/"+parts[1], parts[2], "")
+	case buildinfo.Docker, buildinfo.Helm:
+		if dependency {
+			return id, "", ""
+		}
+		name, version = splitImageReference(id)
+		if moduleType == buildinfo.Helm {
+			return name, version, packageUrl("helm", name, version, "")
+		}
+		// An image name that starts with a registry host is a reference to that registry.
+		if host, path, found := strings.Cut(name, "/"); found && strings.ContainsAny(host, ".:") {
+			return name, version, packageUrl("docker", path, version, "repository_url="+host)
+		}
+		return name, version, packageUrl("docker", name, version, "")
+	case buildinfo.Conan:
+		return conanCoordinates(id)
+	}
+
+	name, version = splitDependencyId(id)
+	switch moduleType {
+	case buildinfo.Npm:
+		purl = packageUrl("npm", name, version, "")
+	case buildinfo.Go:
+		purl = packageUrl("golang", name, version, "")
+	case buildinfo.Python:
+		purl = packageUrl("pypi", strings.ReplaceAll(strings.ToLower(name), "_", "-"), version, "")
+	}
+	return name, version, purl
+}
+
+// splitImageReference splits a Docker image or Helm chart reference such as "registry:5000/org/app:1.0" into its name and tag.
+func splitImageReference(reference string) (name, tag string) {
+	separator := strings.LastIndex(reference, ":")
+	if separator <= strings.LastIndex(reference, "/") {
+		return reference, ""
+	}
+	return reference[:separator], reference[separator+1:]
+}
+
+// conanCoordinates maps a Conan reference such as "zlib/1.3@user/channel#revision" to a package URL.
+func conanCoordinates(reference string) (name, version, purl string) {
+	reference, _, _ = strings.Cut(reference, "#")
+	nameVersion, userChannel, _ := strings.Cut(reference, "@")
+	name, version, found := strings.Cut(nameVersion, "/")
+	if !found {
+		return reference, "", ""
+	}
+	qualifiers := ""
+	if user, channel, found := strings.Cut(userChannel, "/"); found {
+		qualifiers = "channel=" + url.QueryEscape(channel) + "&user=" + url.QueryEscape(user)
+	}
+	return name, version, packageUrl("conan", name, version, qualifiers)
+}
+
+// packageUrl builds a package URL. The name may contain a namespace, separated by '/'.
+func packageUrl(purlType, name, version, qualifiers string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = escapePurlSegment(segment)
+	}
+	purl := "pkg:" + purlType + "/" + strings.Join(segments, "/")
+	if version != "" {
+		purl += "@" + escapePurlSegment(version)
+	}
+	if qualifiers != "" {
+		purl += "?" + qualifiers
+	}
+	return purl
+}
+
+func escapePurlSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
+
+func (graph *sbomGraph) buildRef() string {
+	return "build:" + graph.buildName + "/" + graph.buildNumber
+}
+
+func (graph *sbomGraph) toCycloneDx() *formats.CycloneDxBom {
+	bom := &formats.CycloneDxBom{
+		BomFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: formats.CycloneDxMetadata{
+			Timestamp: graph.timestamp,
+			Tools: &formats.CycloneDxTools{Components: []formats.CycloneDxComponent{
+				{Type: "application", Name: coreutils.GetCliUserAgentName(), Version: coreutils.GetCliUserAgentVersion()},
+			}},
+			Component: &formats.CycloneDxComponent{BomRef: graph.buildRef(), Type: "application", Name: graph.buildName, Version: graph.buildNumber},
+		},
+	}
+	buildDependencies := formats.CycloneDxDependency{Ref: graph.buildRef()}
+	modulesIndexes := map[string]int{}
+	for _, component := range graph.components {
+		switch component.kind {
+		case sbomModule:
+			componentType := "library"
+			if component.moduleType == buildinfo.Docker {
+				componentType = "container"
+			}
+			modulesIndexes[component.ref] = len(bom.Components)
+			bom.Components = append(bom.Components, formats.CycloneDxComponent{BomRef: component.ref, Type: componentType,
+				Name: component.name, Version: component.version, Purl: component.purl})
+			buildDependencies.DependsOn = append(buildDependencies.DependsOn, component.ref)
+		case sbomArtifact:
+			module := &bom.Components[modulesIndexes[component.module]]
+			module.Components = append(module.Components, formats.CycloneDxComponent{BomRef: component.ref, Type: "file",
+				Name: component.name, Hashes: cycloneDxHashes(component.checksum)})
+		case sbomDependency:
+			dependency := formats.CycloneDxComponent{BomRef: component.ref, Type: "library", Name: component.name, Version: component.version,
+				Scope: cycloneDxScope(component.scopes), Hashes: cycloneDxHashes(component.checksum), Purl: component.purl}
+			if len(component.scopes) > 0 {
+				dependency.Properties = []formats.CycloneDxProperty{{Name: "build-info:scopes", Value: strings.Join(component.scopes, ",")}}
+			}
+			bom.Components = append(bom.Components, dependency)
+		}
+	}
+
+	bom.Dependencies = append(bom.Dependencies, buildDependencies)
+	for _, component := range graph.components {
+		if component.kind != sbomArtifact {
+			bom.Dependencies = append(bom.Dependencies, formats.CycloneDxDependency{Ref: component.ref, DependsOn: graph.dependsOn[component.ref]})
+		}
+	}
+	return bom
+}
+
+func cycloneDxHashes(checksum buildinfo.Checksum) []formats.CycloneDxHash {
+	var hashes []formats.CycloneDxHash
+	for _, hash := range []formats.CycloneDxHash{{Alg: "SHA-1", Content: checksum.Sha1}, {Alg: "SHA-256", Content: checksum.Sha256}, {Alg: "MD5", Content: checksum.Md5}} {
+		if hash.Content != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// cycloneDxScope returns "optional" for dependencies used only for tests or development, which are not needed at runtime.
+func cycloneDxScope(scopes []string) string {
+	if len(scopes) == 0 {
+		return ""
+	}
+	for _, scope := range scopes {
+		switch strings.ToLower(scope) {
+		case "test", "dev", "development":
+		default:
+			return "required"
+		}
+	}
+	return "optional"
+}
+
+func (graph *sbomGraph) toSpdx() *formats.SpdxDocument {
+	// The namespace must be unique per document, and stable for the same build run.
+	hash := sha256.Sum256([]byte(graph.buildName + "/" + graph.buildNumber + "/" + graph.timestamp))
+	document := &formats.SpdxDocument{
+		SpdxVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SpdxId:            "SPDXRef-DOCUMENT",
+		Name:              graph.buildName + "-" + graph.buildNumber,
+		DocumentNamespace: fmt.Sprintf("https://spdx.org/spdxdocs/%s-%s-%s", url.PathEscape(graph.buildName), url.PathEscape(graph.buildNumber), hex.EncodeToString(hash[:8])),
+		CreationInfo: formats.SpdxCreationInfo{
+			Created:  graph.timestamp,
+			Creators: []string{fmt.Sprintf("Tool: %s-%s", coreutils.GetCliUserAgentName(), coreutils.GetCliUserAgentVersion())},
+		},
+		Packages: []formats.SpdxPackage{{SpdxId: "SPDXRef-Build", Name: graph.buildName, VersionInfo: graph.buildNumber,
+			DownloadLocation: "NOASSERTION", PrimaryPackagePurpose: "APPLICATION"}},
+		Relationships: []formats.SpdxRelationship{{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: "SPDXRef-Build"}},
+	}
+
+	spdxIds := map[string]string{}
+	for i, component := range graph.components {
+		spdxId := fmt.Sprintf("SPDXRef-%s-%d", strings.ToUpper(component.kind[:1])+component.kind[1:], i+1)
+		spdxIds[component.ref] = spdxId
+		switch component.kind {
+		case sbomArtifact:
+			document.Files = append(document.Files, formats.SpdxFile{SpdxId: spdxId, FileName: component.name, Checksums: spdxChecksums(component.checksum)})
+			document.Relationships = append(document.Relationships, formats.SpdxRelationship{SpdxElementId: spdxIds[component.module], RelationshipType: "CONTAINS", RelatedSpdxElement: spdxId})
+		default:
+			spdxPackage := formats.SpdxPackage{SpdxId: spdxId, Name: component.name, VersionInfo: component.version, DownloadLocation: "NOASSERTION",
+				Checksums: spdxChecksums(component.checksum), PrimaryPackagePurpose: "LIBRARY"}
+			if component.purl != "" {
+				spdxPackage.ExternalRefs = []formats.SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: component.purl}}
+			}
+			if component.kind == sbomModule {
+				if component.moduleType == buildinfo.Docker {
+					spdxPackage.PrimaryPackagePurpose = "CONTAINER"
+				}
+				document.Relationships = append(document.Relationships, formats.SpdxRelationship{SpdxElementId: "SPDXRef-Build", RelationshipType: "CONTAINS", RelatedSpdxElement: spdxId})
+			}
+			document.Packages = append(document.Packages, spdxPackage)
+		}
+	}
+	for _, component := range graph.components {
+		for _, dependencyRef := range graph.dependsOn[component.ref] {
+			document.Relationships = append(document.Relationships, formats.SpdxRelationship{SpdxElementId: spdxIds[component.ref], RelationshipType: "DEPENDS_ON", RelatedSpdxElement: spdxIds[dependencyRef]})
+		}
+	}
+	return document
+}
+
+func spdxChecksums(checksum buildinfo.Checksum) []formats.SpdxChecksum {
+	checksums := []formats.SpdxChecksum{}
+	for _, spdxChecksum := range []formats.SpdxChecksum{{Algorithm: "SHA1", ChecksumValue: checksum.Sha1}, {Algorithm: "SHA256", ChecksumValue: checksum.Sha256}, {Algorithm: "MD5", ChecksumValue: checksum.Md5}} {
+		if spdxChecksum.ChecksumValue != "" {
+			checksums = append(checksums, spdxChecksum)
+		}
+	}
+	return checksums
+}
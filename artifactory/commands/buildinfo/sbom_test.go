@@ -0,0 +1,123 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageCoordinates(t *testing.T) {
+	testCases := []struct {
+		moduleType buildinfo.ModuleType
+		id         string
+		dependency bool
+		name       string
+		version    string
+		purl       string
+	}{
+		{buildinfo.Maven, "org.jfrog:app:1.0", false, "org.jfrog:app", "1.0", "pkg:maven/org.jfrog/app@1.0"},
+		{buildinfo.Gradle, "org.slf4j:slf4j-api:1.7.36", true, "org.slf4j:slf4j-api", "1.7.36", "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+		{buildinfo.Npm, "@angular/core:17.0.1", true, "@angular/core", "17.0.1", "pkg:npm/%40angular/core@17.0.1"},
+		{buildinfo.Go, "github.com/jfrog/app", false, "github.com/jfrog/app", "", "pkg:golang/github.com/jfrog/app"},
+		{buildinfo.Go, "golang.org/x/mod:v0.14.0", true, "golang.org/x/mod", "v0.14.0", "pkg:golang/golang.org/x/mod@v0.14.0"},
+		{buildinfo.Python, "Typing_Extensions:4.8.0", true, "Typing_Extensions", "4.8.0", "pkg:pypi/typing-extensions@4.8.0"},
+		{buildinfo.Docker, "acme.jfrog.io/docker-local/app:1.0", false, "acme.jfrog.io/docker-local/app", "1.0", "pkg:docker/docker-local/app@1.0?repository_url=acme.jfrog.io"},
+		{buildinfo.Docker, "library/alpine:3.19", false, "library/alpine", "3.19", "pkg:docker/library/alpine@3.19"},
+		{buildinfo.Docker, "sha256__f1b5933fe4b5", true, "sha256__f1b5933fe4b5", "", ""},
+		{buildinfo.Helm, "nginx:15.4.0", false, "nginx", "15.4.0", "pkg:helm/nginx@15.4.0"},
+		{buildinfo.Conan, "zlib/1.3@acme/stable#a1b2", false, "zlib", "1.3", "pkg:conan/zlib@1.3?channel=stable&user=acme"},
+		{buildinfo.Conan, "fmt/10.1.1", true, "fmt", "10.1.1", "pkg:conan/fmt@10.1.1"},
+		{buildinfo.Generic, "my-module", false, "my-module", "", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(string(testCase.moduleType)+"/"+testCase.id, func(t *testing.T) {
+			name, version, purl := packageCoordinates(testCase.moduleType, testCase.id, testCase.dependency)
+			assert.Equal(t, testCase.name, name)
+			assert.Equal(t, testCase.version, version)
+			assert.Equal(t, testCase.purl, purl)
+		})
+	}
+}
+
+func newTestSbomBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "my-app",
+		Number:  "7",
+		Started: "2026-03-01T10:00:00.000+0200",
+		Modules: []buildinfo.Module{
+			{
+				Id:        "org.jfrog:app:1.0",
+				Type:      buildinfo.Maven,
+				Artifacts: []buildinfo.Artifact{{Name: "app-1.0.jar", Path: "org/jfrog/app/1.0/app-1.0.jar", Checksum: buildinfo.Checksum{Sha1: "sha1-app"}}},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.jfrog:core:1.0", Scopes: []string{"compile"}, RequestedBy: [][]string{{"org.jfrog:app:1.0"}}},
+					{Id: "org.slf4j:slf4j-api:1.7.36", Scopes: []string{"compile"}, Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j"},
+						RequestedBy: [][]string{{"org.jfrog:core:1.0", "org.jfrog:app:1.0"}}},
+					{Id: "junit:junit:4.13.2", Scopes: []string{"test"}},
+				},
+			},
+			{
+				Id:        "org.jfrog:core:1.0",
+				Type:      buildinfo.Maven,
+				Artifacts: []buildinfo.Artifact{{Name: "core-1.0.jar", Path: "org/jfrog/core/1.0/core-1.0.jar", Checksum: buildinfo.Checksum{Sha1: "sha1-core"}}},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.slf4j:slf4j-api:1.7.36", Scopes: []string{"runtime"}, Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j"}},
+				},
+			},
+		},
+	}
+}
+
+func TestSbomCycloneDx(t *testing.T) {
+	bom := newSbomGraph(newTestSbomBuildInfo()).toCycloneDx()
+	assert.Equal(t, "2026-03-01T08:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "build:my-app/7", bom.Metadata.Component.BomRef)
+
+	// The core module is also a dependency of the app module. It is listed once, as a module with its artifacts.
+	require.Len(t, bom.Components, 4)
+	assert.Equal(t, formats.CycloneDxComponent{BomRef: "pkg:maven/org.jfrog/app@1.0", Type: "library", Name: "org.jfrog:app", Version: "1.0", Purl: "pkg:maven/org.jfrog/app@1.0",
+		Components: []formats.CycloneDxComponent{{BomRef: "artifact:org.jfrog:app:1.0/org/jfrog/app/1.0/app-1.0.jar", Type: "file", Name: "org/jfrog/app/1.0/app-1.0.jar",
+			Hashes: []formats.CycloneDxHash{{Alg: "SHA-1", Content: "sha1-app"}}}}}, bom.Components[0])
+	assert.Equal(t, "pkg:maven/org.jfrog/core@1.0", bom.Components[1].BomRef)
+	assert.Len(t, bom.Components[1].Components, 1)
+	assert.Equal(t, formats.CycloneDxComponent{BomRef: "pkg:maven/org.slf4j/slf4j-api@1.7.36", Type: "library", Name: "org.slf4j:slf4j-api", Version: "1.7.36",
+		Scope: "required", Hashes: []formats.CycloneDxHash{{Alg: "SHA-1", Content: "sha1-slf4j"}}, Purl: "pkg:maven/org.slf4j/slf4j-api@1.7.36",
+		Properties: []formats.CycloneDxProperty{{Name: "build-info:scopes", Value: "compile,runtime"}}}, bom.Components[2])
+	assert.Equal(t, "optional", bom.Components[3].Scope)
+
+	assert.Equal(t, []formats.CycloneDxDependency{
+		{Ref: "build:my-app/7", DependsOn: []string{"pkg:maven/org.jfrog/app@1.0", "pkg:maven/org.jfrog/core@1.0"}},
+		{Ref: "pkg:maven/org.jfrog/app@1.0", DependsOn: []string{"pkg:maven/org.jfrog/core@1.0", "pkg:maven/junit/junit@4.13.2"}},
+		{Ref: "pkg:maven/org.jfrog/core@1.0", DependsOn: []string{"pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+		{Ref: "pkg:maven/org.slf4j/slf4j-api@1.7.36"},
+		{Ref: "pkg:maven/junit/junit@4.13.2"},
+	}, bom.Dependencies)
+}
+
+func TestSbomSpdx(t *testing.T) {
+	document := newSbomGraph(newTestSbomBuildInfo()).toSpdx()
+	assert.Equal(t, "my-app-7", document.Name)
+	assert.Regexp(t, `^https://spdx.org/spdxdocs/my-app-7-[0-9a-f]{16}$`, document.DocumentNamespace)
+	require.Len(t, document.Packages, 5)
+	assert.Equal(t, formats.SpdxPackage{SpdxId: "SPDXRef-Dependency-4", Name: "org.slf4j:slf4j-api", VersionInfo: "1.7.36", DownloadLocation: "NOASSERTION",
+		Checksums:             []formats.SpdxChecksum{{Algorithm: "SHA1", ChecksumValue: "sha1-slf4j"}},
+		ExternalRefs:          []formats.SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:maven/org.slf4j/slf4j-api@1.7.36"}},
+		PrimaryPackagePurpose: "LIBRARY"}, document.Packages[3])
+	assert.Equal(t, []formats.SpdxFile{
+		{SpdxId: "SPDXRef-Artifact-2", FileName: "org/jfrog/app/1.0/app-1.0.jar", Checksums: []formats.SpdxChecksum{{Algorithm: "SHA1", ChecksumValue: "sha1-app"}}},
+		{SpdxId: "SPDXRef-Artifact-6", FileName: "org/jfrog/core/1.0/core-1.0.jar", Checksums: []formats.SpdxChecksum{{Algorithm: "SHA1", ChecksumValue: "sha1-core"}}},
+	}, document.Files)
+	assert.Equal(t, []formats.SpdxRelationship{
+		{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: "SPDXRef-Build"},
+		{SpdxElementId: "SPDXRef-Build", RelationshipType: "CONTAINS", RelatedSpdxElement: "SPDXRef-Module-1"},
+		{SpdxElementId: "SPDXRef-Module-1", RelationshipType: "CONTAINS", RelatedSpdxElement: "SPDXRef-Artifact-2"},
+		{SpdxElementId: "SPDXRef-Build", RelationshipType: "CONTAINS", RelatedSpdxElement: "SPDXRef-Module-3"},
+		{SpdxElementId: "SPDXRef-Module-3", RelationshipType: "CONTAINS", RelatedSpdxElement: "SPDXRef-Artifact-6"},
+		{SpdxElementId: "SPDXRef-Module-1", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Module-3"},
+		{SpdxElementId: "SPDXRef-Module-1", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Dependency-5"},
+		{SpdxElementId: "SPDXRef-Module-3", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Dependency-4"},
+	}, document.Relationships)
+}
@@ -0,0 +1,327 @@
+package buildinfo
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	verifyQueryBatchSize = 100
+
+	verifyStatusOk           = "ok"
+	verifyStatusMissing      = "missing"
+	verifyStatusRelocated    = "relocated"
+	verifyStatusMismatch     = "mismatch"
+	verifyStatusUnresolvable = "unresolvable"
+
+	verifiedArtifact   = "artifact"
+	verifiedDependency = "dependency"
+)
+
+// verifiedEntry is an artifact or a dependency of a build-info, and the result of looking it up in Artifactory.
+type verifiedEntry struct {
+	module   string
+	kind     string
+	name     string
+	repo     string
+	path     string
+	checksum buildinfo.Checksum
+	status   string
+	details  string
+}
+
+type buildVerifyRow struct {
+	Module  string `col-name:"Module"`
+	Type    string `col-name:"Type"`
+	Name    string `col-name:"Name"`
+	Status  string `col-name:"Status"`
+	Details string `col-name:"Details"`
+}
+
+type BuildVerifyCommand struct {
+	serverDetails *config.ServerDetails
+	buildName     string
+	buildNumber   string
+	project       string
+}
+
+func NewBuildVerifyCommand() *BuildVerifyCommand {
+	return &BuildVerifyCommand{}
+}
+
+func (bvc *BuildVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildVerifyCommand {
+	bvc.serverDetails = serverDetails
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetBuildName(buildName string) *BuildVerifyCommand {
+	bvc.buildName = buildName
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetBuildNumber(buildNumber string) *BuildVerifyCommand {
+	bvc.buildNumber = buildNumber
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetProject(project string) *BuildVerifyCommand {
+	bvc.project = project
+	return bvc
+}
+
+// Run looks up every artifact and dependency of the build in Artifactory and compares their checksums with the recorded ones.
+// It returns an error if any of them is missing, was moved from its recorded path, has different checksums or can't be looked up.
+func (bvc *BuildVerifyCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bvc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	build, err := getPublishedBuildInfo(servicesManager, bvc.buildName, bvc.buildNumber, bvc.project)
+	if err != nil {
+		return err
+	}
+	entries := collectVerifiedEntries(build)
+	filesByPath, filesByChecksum, err := findBuildFiles(servicesManager, entries)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		entries[i].status, entries[i].details = verifyEntry(entries[i], filesByPath, filesByChecksum)
+	}
+
+	title := fmt.Sprintf("Verification of build %s/%s", bvc.buildName, bvc.buildNumber)
+	if err = coreutils.PrintTable(buildVerifyProblemRows(entries), title, "All artifacts and dependencies were verified", false); err != nil {
+		return err
+	}
+	counts := map[string]int{}
+	for _, entry := range entries {
+		counts[entry.status]++
+	}
+	log.Info(fmt.Sprintf("Verified %d artifacts and dependencies: %d ok, %d missing, %d relocated, %d mismatched, %d unresolvable.",
+		len(entries), counts[verifyStatusOk], counts[verifyStatusMissing], counts[verifyStatusRelocated], counts[verifyStatusMismatch], counts[verifyStatusUnresolvable]))
+	if failed := len(entries) - counts[verifyStatusOk]; failed > 0 {
+		return errorutils.CheckErrorf("build %s/%s failed verification: %d of %d artifacts and dependencies don't match the build-info", bvc.buildName, bvc.buildNumber, failed, len(entries))
+	}
+	return nil
+}
+
+func (bvc *BuildVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bvc.serverDetails, nil
+}
+
+func (bvc *BuildVerifyCommand) CommandName() string {
+	return "rt_build_verify"
+}
+
+// collectVerifiedEntries lists the artifacts and dependencies of all modules of the build.
+// Artifacts are looked up by their path, and dependencies, which have no recorded path, by their checksum.
+func collectVerifiedEntries(build *buildinfo.BuildInfo) []verifiedEntry {
+	var entries []verifiedEntry
+	for _, module := range build.Modules {
+		for _, artifact := range module.Artifacts {
+			entry := verifiedEntry{module: module.Id, kind: verifiedArtifact, name: constructArtifactPathWithFallback(artifact),
+				repo: artifact.OriginalDeploymentRepo, checksum: normalizeChecksum(artifact.Checksum)}
+			switch {
+			case artifact.Path != "":
+				entry.path = strings.Trim(artifact.Path, "/")
+			case artifact.OriginalDeploymentRepo != "":
+				entry.path = artifact.Name
+			}
+			entries = append(entries, entry)
+		}
+		for _, dependency := range module.Dependencies {
+			entries = append(entries, verifiedEntry{module: module.Id, kind: verifiedDependency, name: dependency.Id, checksum: normalizeChecksum(dependency.Checksum)})
+		}
+	}
+	return entries
+}
+
+func normalizeChecksum(checksum buildinfo.Checksum) buildinfo.Checksum {
+	return buildinfo.Checksum{Sha1: strings.ToLower(checksum.Sha1), Sha256: strings.ToLower(checksum.Sha256), Md5: strings.ToLower(checksum.Md5)}
+}
+
+// findBuildFiles searches Artifactory for the files at the paths of the entries, in any repository, and for the files with their checksums.
+// It returns the files by their path within the repository, and by each of their checksums.
+func findBuildFiles(servicesManager artifactory.ArtifactoryServicesManager, entries []verifiedEntry) (
+	filesByPath, filesByChecksum map[string][]servicesutils.ResultItem, err error) {
+	var paths, checksums []string
+	for _, entry := range entries {
+		if entry.path != "" {
+			paths = append(paths, entry.path)
+		}
+		if checksum := lookupChecksum(entry.checksum); checksum != "" {
+			checksums = append(checksums, checksum)
+		}
+	}
+	var results []servicesutils.ResultItem
+	for _, query := range append(buildVerifyQueries(paths, buildPathsQuery), buildVerifyQueries(checksums, buildChecksumsQuery)...) {
+		batch, err := artUtils.ExecuteAqlQuery(servicesManager, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, batch...)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return path.Join(results[i].Repo, results[i].Path, results[i].Name) < path.Join(results[j].Repo, results[j].Path, results[j].Name)
+	})
+
+	filesByPath, filesByChecksum = map[string][]servicesutils.ResultItem{}, map[string][]servicesutils.ResultItem{}
+	added := map[string]bool{}
+	for _, result := range results {
+		fullPath := path.Join(result.Repo, result.Path, result.Name)
+		// A file may be returned by both queries.
+		if added[fullPath] {
+			continue
+		}
+		added[fullPath] = true
+		filesByPath[path.Join(result.Path, result.Name)] = append(filesByPath[path.Join(result.Path, result.Name)], result)
+		for _, checksum := range []string{result.Actual_Sha1, result.Sha256, result.Actual_Md5} {
+			if checksum != "" {
+				filesByChecksum[checksum] = append(filesByChecksum[checksum], result)
+			}
+		}
+	}
+	return filesByPath, filesByChecksum, nil
+}
+
+func buildVerifyQueries(values []string, buildQuery func([]string) string) []string {
+	var queries []string
+	for start := 0; start < len(values); start += verifyQueryBatchSize {
+		queries = append(queries, buildQuery(values[start:min(start+verifyQueryBatchSize, len(values))]))
+	}
+	return queries
+}
+
+func buildPathsQuery(paths []string) string {
+	clauses := make([]string, len(paths))
+	for i, filePath := range paths {
+		dir, name := path.Split(filePath)
+		dir = strings.Trim(dir, "/")
+		if dir == "" {
+			dir = "."
+		}
+		clauses[i] = fmt.Sprintf(`{"$and":[{"path":%s},{"name":%s}]}`, artUtils.AqlString(dir), artUtils.AqlString(name))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include("repo","path","name","actual_sha1","sha256","actual_md5")`, strings.Join(clauses, ","))
+}
+
+func buildChecksumsQuery(checksums []string) string {
+	clauses := make([]string, len(checksums))
+	for i, checksum := range checksums {
+		field := "actual_sha1"
+		switch len(checksum) {
+		case 64:
+			field = "sha256"
+		case 32:
+			field = "actual_md5"
+		}
+		clauses[i] = fmt.Sprintf(`{"%s":%s}`, field, artUtils.AqlString(checksum))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include("repo","path","name","actual_sha1","sha256","actual_md5")`, strings.Join(clauses, ","))
+}
+
+// lookupChecksum returns the checksum used to search for the entry.
+// SHA-1 is preferred, since Artifactory may not have calculated the SHA-256 of older files.
+func lookupChecksum(checksum buildinfo.Checksum) string {
+	for _, value := range []string{checksum.Sha1, checksum.Sha256, checksum.Md5} {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// verifyEntry returns the status of an entry and the details of the status.
+// A file at the recorded path is verified first. Its repository may differ from the recorded one, since artifacts deployed
+// through a virtual repository are stored in a local repository. If no file is at the recorded path, the files with the
+// checksum of the entry are looked up, so that a file moved from its recorded path is reported as relocated rather than missing.
+func verifyEntry(entry verifiedEntry, filesByPath, filesByChecksum map[string][]servicesutils.ResultItem) (status, details string) {
+	if entry.path != "" {
+		candidates := filesByPath[entry.path]
+		if entry.repo != "" {
+			var inRepo []servicesutils.ResultItem
+			for _, candidate := range candidates {
+				if candidate.Repo == entry.repo {
+					inRepo = append(inRepo, candidate)
+				}
+			}
+			if len(inRepo) > 0 {
+				candidates = inRepo
+			}
+		}
+		for _, candidate := range candidates {
+			if len(checksumMismatches(entry.checksum, candidate)) == 0 {
+				return verifyStatusOk, ""
+			}
+		}
+		if len(candidates) > 0 {
+			return verifyStatusMismatch, describeMismatches(entry.checksum, candidates[0])
+		}
+	}
+
+	checksum := lookupChecksum(entry.checksum)
+	if checksum == "" {
+		if entry.path != "" {
+			return verifyStatusMissing, fmt.Sprintf("not found at '%s' and no checksum is recorded", entry.name)
+		}
+		return verifyStatusUnresolvable, "no path or checksum is recorded"
+	}
+	candidates := filesByChecksum[checksum]
+	for _, candidate := range candidates {
+		if len(checksumMismatches(entry.checksum, candidate)) == 0 {
+			if entry.path != "" {
+				return verifyStatusRelocated, fmt.Sprintf("not found at '%s', found at '%s'", entry.name, path.Join(candidate.Repo, candidate.Path, candidate.Name))
+			}
+			return verifyStatusOk, ""
+		}
+	}
+	if len(candidates) > 0 {
+		return verifyStatusMismatch, describeMismatches(entry.checksum, candidates[0])
+	}
+	if entry.path != "" {
+		return verifyStatusMissing, fmt.Sprintf("not found at '%s' or by checksum", entry.name)
+	}
+	return verifyStatusMissing, fmt.Sprintf("no file with checksum %s", checksum)
+}
+
+// checksumMismatches returns the recorded checksums that differ from the checksums of the file.
+// Checksums that weren't recorded or that Artifactory didn't calculate are not compared.
+func checksumMismatches(recorded buildinfo.Checksum, file servicesutils.ResultItem) []string {
+	var mismatches []string
+	for _, pair := range []struct{ algorithm, recorded, actual string }{
+		{"sha1", recorded.Sha1, file.Actual_Sha1},
+		{"sha256", recorded.Sha256, file.Sha256},
+		{"md5", recorded.Md5, file.Actual_Md5},
+	} {
+		if pair.recorded != "" && pair.actual != "" && pair.recorded != strings.ToLower(pair.actual) {
+			mismatches = append(mismatches, fmt.Sprintf("%s recorded %s, found %s", pair.algorithm, pair.recorded, pair.actual))
+		}
+	}
+	return mismatches
+}
+
+func describeMismatches(recorded buildinfo.Checksum, file servicesutils.ResultItem) string {
+	return fmt.Sprintf("'%s': %s", path.Join(file.Repo, file.Path, file.Name), strings.Join(checksumMismatches(recorded, file), "; "))
+}
+
+func buildVerifyProblemRows(entries []verifiedEntry) []buildVerifyRow {
+	var rows []buildVerifyRow
+	for _, entry := range entries {
+		if entry.status != verifyStatusOk {
+			rows = append(rows, buildVerifyRow{Module: entry.module, Type: entry.kind, Name: entry.name, Status: entry.status, Details: entry.details})
+		}
+	}
+	return rows
+}
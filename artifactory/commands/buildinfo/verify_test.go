@@ -0,0 +1,94 @@
+package buildinfo
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aqlServicesManagerMock returns the AQL results in the order of the queries.
+type aqlServicesManagerMock struct {
+	artifactory.EmptyArtifactoryServicesManager
+	aqlResults []string
+	queries    []string
+}
+
+func (asm *aqlServicesManagerMock) Aql(query string) (io.ReadCloser, error) {
+	result := asm.aqlResults[len(asm.queries)]
+	asm.queries = append(asm.queries, query)
+	return io.NopCloser(strings.NewReader(result)), nil
+}
+
+func TestVerifyBuild(t *testing.T) {
+	build := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id: "org:app:1.0",
+		Artifacts: []buildinfo.Artifact{
+			// Deployed through a virtual repository, and stored in a local one.
+			{Name: "app-1.0.jar", Path: "org/app/1.0/app-1.0.jar", OriginalDeploymentRepo: "libs-virtual", Checksum: buildinfo.Checksum{Sha1: "SHA1-APP"}},
+			{Name: "app-1.0.pom", Path: "org/app/1.0/app-1.0.pom", OriginalDeploymentRepo: "libs-release", Checksum: buildinfo.Checksum{Sha1: "sha1-pom", Sha256: "sha256-pom"}},
+			{Name: "notes.txt", OriginalDeploymentRepo: "generic-local", Checksum: buildinfo.Checksum{Sha1: "sha1-notes"}},
+			{Name: "moved.txt", Path: "moved.txt", Checksum: buildinfo.Checksum{Sha1: "sha1-moved"}},
+			{Name: "deleted.zip", Path: "dist/deleted.zip", Checksum: buildinfo.Checksum{Sha1: "sha1-deleted"}},
+			{Name: "unknown.bin"},
+		},
+		Dependencies: []buildinfo.Dependency{
+			{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Sha1: "sha1-slf4j", Md5: "md5-slf4j"}},
+			{Id: "commons-io:commons-io:2.11.0", Checksum: buildinfo.Checksum{Sha1: "sha1-io"}},
+			{Id: "lodash:4.17.21"},
+		},
+	}}}
+	entries := collectVerifiedEntries(build)
+	require.Len(t, entries, 9)
+
+	manager := &aqlServicesManagerMock{aqlResults: []string{
+		`{"results":[
+			{"repo":"libs-release-local","path":"org/app/1.0","name":"app-1.0.jar","actual_sha1":"sha1-app"},
+			{"repo":"libs-release","path":"org/app/1.0","name":"app-1.0.pom","actual_sha1":"sha1-pom","sha256":"sha256-overwritten"},
+			{"repo":"generic-local","path":".","name":"notes.txt","actual_sha1":"sha1-notes"}]}`,
+		`{"results":[
+			{"repo":"libs-release-local","path":"org/app/1.0","name":"app-1.0.jar","actual_sha1":"sha1-app"},
+			{"repo":"generic-local","path":"archive","name":"moved.txt","actual_sha1":"sha1-moved"},
+			{"repo":"maven-remote-cache","path":"org/slf4j/slf4j-api/1.7.36","name":"slf4j-api-1.7.36.jar","actual_sha1":"sha1-slf4j","actual_md5":"md5-corrupted"}]}`,
+	}}
+	filesByPath, filesByChecksum, err := findBuildFiles(manager, entries)
+	require.NoError(t, err)
+	require.Len(t, manager.queries, 2)
+	assert.Equal(t, `items.find({"$or":[{"$and":[{"path":"org/app/1.0"},{"name":"app-1.0.jar"}]},{"$and":[{"path":"org/app/1.0"},{"name":"app-1.0.pom"}]},`+
+		`{"$and":[{"path":"."},{"name":"notes.txt"}]},{"$and":[{"path":"."},{"name":"moved.txt"}]},{"$and":[{"path":"dist"},{"name":"deleted.zip"}]}]})`+
+		`.include("repo","path","name","actual_sha1","sha256","actual_md5")`, manager.queries[0])
+	assert.Contains(t, manager.queries[1], `{"actual_sha1":"sha1-app"},{"actual_sha1":"sha1-pom"}`)
+
+	for i := range entries {
+		entries[i].status, entries[i].details = verifyEntry(entries[i], filesByPath, filesByChecksum)
+	}
+	assert.Equal(t, []buildVerifyRow{
+		{Module: "org:app:1.0", Type: verifiedArtifact, Name: "libs-release/org/app/1.0/app-1.0.pom", Status: verifyStatusMismatch,
+			Details: "'libs-release/org/app/1.0/app-1.0.pom': sha256 recorded sha256-pom, found sha256-overwritten"},
+		{Module: "org:app:1.0", Type: verifiedArtifact, Name: "moved.txt", Status: verifyStatusRelocated,
+			Details: "not found at 'moved.txt', found at 'generic-local/archive/moved.txt'"},
+		{Module: "org:app:1.0", Type: verifiedArtifact, Name: "dist/deleted.zip", Status: verifyStatusMissing, Details: "not found at 'dist/deleted.zip' or by checksum"},
+		{Module: "org:app:1.0", Type: verifiedArtifact, Name: "unknown.bin", Status: verifyStatusUnresolvable, Details: "no path or checksum is recorded"},
+		{Module: "org:app:1.0", Type: verifiedDependency, Name: "org.slf4j:slf4j-api:1.7.36", Status: verifyStatusMismatch,
+			Details: "'maven-remote-cache/org/slf4j/slf4j-api/1.7.36/slf4j-api-1.7.36.jar': md5 recorded md5-slf4j, found md5-corrupted"},
+		{Module: "org:app:1.0", Type: verifiedDependency, Name: "commons-io:commons-io:2.11.0", Status: verifyStatusMissing, Details: "no file with checksum sha1-io"},
+		{Module: "org:app:1.0", Type: verifiedDependency, Name: "lodash:4.17.21", Status: verifyStatusUnresolvable, Details: "no path or checksum is recorded"},
+	}, buildVerifyProblemRows(entries))
+}
+
+func TestBuildChecksumsQuery(t *testing.T) {
+	assert.Equal(t, `items.find({"$or":[{"actual_sha1":"`+strings.Repeat("1", 40)+`"},{"sha256":"`+strings.Repeat("2", 64)+`"},{"actual_md5":"`+strings.Repeat("3", 32)+`"}]})`+
+		`.include("repo","path","name","actual_sha1","sha256","actual_md5")`,
+		buildChecksumsQuery([]string{strings.Repeat("1", 40), strings.Repeat("2", 64), strings.Repeat("3", 32)}))
+}
+
+func TestBuildPathsQuery(t *testing.T) {
+	// Quotes and backslashes in a path can't change the query.
+	assert.Equal(t, `items.find({"$or":[{"$and":[{"path":"docs/\\\"x"},{"name":"a\"},{\"name\":\"b"}]}]})`+
+		`.include("repo","path","name","actual_sha1","sha256","actual_md5")`,
+		buildPathsQuery([]string{`docs/\"x/a"},{"name":"b`}))
+}
@@ -0,0 +1,26 @@
+package builddiff
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt bdf [command options] <build name> <build number> [base build number]"}
+
+func GetDescription() string {
+	return "Compare a published build with another run of the same build. Lists the artifacts and dependencies that were added, removed or changed, and the environment variables and VCS revisions that differ."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "build name",
+			Description: "Build name.",
+		},
+		{
+			Name:        "build number",
+			Description: "The build number to compare.",
+		},
+		{
+			Name:        "base build number",
+			Description: "[Optional] The build number to compare to. If not provided, the build run published before the compared run is used.",
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package buildexport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt bex [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Export a build-info, and optionally its artifacts, to an archive with a checksum manifest. The archive can be imported to another Artifactory instance with the 'build-import' command, for example across an air gap."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "build name", Description: "Build name. Can also be provided by the JFROG_CLI_BUILD_NAME environment variable."},
+		{Name: "build number", Description: "Build number. Can also be provided by the JFROG_CLI_BUILD_NUMBER environment variable."},
+	}
+}
@@ -0,0 +1,15 @@
+package buildimport
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt bim [command options] <archive>"}
+
+func GetDescription() string {
+	return "Import a build archive created by the 'build-export' command. The archive is verified against its checksum manifest, its artifacts are uploaded and its build-info is published."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "archive", Description: "Path to the build archive."},
+	}
+}
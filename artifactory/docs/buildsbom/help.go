@@ -0,0 +1,22 @@
+package buildsbom
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt bsbom [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Export a build-info as a CycloneDX or SPDX SBOM. The modules, artifacts and dependencies of the build are listed with their package URLs, checksums and dependency relationships."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "build name",
+			Description: "Build name. Can also be provided by the JFROG_CLI_BUILD_NAME environment variable.",
+		},
+		{
+			Name:        "build number",
+			Description: "Build number. Can also be provided by the JFROG_CLI_BUILD_NUMBER environment variable.",
+		},
+	}
+}
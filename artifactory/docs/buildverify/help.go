@@ -0,0 +1,22 @@
+package buildverify
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rt bver [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Verify that every artifact and dependency of a published build still exists in Artifactory with the checksums recorded in the build-info. Fails if any of them is missing, was moved from its recorded path, has different checksums or can't be resolved."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "build name",
+			Description: "Build name.",
+		},
+		{
+			Name:        "build number",
+			Description: "Build number.",
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package formats
+
+// Structs in this file represent the in-toto statement and SLSA v1 provenance predicate generated by 'build-publish --provenance'.
+// Only the fields the command populates are declared. The 'json' annotations follow the specifications and should not be changed.
+
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     SlsaProvenance  `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type SlsaProvenance struct {
+	BuildDefinition SlsaBuildDefinition `json:"buildDefinition"`
+	RunDetails      SlsaRunDetails      `json:"runDetails"`
+}
+
+type SlsaBuildDefinition struct {
+	BuildType            string                   `json:"buildType"`
+	ExternalParameters   map[string]string        `json:"externalParameters"`
+	ResolvedDependencies []SlsaResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type SlsaResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Uri    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type SlsaRunDetails struct {
+	Builder  SlsaBuilder      `json:"builder"`
+	Metadata *SlsaRunMetadata `json:"metadata,omitempty"`
+}
+
+type SlsaBuilder struct {
+	Id      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type SlsaRunMetadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+}
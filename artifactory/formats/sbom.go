@@ -0,0 +1,101 @@
+package formats
+
+// Structs in this file represent the CycloneDX 1.5 and SPDX 2.3 JSON documents generated by the build-sbom command.
+// Only the fields the command populates are declared. The 'json' annotations follow the specifications and should not be changed.
+
+type CycloneDxBom struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDxMetadata     `json:"metadata"`
+	Components   []CycloneDxComponent  `json:"components,omitempty"`
+	Dependencies []CycloneDxDependency `json:"dependencies,omitempty"`
+}
+
+type CycloneDxMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Tools     *CycloneDxTools     `json:"tools,omitempty"`
+	Component *CycloneDxComponent `json:"component,omitempty"`
+}
+
+type CycloneDxTools struct {
+	Components []CycloneDxComponent `json:"components,omitempty"`
+}
+
+type CycloneDxComponent struct {
+	BomRef     string               `json:"bom-ref,omitempty"`
+	Type       string               `json:"type"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version,omitempty"`
+	Scope      string               `json:"scope,omitempty"`
+	Hashes     []CycloneDxHash      `json:"hashes,omitempty"`
+	Purl       string               `json:"purl,omitempty"`
+	Properties []CycloneDxProperty  `json:"properties,omitempty"`
+	Components []CycloneDxComponent `json:"components,omitempty"`
+}
+
+type CycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type CycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+type SpdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SpdxId            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SpdxCreationInfo   `json:"creationInfo"`
+	Packages          []SpdxPackage      `json:"packages,omitempty"`
+	Files             []SpdxFile         `json:"files,omitempty"`
+	Relationships     []SpdxRelationship `json:"relationships,omitempty"`
+}
+
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SpdxPackage struct {
+	SpdxId                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []SpdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []SpdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+}
+
+type SpdxFile struct {
+	SpdxId    string         `json:"SPDXID"`
+	FileName  string         `json:"fileName"`
+	Checksums []SpdxChecksum `json:"checksums"`
+}
+
+type SpdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SpdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jfrog/build-info-go/entities"
 	buildinfoflexpack "github.com/jfrog/build-info-go/flexpack"
@@ -122,6 +124,34 @@ func getDebFlag(c *components.Context) (deb string, err error) {
 	return deb, nil
 }
 
+// AqlString encodes a value as a JSON string to put into an AQL query, so that quotes and backslashes
+// in paths and names can't break or change the query.
+func AqlString(value string) string {
+	// Marshaling a string can't fail.
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// SortBuildRuns returns the runs of a build sorted by their start time, from the latest to the oldest, since the API
+// doesn't guarantee the order of the runs. Runs whose start time can't be parsed are sorted last.
+func SortBuildRuns(runs []entities.BuildRun) []entities.BuildRun {
+	started := make([]time.Time, len(runs))
+	indexes := make([]int, len(runs))
+	for i, run := range runs {
+		// The zero time of an invalid start time sorts the run last.
+		started[i], _ = time.Parse(entities.TimeFormat, run.Started)
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return started[indexes[i]].After(started[indexes[j]])
+	})
+	sorted := make([]entities.BuildRun, len(runs))
+	for i, index := range indexes {
+		sorted[i] = runs[index]
+	}
+	return sorted
+}
+
 // GetDependenciesFromLatestBuild fetches all dependencies from the latest build info
 // stored in Artifactory for the given build name. Returns a map keyed by dependency ID (name:version).
 // projectKey should match the project used when publishing the build (e.g. from buildConfig.GetProject()).
@@ -19,4 +19,12 @@ const (
 	ReleaseBundleExport       = "release-bundle-export"
 	ReleaseBundleImport       = "release-bundle-import"
 	ReleaseBundleAnnotate     = "release-bundle-annotate"
+	ReleaseBundleAnnotateBulk = "release-bundle-annotate-bulk"
+	ReleaseBundleDiff         = "release-bundle-diff"
+	ReleaseBundleApply        = "release-bundle-apply"
+	ReleaseBundleCleanup      = "release-bundle-cleanup"
+	ReleaseBundleMirror       = "release-bundle-mirror"
+	ReleaseBundleInspect      = "release-bundle-inspect"
+	ReleaseBundleStatus       = "release-bundle-status"
+	ReleaseBundleNotes        = "release-bundle-notes"
 )
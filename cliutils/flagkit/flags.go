@@ -40,6 +40,11 @@ const (
 	BuildScanLegacy        = "build-scan-legacy"
 	BuildPromote           = "build-promote"
 	BuildDiscard           = "build-discard"
+	BuildDiff              = "build-diff"
+	BuildVerify            = "build-verify"
+	BuildSbom              = "build-sbom"
+	BuildExport            = "build-export"
+	BuildImport            = "build-import"
 	BuildAddDependencies   = "build-add-dependencies"
 	BuildAddGit            = "build-add-git"
 	BuildCollectEnv        = "build-collect-env"
@@ -275,6 +280,8 @@ const (
 	dotGitPath         = "dot-git-path"
 	gitConfigFilePath  = "git-config-file-path"
 	depExclude         = "dep-exclude-scopes"
+	provenance         = "provenance"
+	provenanceOut      = "provenance-out"
 
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
@@ -310,6 +317,26 @@ const (
 	excludeBuilds      = "exclude-builds"
 	deleteArtifacts    = "delete-artifacts"
 
+	// Unique build-diff flags
+	buildDiffPrefix = "bdf-"
+	bdfFormat       = buildDiffPrefix + Format
+
+	// Unique build-sbom flags
+	buildSbomPrefix = "bsb-"
+	bsbFormat       = buildSbomPrefix + Format
+	bsbLocal        = buildSbomPrefix + "local"
+
+	// Unique build-export flags
+	buildExportPrefix   = "bex-"
+	bexPublished        = buildExportPrefix + "published"
+	bexIncludeArtifacts = buildExportPrefix + "include-artifacts"
+	bexOutput           = buildExportPrefix + "output"
+
+	// Unique build-import flags
+	buildImportPrefix = "bim-"
+	bimRepoMapping    = buildImportPrefix + "repo-mapping"
+	bimOnConflict     = buildImportPrefix + "on-conflict"
+
 	repo = "repo"
 
 	// Unique git-lfs-clean flags
@@ -494,16 +521,52 @@ const (
 	lcProperties             = lifecyclePrefix + Properties
 	DeleteProperty           = "del-prop"
 	lcDeleteProperties       = lifecyclePrefix + DeleteProperty
+	lcFormat                 = lifecyclePrefix + Format
+	ReleaseFile              = "f"
+	lcApplyDryRun            = lifecyclePrefix + "apply-" + dryRun
+	lcSpecVars               = lifecyclePrefix + specVars
+	KeepLast                 = "keep-last"
+	KeepNewerThan            = "keep-newer-than"
+	ProtectEnvironments      = "protect-environments"
+	ProtectTags              = "protect-tags"
+	LocalOnly                = "local-only"
+	lcCleanupDryRun          = lifecyclePrefix + "cleanup-" + dryRun
+	SourceServerId           = "source-server-id"
+	TargetServerId           = "target-server-id"
+	WorkingDir               = "working-dir"
+	VerifyArchive            = "verify"
+	lcPublicKey              = lifecyclePrefix + publicKey
+	Wait                     = "wait"
+	Since                    = "since"
+	lcNotesFormat            = lifecyclePrefix + "notes-" + Format
+	Attach                   = "attach"
+	EvidenceKey              = "evidence-key"
 	SourceTypeReleaseBundles = "source-type-release-bundles"
 	SourceTypeBuilds         = "source-type-builds"
 	Draft                    = "draft"
 	AddSources               = "add"
+	VersionsFile             = "versions-file"
+	lcLimit                  = lifecyclePrefix + Limit
+	lcOffset                 = lifecyclePrefix + Offset
+	lcAnnotateDryRun         = lifecyclePrefix + "annotate-" + dryRun
+	lcAnnotateQuiet          = lifecyclePrefix + "annotate-" + quiet
+	lcPromoteDryRun          = lifecyclePrefix + "promote-" + dryRun
+	FromBuildInfoFile        = "from-build-info-file"
+	FromSbom                 = "from-sbom"
 
 	// Skills commands keys
-	SkillsPublish = "skills-publish"
-	SkillsInstall = "skills-install"
-	SkillsSearch  = "skills-search"
-	SkillsDelete  = "skills-delete"
+	SkillsPublish    = "skills-publish"
+	SkillsInstall    = "skills-install"
+	SkillsSearch     = "skills-search"
+	SkillsDelete     = "skills-delete"
+	SkillsSync       = "skills-sync"
+	SkillsLint       = "skills-lint"
+	SkillsList       = "skills-list"
+	SkillsUpdate     = "skills-update"
+	SkillsPack       = "skills-pack"
+	SkillsTrust      = "skills-trust"
+	SkillsScanStatus = "skills-scan-status"
+	SkillsPromote    = "skills-promote"
 
 	// Skills-specific flags
 	version             = "version"
@@ -515,6 +578,27 @@ const (
 	skillsFormat        = "skills-" + Format
 	skipScan            = "skip-scan"
 	autoDeleteOnFailure = "auto-delete-on-failure"
+	lockFile            = "lockfile"
+	installVersion      = "skills-install-" + version
+	syncUpdate          = "update"
+	noDeps              = "no-deps"
+	lintSchema          = "schema"
+	force               = "force"
+	updateDryRun        = "skills-update-" + dryRun
+	packOutput          = "output"
+	fromFile            = "from-file"
+	publicKey           = "public-key"
+	detachedSignature   = "detached-signature"
+	signaturePolicy     = "signature-policy"
+	trustKeyName        = "skills-trust-" + name
+	scanReport          = "scan-report"
+	scanReportFormat    = "scan-report-format"
+	scanFailOn          = "fail-on"
+	promoteFrom         = "from"
+	promoteTo           = "to"
+	deleteSource        = "delete-source"
+	promoteDryRun       = "skills-promote-" + dryRun
+	installTarget       = "skills-" + target
 )
 
 var commandFlags = map[string][]string{
@@ -540,7 +624,7 @@ var commandFlags = map[string][]string{
 	},
 	cmddefs.ReleaseBundleCreate: {
 		platformUrl, user, password, accessToken, serverId, lcSigningKey, lcSync, lcProject, lcBuilds, lcReleaseBundles,
-		specFlag, specVars, BuildName, BuildNumber, SourceTypeReleaseBundles, SourceTypeBuilds, Draft,
+		specFlag, specVars, BuildName, BuildNumber, SourceTypeReleaseBundles, SourceTypeBuilds, Draft, FromBuildInfoFile, FromSbom,
 	},
 	cmddefs.ReleaseBundleUpdate: {
 		platformUrl, user, password, accessToken, serverId, lcSync, lcProject,
@@ -551,7 +635,7 @@ var commandFlags = map[string][]string{
 	},
 	cmddefs.ReleaseBundlePromote: {
 		platformUrl, user, password, accessToken, serverId, lcSigningKey, lcSync, lcProject, lcIncludeRepos,
-		lcExcludeRepos, PromotionType,
+		lcExcludeRepos, PromotionType, lcPromoteDryRun,
 	},
 	cmddefs.ReleaseBundleDistribute: {
 		platformUrl, user, password, accessToken, serverId, lcProject, DistRules, site, city, countryCodes,
@@ -569,11 +653,38 @@ var commandFlags = map[string][]string{
 		downloadMinSplit, downloadSplitCount,
 	},
 	cmddefs.ReleaseBundleImport: {
-		user, password, accessToken, serverId, platformUrl,
+		user, password, accessToken, serverId, platformUrl, VerifyArchive, lcPublicKey,
 	},
 	cmddefs.ReleaseBundleAnnotate: {
 		platformUrl, user, password, accessToken, serverId, lcProject, lcTag, lcProperties, lcDeleteProperties, propsRecursive,
 	},
+	cmddefs.ReleaseBundleAnnotateBulk: {
+		platformUrl, user, password, accessToken, serverId, lcProject, lcTag, lcProperties, lcDeleteProperties, propsRecursive,
+		FilterBy, OrderBy, OrderAsc, lcLimit, lcOffset, VersionsFile, threads, lcAnnotateDryRun, lcAnnotateQuiet,
+	},
+	cmddefs.ReleaseBundleDiff: {
+		platformUrl, user, password, accessToken, serverId, lcProject, lcFormat,
+	},
+	cmddefs.ReleaseBundleApply: {
+		platformUrl, user, password, accessToken, serverId, ReleaseFile, lcSpecVars, lcApplyDryRun,
+	},
+	cmddefs.ReleaseBundleMirror: {
+		SourceServerId, TargetServerId, lcProject, lcPathMappingPattern, lcPathMappingTarget, downloadMinSplit, downloadSplitCount,
+		WorkingDir, maxWaitMinutes,
+	},
+	cmddefs.ReleaseBundleCleanup: {
+		platformUrl, user, password, accessToken, serverId, lcProject, KeepLast, KeepNewerThan, ProtectEnvironments, ProtectTags,
+		LocalOnly, lcCleanupDryRun, deleteQuiet, maxWaitMinutes,
+	},
+	cmddefs.ReleaseBundleInspect: {
+		lcPublicKey, lcFormat,
+	},
+	cmddefs.ReleaseBundleStatus: {
+		platformUrl, user, password, accessToken, serverId, lcProject, lcFormat, Wait, maxWaitMinutes,
+	},
+	cmddefs.ReleaseBundleNotes: {
+		platformUrl, user, password, accessToken, serverId, lcProject, Since, lcNotesFormat, Attach, EvidenceKey, keyAlias,
+	},
 	AddConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
 		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin,
@@ -640,6 +751,7 @@ var commandFlags = map[string][]string{
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, InsecureTls, Project, bpDetailedSummary, bpOverwrite, collectEnv, collectGitInfo, gitConfigFilePath, dotGitPath, depExclude,
+		provenance, provenanceOut, signingKey, keyAlias,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -673,6 +785,21 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, maxDays, maxBuilds,
 		excludeBuilds, deleteArtifacts, bdiAsync, InsecureTls, Project,
 	},
+	BuildDiff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, bdfFormat, InsecureTls, Project,
+	},
+	BuildVerify: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, Project,
+	},
+	BuildSbom: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, bsbFormat, bsbLocal, InsecureTls, Project,
+	},
+	BuildExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, bexPublished, bexIncludeArtifacts, bexOutput, InsecureTls, Project,
+	},
+	BuildImport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, bimRepoMapping, bimOnConflict, InsecureTls, Project,
+	},
 	GitLfsClean: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
 		glcQuiet, InsecureTls, retries, retryWaitTime,
@@ -843,10 +970,14 @@ var commandFlags = map[string][]string{
 		Format, OrderBy, FilterBy, OrderAsc, Limit, Offset, Includes, Project,
 	},
 	SkillsPublish: {
-		url, user, password, accessToken, serverId, repo, version, signingKey, keyAlias, skillsQuiet, skipScan, autoDeleteOnFailure,
+		url, user, password, accessToken, serverId, repo, version, signingKey, keyAlias, detachedSignature, skillsQuiet, skipScan, autoDeleteOnFailure,
+		scanReport, scanReportFormat, scanFailOn,
 	},
 	SkillsInstall: {
-		url, user, password, accessToken, serverId, repo, version, installPath, skillsQuiet,
+		url, user, password, accessToken, serverId, repo, installVersion, installPath, lockFile, noDeps, force, fromFile, publicKey, signaturePolicy, installTarget, skillsQuiet,
+	},
+	SkillsSync: {
+		url, user, password, accessToken, serverId, installPath, lockFile, syncUpdate, force, signaturePolicy, skillsQuiet,
 	},
 	SkillsDelete: {
 		url, user, password, accessToken, serverId, repo, version, dryRun,
@@ -854,6 +985,28 @@ var commandFlags = map[string][]string{
 	SkillsSearch: {
 		url, user, password, accessToken, serverId, repo, skillsFormat, propSearch,
 	},
+	SkillsLint: {
+		skillsFormat, lintSchema,
+	},
+	SkillsPack: {
+		version, packOutput, signingKey, keyAlias,
+	},
+	SkillsList: {
+		installPath, skillsFormat,
+	},
+	SkillsUpdate: {
+		url, user, password, accessToken, serverId, installPath, lockFile, force, updateDryRun, signaturePolicy, skillsQuiet,
+	},
+	SkillsTrust: {
+		trustKeyName, skillsFormat,
+	},
+	SkillsScanStatus: {
+		url, user, password, accessToken, serverId, repo, scanReport, scanReportFormat, scanFailOn, skillsFormat, skillsQuiet,
+	},
+	SkillsPromote: {
+		url, user, password, accessToken, serverId, promoteFrom, promoteTo, deleteSource, promoteDryRun, signingKey, keyAlias,
+		skipScan, autoDeleteOnFailure, scanReport, scanReportFormat, scanFailOn, skillsQuiet,
+	},
 }
 
 var flagsMap = map[string]components.Flag{
@@ -994,6 +1147,8 @@ var flagsMap = map[string]components.Flag{
 	dotGitPath:        components.NewStringFlag(dotGitPath, "Path to the .git directory. If not provided, the .git directory will be searched in the current working directory or its parent directories. Only respected when collect-git-info is enabled.", components.SetMandatoryFalse()),
 	gitConfigFilePath: components.NewStringFlag(gitConfigFilePath, "Path to the git configuration file. Only respected when collect-git-info is enabled.", components.SetMandatoryFalse()),
 	depExclude:        components.NewStringFlag(depExclude, "List of semicolon-separated(;) dependency scopes to exclude from the published build info. Relevant for Package managers with supported dependency scopes (e.g. Maven, NPM). For example: \"test;provided\".", components.SetMandatoryFalse()),
+	provenance:        components.NewBoolFlag(provenance, "Set to true to generate a SLSA provenance from the build-info and attach it as signed evidence to the build and its artifacts. Requires a signing key.", components.WithBoolDefaultValueFalse()),
+	provenanceOut:     components.NewStringFlag(provenanceOut, "Path to a local file to which the SLSA provenance of the build is written, as an in-toto statement. The statement is wrapped in a signed DSSE envelope if a signing key is provided.", components.SetMandatoryFalse()),
 
 	// Build Add Dependencies specific commands flags
 	badRecursive: components.NewBoolFlag(Recursive, "[Default: true] Set to false if you do not wish to collect artifacts in sub-folders to be added to the build info.", components.WithBoolDefaultValueFalse()),
@@ -1018,6 +1173,22 @@ var flagsMap = map[string]components.Flag{
 	bprDryRun:           components.NewBoolFlag(dryRun, "If true, promotion is only simulated. The build is not promoted.", components.WithBoolDefaultValueFalse()),
 	bprProps:            components.NewStringFlag(props, "List of semicolon-separated(;) properties in the form of \"key1=value1;key2=value2;...\" to be attached to the build artifacts.", components.SetMandatoryFalse()),
 
+	// BuildDiff specific commands flags
+	bdfFormat: components.NewStringFlag(Format, "Output format: \"table\" (default), \"json\" or \"markdown\".", components.SetMandatoryFalse()),
+
+	// BuildSbom specific commands flags
+	bsbFormat: components.NewStringFlag(Format, "SBOM format: \"cyclonedx-json\" (default) or \"spdx-json\".", components.SetMandatoryFalse()),
+	bsbLocal:  components.NewBoolFlag("local", "If true, the SBOM is generated from the build-info collected locally, before it is published.", components.WithBoolDefaultValueFalse()),
+
+	// BuildExport specific commands flags
+	bexPublished:        components.NewBoolFlag("published", "If true, the build-info published to Artifactory is exported, instead of the build-info collected locally.", components.WithBoolDefaultValueFalse()),
+	bexIncludeArtifacts: components.NewBoolFlag("include-artifacts", "If true, the artifacts of the build are downloaded from Artifactory into the archive.", components.WithBoolDefaultValueFalse()),
+	bexOutput:           components.NewStringFlag("output", "Path of the archive to create. The default is <build name>-<build number>.zip in the current directory.", components.SetMandatoryFalse()),
+
+	// BuildImport specific commands flags
+	bimRepoMapping: components.NewStringFlag("repo-mapping", "Maps the repositories of the exported artifacts to the repositories they are uploaded to, in the form of \"source-repo:target-repo;...\". Unmapped repositories keep their name.", components.SetMandatoryFalse()),
+	bimOnConflict:  components.NewStringFlag("on-conflict", "What to do if the build number already exists in Artifactory: \"fail\" (default), \"skip\" or \"overwrite\".", components.SetMandatoryFalse()),
+
 	// BuildDiscard specific commands flags
 	maxDays:         components.NewStringFlag(maxDays, "The maximum number of days to keep builds in Artifactory.", components.SetMandatoryFalse()),
 	maxBuilds:       components.NewStringFlag(maxBuilds, "The maximum number of builds to store in Artifactory.", components.SetMandatoryFalse()),
@@ -1150,10 +1321,38 @@ var flagsMap = map[string]components.Flag{
 	lcTag:                    components.NewStringFlag(Tag, "Tag to put on Release Bundle version.", components.SetMandatoryFalse()),
 	lcProperties:             components.NewStringFlag(Properties, "Properties to put on the of Manifest Release Bundle version.", components.SetMandatoryFalse()),
 	lcDeleteProperties:       components.NewStringFlag(DeleteProperty, "Properties to be deleted on the of Manifest Release Bundle version.", components.SetMandatoryFalse()),
+	lcFormat:                 components.NewStringFlag(Format, "Output format: \"table\" (default) or \"json\".", components.SetMandatoryFalse()),
+	ReleaseFile:              components.NewStringFlag(ReleaseFile, "Path to the YAML release definition.", components.SetMandatoryTrue()),
+	lcApplyDryRun:            components.NewBoolFlag(dryRun, "Set to true to only print the release plan, without changing anything.", components.WithBoolDefaultValueFalse()),
+	lcSpecVars:               components.NewStringFlag(specVars, "List of semicolon-separated(;) variables in the form of \"key1=value1;key2=value2;...\" (wrapped by quotes) to be replaced in the release definition. In the release definition, the variables should be used as follows: ${key1}.", components.SetMandatoryFalse()),
+	KeepLast:                 components.NewStringFlag(KeepLast, "Number of newest release bundle versions to keep.", components.SetMandatoryFalse()),
+	KeepNewerThan:            components.NewStringFlag(KeepNewerThan, "Keep the release bundle versions created within this duration, such as '30d' or '720h'.", components.SetMandatoryFalse()),
+	ProtectEnvironments:      components.NewStringFlag(ProtectEnvironments, "List of semicolon-separated(;) environments. Versions promoted to any of these environments are never deleted.", components.SetMandatoryFalse()),
+	ProtectTags:              components.NewStringFlag(ProtectTags, "List of semicolon-separated(;) tags. Versions carrying any of these tags are never deleted.", components.SetMandatoryFalse()),
+	LocalOnly:                components.NewBoolFlag(LocalOnly, "Set to true to only delete the versions locally, without deleting them from the distribution edges.", components.WithBoolDefaultValueFalse()),
+	lcCleanupDryRun:          components.NewBoolFlag(dryRun, "Set to true to only list the versions that would be kept or deleted, and why.", components.WithBoolDefaultValueFalse()),
+	SourceServerId:           components.NewStringFlag(SourceServerId, "Server ID of the JFrog instance to export the release bundle from.", components.SetMandatoryTrue()),
+	TargetServerId:           components.NewStringFlag(TargetServerId, "Server ID of the JFrog instance to import the release bundle into.", components.SetMandatoryTrue()),
+	WorkingDir:               components.NewStringFlag(WorkingDir, "Directory for the exported archive. An archive left there by a failed import is reused by the next run.", components.WithStrDefaultValue(".")),
+	VerifyArchive:            components.NewBoolFlag(VerifyArchive, "Set to true to verify the checksums and the signatures of the archive before importing it. Requires --public-key.", components.WithBoolDefaultValueFalse()),
+	Wait:                     components.NewBoolFlag(Wait, "Set to true to wait until no promotion or distribution is in progress. The command fails if any of them failed.", components.WithBoolDefaultValueFalse()),
+	Since:                    components.NewStringFlag(Since, "Previous release bundle version. Only the commits and issues of the builds since that version are included.", components.SetMandatoryFalse()),
+	lcNotesFormat:            components.NewStringFlag(Format, "Release notes format: \"markdown\" (default), \"html\" or \"json\".", components.SetMandatoryFalse()),
+	Attach:                   components.NewStringFlag(Attach, "Attach the release notes to the release bundle: \"annotation\" records the builds and issues as properties, \"evidence\" attaches the notes as signed evidence.", components.SetMandatoryFalse()),
+	EvidenceKey:              components.NewStringFlag(EvidenceKey, "Path to the private key that signs the release notes evidence. Overrides EVD_SIGNING_KEY_PATH env var.", components.SetMandatoryFalse()),
+	lcPublicKey:              components.NewStringFlag(publicKey, "Path to the PEM public key that signed the release bundle archive. Required with --verify. If not provided to inspect, the signatures are reported as not verified.", components.SetMandatoryFalse()),
 	SourceTypeReleaseBundles: components.NewStringFlag(SourceTypeReleaseBundles, "List of semicolon-seperated(;) release bundles in the form of 'name=releaseBundleName1, version=version1; name=releaseBundleName2, version=version2' to be included in the new bundle.", components.SetMandatoryFalse()),
 	SourceTypeBuilds:         components.NewStringFlag(SourceTypeBuilds, "List of semicolon-separated(;) builds in the form of 'name=buildName1, id=runID1, include-deps=true; name=buildName2, id=runID2' to be included in the new bundle.", components.SetMandatoryFalse()),
 	Draft:                    components.NewBoolFlag(Draft, "Set to true to create the release bundle as a draft. A draft release bundle can be updated and finalized later.", components.WithBoolDefaultValueFalse()),
 	AddSources:               components.NewBoolFlag(AddSources, "Add sources to an existing draft release bundle.", components.WithBoolDefaultValueFalse()),
+	VersionsFile:             components.NewStringFlag(VersionsFile, "Path to a file listing the release bundle versions to annotate, one per line. Can't be used with the search flags.", components.SetMandatoryFalse()),
+	lcLimit:                  components.NewStringFlag(Limit, "Maximum number of versions to select. If not set, all the matching versions are selected.", components.SetMandatoryFalse()),
+	lcOffset:                 components.NewStringFlag(Offset, "Number of matching versions to skip.", components.SetMandatoryFalse()),
+	lcAnnotateDryRun:         components.NewBoolFlag(dryRun, "Set to true to only list the versions that would be annotated, without changing anything.", components.WithBoolDefaultValueFalse()),
+	lcAnnotateQuiet:          components.NewBoolFlag(quiet, "[Default: $CI] Set to true to skip the annotation confirmation message.", components.WithBoolDefaultValueFalse()),
+	FromBuildInfoFile:        components.NewStringFlag(FromBuildInfoFile, "Path to a local build-info JSON file. The artifacts of its modules are found in Artifactory by checksum and added to the release bundle.", components.SetMandatoryFalse()),
+	FromSbom:                 components.NewStringFlag(FromSbom, "Path to a local CycloneDX or SPDX JSON SBOM file. Its components are found in Artifactory by checksum and added to the release bundle.", components.SetMandatoryFalse()),
+	lcPromoteDryRun:          components.NewBoolFlag(dryRun, "Set to true to only print the promotion plan: the target path of each artifact, the files already existing there and the repositories of the environment that would be skipped.", components.WithBoolDefaultValueFalse()),
 
 	// Skills-specific flags
 	repo:                components.NewStringFlag(repo, "Skills repository key in Artifactory.", components.SetMandatoryFalse()),
@@ -1166,6 +1365,27 @@ var flagsMap = map[string]components.Flag{
 	propSearch:          components.NewBoolFlag(propSearch, "Use Artifactory property search (skill.name) instead of Skills API search.", components.WithBoolDefaultValueFalse()),
 	skipScan:            components.NewBoolFlag(skipScan, "Skip Xray security scan after publish. Can also be set via JFROG_CLI_SKIP_SKILLS_SCAN=true.", components.WithBoolDefaultValueFalse()),
 	autoDeleteOnFailure: components.NewBoolFlag(autoDeleteOnFailure, "Automatically delete the artifact if Xray scan identifies it as malicious.", components.WithBoolDefaultValueFalse()),
+	installVersion:      components.NewStringFlag(version, "Skill version (semver, e.g. 1.2.0), a version range (e.g. ^1.2, ~1.4.0, \">=2 <3\") or \"latest\".", components.SetMandatoryFalse()),
+	lockFile:            components.NewStringFlag(lockFile, "Path to the skills lockfile. Default: skills.lock in the install path.", components.SetMandatoryFalse()),
+	syncUpdate:          components.NewBoolFlag(syncUpdate, "Re-resolve every directly installed skill and its dependencies to the newest allowed versions and rewrite the lockfile.", components.WithBoolDefaultValueFalse()),
+	noDeps:              components.NewBoolFlag(noDeps, "Install only the requested skill, without the dependencies declared in its SKILL.md.", components.WithBoolDefaultValueFalse()),
+	lintSchema:          components.NewBoolFlag(lintSchema, "Print the JSON schema of the SKILL.md frontmatter and exit.", components.WithBoolDefaultValueFalse()),
+	force:               components.NewBoolFlag(force, "Overwrite files of installed skills that were modified locally.", components.WithBoolDefaultValueFalse()),
+	updateDryRun:        components.NewBoolFlag(dryRun, "Show the available updates and their file changes without installing them.", components.WithBoolDefaultValueFalse()),
+	packOutput:          components.NewStringFlag(packOutput, "Directory to write the bundle zip and its metadata file to. Must be outside the skill folder. Default: current directory.", components.SetMandatoryFalse()),
+	fromFile:            components.NewStringFlag(fromFile, "Install an offline bundle created by 'jf skills pack' instead of downloading from Artifactory. The bundle's .metadata.json file must be next to the zip.", components.SetMandatoryFalse()),
+	publicKey:           components.NewStringFlag(publicKey, "Path to a PEM public key or certificate used to verify the signature of a bundle installed with --from-file, even when --signature-policy is off. Default: the keys in the trust store, unless --signature-policy is off.", components.SetMandatoryFalse()),
+	detachedSignature:   components.NewBoolFlag(detachedSignature, "Also sign the skill zip locally and upload the signature next to it as <zip>.sig, so installs can verify it against their trust store without the evidence service. Requires a signing key.", components.WithBoolDefaultValueFalse()),
+	signaturePolicy:     components.NewStringFlag(signaturePolicy, "How to handle a missing or invalid detached signature: \"require\" fails, \"warn\" continues with a warning, \"off\" skips verification. Can also be set via JFROG_SKILLS_SIGNATURE_POLICY. Default: off.", components.SetMandatoryFalse()),
+	trustKeyName:        components.NewStringFlag(name, "Name to store the key under. Default: the key file name without its extension.", components.SetMandatoryFalse()),
+	scanReport:          components.NewStringFlag(scanReport, "Write the Xray scan gate result (status, timestamps, reasons and every poll) to this file.", components.SetMandatoryFalse()),
+	scanReportFormat:    components.NewStringFlag(scanReportFormat, "Format of the --scan-report file: \"json\" (default) or \"sarif\".", components.SetMandatoryFalse()),
+	installTarget:       components.NewStringFlag(target, "Comma-separated coding agents or editors to install the skill for: claude, codex, cursor, kiro, vscode, windsurf. Append \":global\" (e.g. claude:global) to install into the user's home directory instead of the project. With targets, --path is the project root.", components.SetMandatoryFalse()),
+	promoteFrom:         components.NewStringFlag(promoteFrom, "[Mandatory] Skills repository to promote the version from.", components.SetMandatoryTrue()),
+	promoteTo:           components.NewStringFlag(promoteTo, "[Mandatory] Skills repository to promote the version to.", components.SetMandatoryTrue()),
+	deleteSource:        components.NewBoolFlag(deleteSource, "Delete the version from the source repository once it is promoted.", components.WithBoolDefaultValueFalse()),
+	promoteDryRun:       components.NewBoolFlag(dryRun, "Check that the version can be promoted and show what would be done, without copying or deleting anything.", components.WithBoolDefaultValueFalse()),
+	scanFailOn:          components.NewStringFlag(scanFailOn, "Comma-separated scan gate outcomes that fail the command in addition to a blocked skill: unscanned, unknown, timeout, error. Can also be set via JFROG_CLI_SKILLS_SCAN_FAIL_ON. Default: none.", components.SetMandatoryFalse()),
 }
 
 func GetCommandFlags(cmdKey string) []components.Flag {
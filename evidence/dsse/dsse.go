@@ -0,0 +1,209 @@
+// Package dsse signs and verifies DSSE (Dead Simple Signing Envelope) envelopes over in-toto statements,
+// the envelope format used for evidence.
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+	InTotoPayloadType   = "application/vnd.in-toto+json"
+)
+
+// Envelope is a DSSE envelope carrying a signed in-toto statement.
+// It is the same envelope format used for evidence, so it can be signed with the evidence signing key.
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// SignEnvelope signs payload with the PEM-encoded private key (ECDSA, RSA or Ed25519) at keyPath.
+func SignEnvelope(payloadType string, payload []byte, keyPath, keyAlias string) (*Envelope, error) {
+	key, err := loadPrivateKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	message := preAuthEncoding(payloadType, payload)
+	digest := sha256.Sum256(message)
+	var sig []byte
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, k, digest[:])
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, message)
+	default:
+		err = fmt.Errorf("unsupported signing key type %T", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []EnvelopeSignature{{KeyID: keyAlias, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// ReadEnvelope reads a DSSE envelope from a JSON file.
+func ReadEnvelope(path string) (*Envelope, error) {
+	// #nosec G304 -- path is a signature file downloaded or provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+	envelope := &Envelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse signature %s: %w", path, err)
+	}
+	return envelope, nil
+}
+
+// Save writes the envelope to path as JSON.
+func (e *Envelope) Save(path string) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal signature: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write signature %s: %w", path, err)
+	}
+	return nil
+}
+
+// Verify checks that at least one signature of the envelope was made by the PEM-encoded
+// public key (or certificate) at publicKeyPath, and returns the decoded payload.
+func (e *Envelope) Verify(publicKeyPath string) ([]byte, error) {
+	key, err := LoadPublicKey(publicKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return e.VerifyWithKey(key)
+}
+
+// VerifyWithKey checks that at least one signature of the envelope was made by key, and returns the decoded payload.
+func (e *Envelope) VerifyWithKey(key crypto.PublicKey) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope payload: %w", err)
+	}
+	if len(e.Signatures) == 0 {
+		return nil, errors.New("envelope has no signatures")
+	}
+
+	message := preAuthEncoding(e.PayloadType, payload)
+	digest := sha256.Sum256(message)
+	for _, s := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		var ok bool
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			ok = ecdsa.VerifyASN1(k, digest[:], sig)
+		case *rsa.PublicKey:
+			ok = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+		case ed25519.PublicKey:
+			ok = ed25519.Verify(k, message, sig)
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", key)
+		}
+		if ok {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("signature does not match the public key")
+}
+
+// preAuthEncoding returns the DSSE v1 pre-authentication encoding that is actually signed.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func readPEM(path, kind string) (*pem.Block, error) {
+	// #nosec G304 -- path is a key file provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s: %w", kind, path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s %s is not PEM encoded", kind, path)
+	}
+	return block, nil
+}
+
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path, "signing key")
+	if err != nil {
+		return nil, err
+	}
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported signing key %s: PEM type '%s' (encrypted keys are not supported)", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+	return signer, nil
+}
+
+// LoadPublicKey reads a PEM-encoded public key (PKIX or PKCS #1) or certificate.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path, "public key")
+	if err != nil {
+		return nil, err
+	}
+	var key crypto.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key %s: PEM type '%s'", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,90 @@
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a PEM private key and its PKIX public key into dir and returns their paths.
+func writeKeyPair(t *testing.T, dir, name string, private crypto.Signer) (string, string) {
+	privDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+
+	privPath := filepath.Join(dir, name+".key")
+	pubPath := filepath.Join(dir, name+".pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+	return privPath, pubPath
+}
+
+func TestEnvelope_SignAndVerify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"ecdsa": ecKey, "rsa": rsaKey, "ed25519": edKey} {
+		t.Run(name, func(t *testing.T) {
+			privPath, pubPath := writeKeyPair(t, t.TempDir(), name, key)
+			payload := []byte(`{"hello":"world"}`)
+
+			env, err := SignEnvelope(InTotoPayloadType, payload, privPath, "my-alias")
+			require.NoError(t, err)
+			assert.Equal(t, InTotoPayloadType, env.PayloadType)
+			require.Len(t, env.Signatures, 1)
+			assert.Equal(t, "my-alias", env.Signatures[0].KeyID)
+
+			verified, err := env.Verify(pubPath)
+			require.NoError(t, err)
+			assert.Equal(t, payload, verified)
+
+			// The payload type is part of the signed message.
+			env.PayloadType = "text/plain"
+			_, err = env.Verify(pubPath)
+			assert.ErrorContains(t, err, "signature does not match")
+		})
+	}
+}
+
+func TestEnvelope_VerifyWithWrongKey(t *testing.T) {
+	dir := t.TempDir()
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPath, _ := writeKeyPair(t, dir, "signer", signer)
+	_, otherPub := writeKeyPair(t, dir, "other", other)
+
+	env, err := SignEnvelope(InTotoPayloadType, []byte("{}"), privPath, "")
+	require.NoError(t, err)
+	_, err = env.Verify(otherPub)
+	assert.ErrorContains(t, err, "signature does not match")
+}
+
+func TestSignEnvelope_InvalidKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0600))
+
+	_, err := SignEnvelope(InTotoPayloadType, []byte("{}"), path, "")
+	assert.ErrorContains(t, err, "is not PEM encoded")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}}), 0600))
+	_, err = SignEnvelope(InTotoPayloadType, []byte("{}"), path, "")
+	assert.ErrorContains(t, err, "encrypted keys are not supported")
+}
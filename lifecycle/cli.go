@@ -15,14 +15,22 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
 	lifecycle "github.com/jfrog/jfrog-cli-artifactory/lifecycle/commands"
 	rbAnnotate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/annotate"
+	rbAnnotateBulk "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/annotatebulk"
+	rbApply "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/apply"
+	rbCleanup "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/cleanup"
 	rbCreate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/create"
 	rbDeleteLocal "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/deletelocal"
 	rbDeleteRemote "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/deleteremote"
+	rbDiff "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/diff"
 	rbDistribute "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/distribute"
 	rbExport "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/export"
 	rbFinalize "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/finalize"
 	rbImport "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/importbundle"
+	rbInspect "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/inspect"
+	rbMirror "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/mirror"
+	rbNotes "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/notes"
 	rbPromote "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/promote"
+	rbStatus "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/status"
 	rbUpdate "github.com/jfrog/jfrog-cli-artifactory/lifecycle/docs/update"
 	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
@@ -135,6 +143,15 @@ func GetCommands() []components.Command {
 			Category:    lcCategory,
 			Action:      annotate,
 		},
+		{
+			Name:        cmddefs.ReleaseBundleAnnotateBulk,
+			Aliases:     []string{"rbab"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleAnnotateBulk),
+			Description: rbAnnotateBulk.GetDescription(),
+			Arguments:   rbAnnotateBulk.GetArguments(),
+			Category:    lcCategory,
+			Action:      annotateBulk,
+		},
 		{
 			Name:        "release-bundle-search",
 			Aliases:     []string{"rbs"},
@@ -144,6 +161,68 @@ func GetCommands() []components.Command {
 			Category:    lcCategory,
 			Action:      releaseBundleSearch,
 		},
+		{
+			Name:        cmddefs.ReleaseBundleDiff,
+			Aliases:     []string{"rbdiff"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleDiff),
+			Description: rbDiff.GetDescription(),
+			Arguments:   rbDiff.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleDiff,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleApply,
+			Aliases:     []string{"rbapply"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleApply),
+			Description: rbApply.GetDescription(),
+			Category:    lcCategory,
+			Action:      releaseBundleApply,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleCleanup,
+			Aliases:     []string{"rbclean"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleCleanup),
+			Description: rbCleanup.GetDescription(),
+			Arguments:   rbCleanup.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleCleanup,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleMirror,
+			Aliases:     []string{"rbmirror"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleMirror),
+			Description: rbMirror.GetDescription(),
+			Arguments:   rbMirror.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleMirror,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleInspect,
+			Aliases:     []string{"rbinspect"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleInspect),
+			Description: rbInspect.GetDescription(),
+			Arguments:   rbInspect.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleInspect,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleStatus,
+			Aliases:     []string{"rbstatus"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleStatus),
+			Description: rbStatus.GetDescription(),
+			Arguments:   rbStatus.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleStatus,
+		},
+		{
+			Name:        cmddefs.ReleaseBundleNotes,
+			Aliases:     []string{"rbnotes"},
+			Flags:       flagkit.GetCommandFlags(cmddefs.ReleaseBundleNotes),
+			Description: rbNotes.GetDescription(),
+			Arguments:   rbNotes.GetArguments(),
+			Category:    lcCategory,
+			Action:      releaseBundleNotes,
+		},
 	}
 }
 
@@ -165,6 +244,8 @@ func assertValidCreationMethod(c *components.Context) error {
 		c.IsFlagSet("spec"),
 		c.IsFlagSet(flagkit.Builds),
 		c.IsFlagSet(flagkit.ReleaseBundles),
+		c.IsFlagSet(flagkit.FromBuildInfoFile),
+		c.IsFlagSet(flagkit.FromSbom),
 	}
 	methodCount := coreutils.SumTrueValues(monoReleaseBundleSource)
 
@@ -196,9 +277,9 @@ func validateCreationMethods(c *components.Context, regularMethodsCount int, mul
 		}
 		if regularMethodsCount > 0 {
 			errMsg := fmt.Sprintf("only multiple sources must be supplied: --%s, --%s,\n"+
-				"or one of: --%s, --%s or --%s",
+				"or one of: --%s, --%s, --%s, --%s or --%s",
 				flagkit.SourceTypeReleaseBundles, flagkit.SourceTypeBuilds,
-				"spec", flagkit.Builds, flagkit.ReleaseBundles)
+				"spec", flagkit.Builds, flagkit.ReleaseBundles, flagkit.FromBuildInfoFile, flagkit.FromSbom)
 			return errorutils.CheckError(errors.New(errMsg))
 		}
 		return nil
@@ -209,9 +290,9 @@ func validateCreationMethods(c *components.Context, regularMethodsCount int, mul
 func validateSingleCreationMethod(methodCount int) error {
 	if methodCount > 1 {
 		return errorutils.CheckErrorf(
-			"exactly one creation source must be supplied: --%s, --%s, or --%s.\n"+
+			"exactly one creation source must be supplied: --%s, --%s, --%s, --%s or --%s.\n"+
 				"Opt to use the --%s option as the --%s and --%s are deprecated",
-			"spec", flagkit.Builds, flagkit.ReleaseBundles,
+			"spec", flagkit.Builds, flagkit.ReleaseBundles, flagkit.FromBuildInfoFile, flagkit.FromSbom,
 			"spec", flagkit.Builds, flagkit.ReleaseBundles,
 		)
 	}
@@ -253,7 +334,8 @@ func create(c *components.Context) (err error) {
 		SetReleaseBundleVersion(c.GetArgumentAt(1)).SetSigningKeyName(c.GetStringFlagValue(flagkit.SigningKey)).
 		SetSync(c.GetBoolFlagValue(flagkit.Sync)).SetDraft(c.GetBoolFlagValue(flagkit.Draft)).
 		SetReleaseBundleProject(pluginsCommon.GetProject(c)).SetSpec(creationSpec).
-		SetBuildsSpecPath(c.GetStringFlagValue(flagkit.Builds)).SetReleaseBundlesSpecPath(c.GetStringFlagValue(flagkit.ReleaseBundles))
+		SetBuildsSpecPath(c.GetStringFlagValue(flagkit.Builds)).SetReleaseBundlesSpecPath(c.GetStringFlagValue(flagkit.ReleaseBundles)).
+		SetBuildInfoFilePath(c.GetStringFlagValue(flagkit.FromBuildInfoFile)).SetSbomFilePath(c.GetStringFlagValue(flagkit.FromSbom))
 
 	err = lifecycle.ValidateFeatureSupportedVersion(lcDetails, minArtifactoryVersionForMultiSourceSupport)
 	// err == nil means new flags are supported and may be added to createCmd
@@ -375,6 +457,11 @@ func getReleaseBundleCreationSpec(c *components.Context) (*speccore.SpecFiles, e
 		return nil, nil
 	}
 
+	// The local build-info and SBOM files are resolved to artifacts by the command
+	if c.IsFlagSet(flagkit.FromBuildInfoFile) || c.IsFlagSet(flagkit.FromSbom) {
+		return nil, nil
+	}
+
 	// Check if the "spec" flag is set - if so, return the spec
 	if c.IsFlagSet("spec") {
 		return commonCliUtils.GetSpec(c, true, false)
@@ -418,7 +505,7 @@ func promote(c *components.Context) error {
 		SetReleaseBundleVersion(c.GetArgumentAt(1)).SetEnvironment(c.GetArgumentAt(2)).SetSigningKeyName(c.GetStringFlagValue(flagkit.SigningKey)).
 		SetSync(c.GetBoolFlagValue(flagkit.Sync)).SetReleaseBundleProject(pluginsCommon.GetProject(c)).
 		SetIncludeReposPatterns(splitRepos(c, flagkit.IncludeRepos)).SetExcludeReposPatterns(splitRepos(c, flagkit.ExcludeRepos)).
-		SetPromotionType(c.GetStringFlagValue(flagkit.PromotionType))
+		SetPromotionType(c.GetStringFlagValue(flagkit.PromotionType)).SetDryRun(c.GetBoolFlagValue("dry-run"))
 	return commands.Exec(promoteCmd)
 }
 
@@ -557,7 +644,9 @@ func releaseBundleImport(c *components.Context) error {
 	}
 	importCmd.
 		SetServerDetails(rtDetails).
-		SetFilepath(c.GetArgumentAt(0))
+		SetFilepath(c.GetArgumentAt(0)).
+		SetVerify(c.GetBoolFlagValue(flagkit.VerifyArchive)).
+		SetPublicKeyPath(c.GetStringFlagValue("public-key"))
 
 	return commands.Exec(importCmd)
 }
@@ -602,6 +691,68 @@ func annotate(c *components.Context) error {
 	return commands.Exec(annotateCmd)
 }
 
+func annotateBulk(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	tagExist := c.IsFlagSet(flagkit.Tag)
+	if !tagExist && !c.IsFlagSet(flagkit.Properties) && !c.IsFlagSet(flagkit.DeleteProperty) {
+		return errors.New("action is not specified. One of tag/properties/del-prop should be specified")
+	}
+	searchFlagSet := c.IsFlagSet(flagkit.FilterBy) || c.IsFlagSet(flagkit.OrderBy) || c.IsFlagSet(flagkit.OrderAsc) ||
+		c.IsFlagSet(flagkit.Limit) || c.IsFlagSet(flagkit.Offset)
+	if c.IsFlagSet(flagkit.VersionsFile) && searchFlagSet {
+		return errorutils.CheckErrorf("the --%s option can't be used with the --%s, --%s, --%s, --%s and --%s options",
+			flagkit.VersionsFile, flagkit.FilterBy, flagkit.OrderBy, flagkit.OrderAsc, flagkit.Limit, flagkit.Offset)
+	}
+	limit, err := c.GetDefaultIntFlagValueIfNotSet(flagkit.Limit, 0)
+	if err != nil {
+		return err
+	}
+	offset, err := c.GetDefaultIntFlagValueIfNotSet(flagkit.Offset, 0)
+	if err != nil {
+		return err
+	}
+	threads, err := pluginsCommon.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	project := pluginsCommon.GetProject(c)
+	if project == "" {
+		project = "default"
+	}
+	versionsQuery := lifecycle.NewSearchVersionsCommand().
+		SetFilterBy(c.GetStringFlagValue(flagkit.FilterBy)).
+		SetOrderBy(c.GetStringFlagValue(flagkit.OrderBy)).
+		SetOrderAsc(c.GetBoolFlagValue(flagkit.OrderAsc)).
+		SetLimit(limit).
+		SetOffset(offset)
+	annotateBulkCmd := lifecycle.NewReleaseBundleAnnotateBulkCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleProject(project).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetVersionsQuery(versionsQuery).
+		SetVersionsFile(c.GetStringFlagValue(flagkit.VersionsFile)).
+		SetTag(c.GetStringFlagValue(flagkit.Tag), tagExist).
+		SetProps(c.GetStringFlagValue(flagkit.Properties)).
+		DeleteProps(c.GetStringFlagValue(flagkit.DeleteProperty)).
+		SetRecursive(c.GetBoolFlagValue(flagkit.Recursive), c.IsFlagSet(flagkit.Recursive)).
+		SetThreads(threads).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).
+		SetQuiet(pluginsCommon.GetQuietValue(c))
+	return commands.Exec(annotateBulkCmd)
+}
+
 func validateDistributeCommand(c *components.Context) error {
 	if err := distribution.ValidateReleaseBundleDistributeCmd(c); err != nil {
 		return err
@@ -739,6 +890,240 @@ func releaseBundleSearch(c *components.Context) error {
 	}
 }
 
+func releaseBundleDiff(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 3 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	diffCmd := lifecycle.NewReleaseBundleDiffCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetFromVersion(c.GetArgumentAt(1)).
+		SetToVersion(c.GetArgumentAt(2)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetOutputFormat(c.GetStringFlagValue(flagkit.Format))
+	return commands.Exec(diffCmd)
+}
+
+func releaseBundleApply(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 0 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.GetStringFlagValue(flagkit.ReleaseFile) == "" {
+		return errorutils.CheckErrorf("the release definition must be provided with -%s", flagkit.ReleaseFile)
+	}
+
+	pipeline, err := lifecycle.LoadReleasePipeline(c.GetStringFlagValue(flagkit.ReleaseFile), coreutils.SpecVarsStringToMap(c.GetStringFlagValue("spec-vars")))
+	if err != nil {
+		return err
+	}
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	applyCmd := lifecycle.NewReleaseBundleApplyCommand().
+		SetServerDetails(lcDetails).
+		SetPipeline(pipeline).
+		SetDryRun(c.GetBoolFlagValue("dry-run"))
+	return commands.Exec(applyCmd)
+}
+
+func releaseBundleCleanup(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	policy := lifecycle.RetentionPolicy{
+		ProtectedEnvironments: splitRepos(c, flagkit.ProtectEnvironments),
+		ProtectedTags:         splitRepos(c, flagkit.ProtectTags),
+	}
+	var err error
+	if policy.KeepLast, err = c.GetDefaultIntFlagValueIfNotSet(flagkit.KeepLast, 0); err != nil {
+		return err
+	}
+	if c.IsFlagSet(flagkit.KeepNewerThan) {
+		if policy.KeepNewerThan, err = lifecycle.ParseRetentionDuration(c.GetStringFlagValue(flagkit.KeepNewerThan)); err != nil {
+			return err
+		}
+	}
+	maxWaitMinutes, err := c.GetDefaultIntFlagValueIfNotSet("max-wait-minutes", 60)
+	if err != nil {
+		return err
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	cleanupCmd := lifecycle.NewReleaseBundleCleanupCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetRetentionPolicy(policy).
+		SetLocalOnly(c.GetBoolFlagValue(flagkit.LocalOnly)).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).
+		SetQuiet(pluginsCommon.GetQuietValue(c)).
+		SetMaxWaitMinutes(maxWaitMinutes)
+	return commands.Exec(cleanupCmd)
+}
+
+func releaseBundleMirror(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 2 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	sourceDetails, err := createLifecycleDetailsByServerId(c.GetStringFlagValue(flagkit.SourceServerId), flagkit.SourceServerId)
+	if err != nil {
+		return err
+	}
+	targetDetails, err := createLifecycleDetailsByServerId(c.GetStringFlagValue(flagkit.TargetServerId), flagkit.TargetServerId)
+	if err != nil {
+		return err
+	}
+	downloadConfig, err := CreateDownloadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	maxWaitMinutes, err := c.GetDefaultIntFlagValueIfNotSet("max-wait-minutes", 10)
+	if err != nil {
+		return err
+	}
+
+	mirrorCmd := lifecycle.NewReleaseBundleMirrorCommand().
+		SetSourceServerDetails(sourceDetails).
+		SetTargetServerDetails(targetDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleVersion(c.GetArgumentAt(1)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetModifications(services.Modifications{
+			PathMappings: []artClientUtils.PathMapping{
+				{
+					Input:  c.GetStringFlagValue(flagkit.PathMappingPattern),
+					Output: c.GetStringFlagValue(flagkit.PathMappingTarget),
+				},
+			},
+		}).
+		SetDownloadConfiguration(*downloadConfig).
+		SetWorkingDir(c.GetStringFlagValue(flagkit.WorkingDir)).
+		SetMaxWaitMinutes(maxWaitMinutes)
+	return commands.Exec(mirrorCmd)
+}
+
+func releaseBundleInspect(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 1 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	inspectCmd := lifecycle.NewReleaseBundleInspectCommand().
+		SetArchivePath(c.GetArgumentAt(0)).
+		SetPublicKeyPath(c.GetStringFlagValue("public-key")).
+		SetOutputFormat(c.GetStringFlagValue(flagkit.Format))
+	return commands.Exec(inspectCmd)
+}
+
+func releaseBundleStatus(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 2 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	maxWaitMinutes, err := c.GetDefaultIntFlagValueIfNotSet("max-wait-minutes", 60)
+	if err != nil {
+		return err
+	}
+
+	statusCmd := lifecycle.NewReleaseBundleStatusCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleVersion(c.GetArgumentAt(1)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetWait(c.GetBoolFlagValue(flagkit.Wait)).
+		SetMaxWaitMinutes(maxWaitMinutes).
+		SetOutputFormat(c.GetStringFlagValue(flagkit.Format))
+	return commands.Exec(statusCmd)
+}
+
+func releaseBundleNotes(c *components.Context) error {
+	if show, err := pluginsCommon.ShowCmdHelpIfNeeded(c, c.Arguments); show || err != nil {
+		return err
+	}
+
+	if c.GetNumberOfArgs() != 2 {
+		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
+	}
+
+	lcDetails, err := createLifecycleDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	keyPath := c.GetStringFlagValue(flagkit.EvidenceKey)
+	if keyPath == "" {
+		keyPath = os.Getenv("EVD_SIGNING_KEY_PATH")
+	}
+	keyAlias := c.GetStringFlagValue("key-alias")
+	if keyAlias == "" {
+		keyAlias = os.Getenv("EVD_KEY_ALIAS")
+	}
+
+	notesCmd := lifecycle.NewReleaseBundleNotesCommand().
+		SetServerDetails(lcDetails).
+		SetReleaseBundleName(c.GetArgumentAt(0)).
+		SetReleaseBundleVersion(c.GetArgumentAt(1)).
+		SetReleaseBundleProject(pluginsCommon.GetProject(c)).
+		SetSinceVersion(c.GetStringFlagValue(flagkit.Since)).
+		SetOutputFormat(c.GetStringFlagValue(flagkit.Format)).
+		SetAttach(c.GetStringFlagValue(flagkit.Attach)).
+		SetEvidenceKey(keyPath, keyAlias)
+	return commands.Exec(notesCmd)
+}
+
+// createLifecycleDetailsByServerId returns the details of a configured server, for commands that work with two servers.
+func createLifecycleDetailsByServerId(serverId, flagName string) (*config.ServerDetails, error) {
+	if serverId == "" {
+		return nil, errorutils.CheckErrorf("the --%s option is mandatory", flagName)
+	}
+	lcDetails, err := config.GetSpecificConfig(serverId, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if lcDetails.Url == "" {
+		return nil, fmt.Errorf("platform URL is not configured for server ID '%s'", serverId)
+	}
+	PlatformToLifecycleUrls(lcDetails)
+	return lcDetails, nil
+}
+
 func GetReleaseBundleGroupCmd(c *components.Context, lcDetails *config.ServerDetails, offset, limit int) (err error) {
 	if len(c.Arguments) != 1 {
 		return pluginsCommon.WrongNumberOfArgumentsHandler(c)
@@ -41,6 +41,12 @@ func TestValidateCreateReleaseBundleContext(t *testing.T) {
 			"spec=/path/to/file", flagkit.SigningKey + "=key"}, false},
 		{"builds with draft flag", []string{"name", "version"}, []string{
 			flagkit.Builds + "=/path/to/file", flagkit.SigningKey + "=key"}, false},
+		{"build-info file", []string{"name", "version"}, []string{flagkit.FromBuildInfoFile + "=/path/to/build.json"}, false},
+		{"sbom file", []string{"name", "version"}, []string{flagkit.FromSbom + "=/path/to/bom.json"}, false},
+		{"sbom and spec", []string{"name", "version"}, []string{
+			flagkit.FromSbom + "=/path/to/bom.json", "spec=/path/to/file"}, true},
+		{"build-info file and sbom", []string{"name", "version"}, []string{
+			flagkit.FromBuildInfoFile + "=/path/to/build.json", flagkit.FromSbom + "=/path/to/bom.json"}, true},
 	}
 
 	for _, test := range testRuns {
@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	defaultAnnotateBulkThreads = 3
+	annotateResultAnnotated    = "annotated"
+	annotateResultFailed       = "failed"
+)
+
+type annotateBulkPlanRow struct {
+	Version string `col-name:"Version"`
+}
+
+type annotateBulkResultRow struct {
+	Version string `col-name:"Version"`
+	Result  string `col-name:"Result"`
+	Error   string `col-name:"Error"`
+}
+
+// ReleaseBundleAnnotateBulkCommand applies the same tag and property changes to many versions of a release bundle.
+// The versions are selected with the filter and order of the versions search, or listed in a file.
+type ReleaseBundleAnnotateBulkCommand struct {
+	releaseBundleCmd
+	versionsQuery    *SearchVersionsCommand
+	versionsFile     string
+	tag              string
+	tagExist         bool
+	props            string
+	deleteProps      string
+	recursive        bool
+	recursiveFlagSet bool
+	threads          int
+	dryRun           bool
+	quiet            bool
+}
+
+func NewReleaseBundleAnnotateBulkCommand() *ReleaseBundleAnnotateBulkCommand {
+	return &ReleaseBundleAnnotateBulkCommand{versionsQuery: NewSearchVersionsCommand()}
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleAnnotateBulkCommand {
+	rbab.serverDetails = serverDetails
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleAnnotateBulkCommand {
+	rbab.releaseBundleName = releaseBundleName
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleAnnotateBulkCommand {
+	rbab.rbProjectKey = rbProjectKey
+	return rbab
+}
+
+// SetVersionsQuery sets the search that selects the versions to annotate. Its filter, order, offset and limit are used,
+// while the release bundle name and project are taken from this command.
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetVersionsQuery(versionsQuery *SearchVersionsCommand) *ReleaseBundleAnnotateBulkCommand {
+	rbab.versionsQuery = versionsQuery
+	return rbab
+}
+
+// SetVersionsFile sets a file listing the versions to annotate, one per line, instead of searching for them.
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetVersionsFile(versionsFile string) *ReleaseBundleAnnotateBulkCommand {
+	rbab.versionsFile = versionsFile
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetTag(tag string, exist bool) *ReleaseBundleAnnotateBulkCommand {
+	rbab.tag = tag
+	rbab.tagExist = exist
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetProps(props string) *ReleaseBundleAnnotateBulkCommand {
+	rbab.props = props
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) DeleteProps(deleteProps string) *ReleaseBundleAnnotateBulkCommand {
+	rbab.deleteProps = deleteProps
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetRecursive(recursive, flagIsSet bool) *ReleaseBundleAnnotateBulkCommand {
+	rbab.recursive = recursive
+	rbab.recursiveFlagSet = flagIsSet
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetThreads(threads int) *ReleaseBundleAnnotateBulkCommand {
+	rbab.threads = threads
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetDryRun(dryRun bool) *ReleaseBundleAnnotateBulkCommand {
+	rbab.dryRun = dryRun
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) SetQuiet(quiet bool) *ReleaseBundleAnnotateBulkCommand {
+	rbab.quiet = quiet
+	return rbab
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) CommandName() string {
+	return "rb_annotate_bulk"
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbab.serverDetails, nil
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) Run() error {
+	if !rbab.tagExist && rbab.props == "" && rbab.deleteProps == "" {
+		return errorutils.CheckErrorf("action is not specified. One of tag/properties/del-prop should be specified")
+	}
+	if err := ValidateFeatureSupportedVersion(rbab.serverDetails, minSetTagArtifactoryVersion); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateLifecycleServiceManager(rbab.serverDetails, false)
+	if err != nil {
+		return err
+	}
+
+	versions, err := rbab.selectVersions(servicesManager)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		log.Info(fmt.Sprintf("No versions of release bundle '%s' matched.", rbab.releaseBundleName))
+		return nil
+	}
+	if err = printAnnotateBulkPlan(versions, rbab.dryRun); err != nil {
+		return err
+	}
+	if rbab.dryRun {
+		log.Info(fmt.Sprintf("Dry run: would %s on %d versions of release bundle '%s'.", rbab.describeChanges(), len(versions), rbab.releaseBundleName))
+		return nil
+	}
+	if !rbab.confirmAnnotate(len(versions)) {
+		return nil
+	}
+
+	results := annotateVersions(versions, rbab.threads, func(version string) error {
+		return rbab.annotateVersion(servicesManager, version)
+	})
+	return rbab.reportResults(results)
+}
+
+// selectVersions returns the versions listed in the versions file, or the versions matching the versions query.
+// When the query has a limit, only a single page is fetched, so that the limit caps the number of annotated versions.
+func (rbab *ReleaseBundleAnnotateBulkCommand) selectVersions(servicesManager *lifecycle.LifecycleServicesManager) ([]string, error) {
+	if rbab.versionsFile != "" {
+		return readVersionsFile(rbab.versionsFile)
+	}
+	query := rbab.versionsQuery.SetReleaseBundleName(rbab.releaseBundleName).SetProject(rbab.rbProjectKey)
+	var found []services.ReleaseBundleVersion
+	if query.limit > 0 {
+		response, err := servicesManager.ReleaseBundlesSearchVersions(rbab.releaseBundleName, query.queryParams())
+		if err != nil {
+			return nil, err
+		}
+		found = response.ReleaseBundles
+	} else {
+		var err error
+		if found, err = query.searchAllVersions(servicesManager); err != nil {
+			return nil, err
+		}
+	}
+	versions := make([]string, len(found))
+	for i, version := range found {
+		versions[i] = version.ReleaseBundleVersion
+	}
+	return versions, nil
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) annotateVersion(servicesManager *lifecycle.LifecycleServicesManager, version string) error {
+	annotateCmd := NewReleaseBundleAnnotateCommand().
+		SetServerDetails(rbab.serverDetails).
+		SetReleaseBundleName(rbab.releaseBundleName).
+		SetReleaseBundleVersion(version).
+		SetReleaseBundleProject(rbab.rbProjectKey).
+		SetTag(rbab.tag, rbab.tagExist).
+		SetProps(rbab.props).
+		DeleteProps(rbab.deleteProps).
+		SetRecursive(rbab.recursive, rbab.recursiveFlagSet)
+	rbDetails := services.ReleaseBundleDetails{ReleaseBundleName: rbab.releaseBundleName, ReleaseBundleVersion: version}
+	queryParams := services.CommonOptionalQueryParams{ProjectKey: rbab.rbProjectKey}
+	return servicesManager.AnnotateReleaseBundle(BuildAnnotationOperationParams(annotateCmd, rbDetails, queryParams))
+}
+
+// describeChanges describes the annotation changes, for the confirmation message and the dry run.
+func (rbab *ReleaseBundleAnnotateBulkCommand) describeChanges() string {
+	var changes []string
+	if rbab.tagExist {
+		if rbab.tag == "" {
+			changes = append(changes, "remove the tag")
+		} else {
+			changes = append(changes, fmt.Sprintf("set the tag '%s'", rbab.tag))
+		}
+	}
+	if rbab.props != "" {
+		changes = append(changes, fmt.Sprintf("set the properties '%s'", rbab.props))
+	}
+	if rbab.deleteProps != "" {
+		changes = append(changes, fmt.Sprintf("delete the properties '%s'", rbab.deleteProps))
+	}
+	return strings.Join(changes, ", ")
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) confirmAnnotate(count int) bool {
+	if rbab.quiet {
+		return true
+	}
+	return coreutils.AskYesNo(
+		fmt.Sprintf("Are you sure you want to %s on %d versions of release bundle '%s'?\n"+avoidConfirmationMsg, rbab.describeChanges(), count, rbab.releaseBundleName), false)
+}
+
+func (rbab *ReleaseBundleAnnotateBulkCommand) reportResults(results []annotateBulkResultRow) error {
+	failed := 0
+	for _, result := range results {
+		if result.Result == annotateResultFailed {
+			failed++
+		}
+	}
+	if err := coreutils.PrintTable(results, "Release bundle annotation", "No versions were annotated", false); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Release bundle versions annotated successfully: %d, failed: %d", len(results)-failed, failed))
+	if failed > 0 {
+		return errorutils.CheckErrorf("failed to annotate %d of %d versions of release bundle '%s'", failed, len(results), rbab.releaseBundleName)
+	}
+	return nil
+}
+
+// annotateVersions annotates the versions concurrently, with at most the given number of threads.
+// A failure in one version doesn't stop the others. The results are in the order of the versions.
+func annotateVersions(versions []string, threads int, annotate func(version string) error) []annotateBulkResultRow {
+	if threads <= 0 {
+		threads = defaultAnnotateBulkThreads
+	}
+	var (
+		results = make([]annotateBulkResultRow, len(versions))
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, threads)
+	)
+	for i, version := range versions {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, version string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = annotateBulkResultRow{Version: version, Result: annotateResultAnnotated}
+			if err := annotate(version); err != nil {
+				log.Warn(fmt.Sprintf("Failed to annotate version '%s': %s", version, err.Error()))
+				results[i].Result = annotateResultFailed
+				results[i].Error = err.Error()
+			}
+		}(i, version)
+	}
+	wg.Wait()
+	return results
+}
+
+// readVersionsFile reads a file listing one version per line. Empty lines, lines starting with '#' and repeated versions are skipped.
+func readVersionsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var versions []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		version := strings.TrimSpace(scanner.Text())
+		if version == "" || strings.HasPrefix(version, "#") || seen[version] {
+			continue
+		}
+		seen[version] = true
+		versions = append(versions, version)
+	}
+	return versions, errorutils.CheckError(scanner.Err())
+}
+
+func printAnnotateBulkPlan(versions []string, dryRun bool) error {
+	rows := make([]annotateBulkPlanRow, len(versions))
+	for i, version := range versions {
+		rows[i] = annotateBulkPlanRow{Version: version}
+	}
+	title := "Release bundle versions to annotate"
+	if dryRun {
+		title += " (dry run)"
+	}
+	return coreutils.PrintTable(rows, title, "No release bundle versions found", false)
+}
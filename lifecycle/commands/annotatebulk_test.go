@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadVersionsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# affected by CVE-2026-1234\n1.0.0\n\n  1.1.0  \n1.0.0\n#1.2.0\n2.0.0"), 0644))
+	versions, err := readVersionsFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "2.0.0"}, versions)
+
+	_, err = readVersionsFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestAnnotateVersions(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	results := annotateVersions([]string{"1.0.0", "1.1.0", "1.2.0", "2.0.0"}, 2, func(version string) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		if version == "1.1.0" {
+			return errors.New("not found")
+		}
+		return nil
+	})
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+	assert.Equal(t, []annotateBulkResultRow{
+		{Version: "1.0.0", Result: annotateResultAnnotated},
+		{Version: "1.1.0", Result: annotateResultFailed, Error: "not found"},
+		{Version: "1.2.0", Result: annotateResultAnnotated},
+		{Version: "2.0.0", Result: annotateResultAnnotated},
+	}, results)
+}
+
+func TestAnnotateBulkDescribeChanges(t *testing.T) {
+	command := NewReleaseBundleAnnotateBulkCommand().SetTag("deprecated", true).SetProps("cve=2026-1234").DeleteProps("approved")
+	assert.Equal(t, "set the tag 'deprecated', set the properties 'cve=2026-1234', delete the properties 'approved'", command.describeChanges())
+	assert.Equal(t, "remove the tag", NewReleaseBundleAnnotateBulkCommand().SetTag("", true).describeChanges())
+}
+
+func TestAnnotateBulkReportResults(t *testing.T) {
+	command := NewReleaseBundleAnnotateBulkCommand().SetReleaseBundleName("my-app")
+	assert.NoError(t, command.reportResults([]annotateBulkResultRow{{Version: "1.0.0", Result: annotateResultAnnotated}}))
+	assert.EqualError(t, command.reportResults([]annotateBulkResultRow{
+		{Version: "1.0.0", Result: annotateResultAnnotated},
+		{Version: "1.1.0", Result: annotateResultFailed, Error: "not found"},
+	}), "failed to annotate 1 of 2 versions of release bundle 'my-app'")
+}
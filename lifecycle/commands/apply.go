@@ -0,0 +1,426 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	applyStageCreate     = "create"
+	applyStagePromote    = "promote"
+	applyStageDistribute = "distribute"
+	applyStageAnnotate   = "annotate"
+
+	defaultApplyMaxWaitMinutes = 60
+	// propertiesSeparators are the characters that separate the properties of an annotation, and their keys from their values.
+	propertiesSeparators = ";,="
+)
+
+// ReleasePipeline is the release definition applied by release-bundle-apply.
+type ReleasePipeline struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Project    string `yaml:"project"`
+	SigningKey string `yaml:"signingKey"`
+	// Sources uses the fields of the release-bundle-create file spec (build, bundle, package, aql, pattern...).
+	Sources      []map[string]interface{} `yaml:"sources"`
+	Promotions   []PipelinePromotion      `yaml:"promotions"`
+	Distribution *PipelineDistribution    `yaml:"distribution"`
+	Tag          string                   `yaml:"tag"`
+	Properties   map[string]string        `yaml:"properties"`
+
+	spec        *spec.SpecFiles
+	sourceTypes []services.SourceType
+}
+
+// PipelinePromotion promotes the release bundle to an environment.
+type PipelinePromotion struct {
+	Environment   string   `yaml:"environment"`
+	IncludeRepos  []string `yaml:"includeRepos"`
+	ExcludeRepos  []string `yaml:"excludeRepos"`
+	PromotionType string   `yaml:"promotionType"`
+}
+
+// PipelineDistribution distributes the release bundle to the edges matching its rules, or to all edges without rules.
+type PipelineDistribution struct {
+	Rules              []PipelineDistributionRule `yaml:"rules"`
+	CreateRepo         bool                       `yaml:"createRepo"`
+	PathMappingPattern string                     `yaml:"pathMappingPattern"`
+	PathMappingTarget  string                     `yaml:"pathMappingTarget"`
+	MaxWaitMinutes     int                        `yaml:"maxWaitMinutes"`
+}
+
+type PipelineDistributionRule struct {
+	SiteName     string   `yaml:"siteName"`
+	CityName     string   `yaml:"cityName"`
+	CountryCodes []string `yaml:"countryCodes"`
+}
+
+// LoadReleasePipeline reads and validates a release definition. Variables in the form ${key} are replaced by specVars.
+func LoadReleasePipeline(path string, specVars map[string]string) (*ReleasePipeline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read release definition %s: %s", path, err.Error())
+	}
+	if len(specVars) > 0 {
+		content = coreutils.ReplaceVars(content, specVars)
+	}
+
+	pipeline := &ReleasePipeline{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(pipeline); err != nil {
+		return nil, errorutils.CheckErrorf("invalid release definition %s: %s", path, err.Error())
+	}
+	if err = pipeline.validate(); err != nil {
+		return nil, errorutils.CheckErrorf("invalid release definition %s: %s", path, err.Error())
+	}
+	return pipeline, nil
+}
+
+func (rp *ReleasePipeline) validate() (err error) {
+	if rp.Name == "" || rp.Version == "" {
+		return errors.New("'name' and 'version' are required")
+	}
+	if len(rp.Sources) == 0 {
+		return errors.New("at least one entry in 'sources' is required")
+	}
+	// The sources go through the same JSON parsing and validation as a release-bundle-create file spec.
+	specContent, err := json.Marshal(map[string]interface{}{"files": rp.Sources})
+	if err != nil {
+		return err
+	}
+	rp.spec = new(spec.SpecFiles)
+	if err = json.Unmarshal(specContent, rp.spec); err != nil {
+		return fmt.Errorf("invalid 'sources': %s", err.Error())
+	}
+	if rp.sourceTypes, err = validateAndIdentifyRbCreationSpec(rp.spec.Files, true); err != nil {
+		return fmt.Errorf("invalid 'sources': %s", err.Error())
+	}
+
+	environments := map[string]bool{}
+	for i := range rp.Promotions {
+		promotion := &rp.Promotions[i]
+		if promotion.Environment == "" {
+			return errors.New("every promotion requires an 'environment'")
+		}
+		if environments[promotion.Environment] {
+			return fmt.Errorf("environment '%s' appears in more than one promotion", promotion.Environment)
+		}
+		environments[promotion.Environment] = true
+		if promotion.PromotionType == "" {
+			promotion.PromotionType = "copy"
+		}
+		if promotion.PromotionType != "copy" && promotion.PromotionType != "move" {
+			return fmt.Errorf("invalid promotionType '%s' for environment '%s': expected 'copy' or 'move'", promotion.PromotionType, promotion.Environment)
+		}
+	}
+	// The properties are passed to the annotation as key=value;key=value, which has no escaping.
+	for key, value := range rp.Properties {
+		if key == "" {
+			return errors.New("property keys must not be empty")
+		}
+		if strings.ContainsAny(key, propertiesSeparators) || strings.ContainsAny(value, propertiesSeparators) {
+			return fmt.Errorf("property '%s' must not contain any of the characters '%s' in its key or value", key, propertiesSeparators)
+		}
+	}
+	return nil
+}
+
+func (rp *ReleasePipeline) hasAnnotations() bool {
+	return rp.Tag != "" || len(rp.Properties) > 0
+}
+
+// propertiesString returns the properties in the key=value;key=value form accepted by release-bundle-annotate.
+func (rp *ReleasePipeline) propertiesString() string {
+	keys := make([]string, 0, len(rp.Properties))
+	for key := range rp.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + rp.Properties[key]
+	}
+	return strings.Join(pairs, ";")
+}
+
+func (pd *PipelineDistribution) distributionRules() *spec.DistributionRules {
+	rules := &spec.DistributionRules{}
+	for _, rule := range pd.Rules {
+		rules.DistributionRules = append(rules.DistributionRules, spec.DistributionRule{
+			SiteName:     rule.SiteName,
+			CityName:     rule.CityName,
+			CountryCodes: rule.CountryCodes,
+		})
+	}
+	return rules
+}
+
+// siteDistributionRules distributes to the given targets only.
+func siteDistributionRules(sites []string) *spec.DistributionRules {
+	rules := &spec.DistributionRules{}
+	for _, site := range sites {
+		rules.DistributionRules = append(rules.DistributionRules, spec.DistributionRule{SiteName: site})
+	}
+	return rules
+}
+
+// releaseBundleState is what already happened to the release bundle version on the server.
+type releaseBundleState struct {
+	exists   bool
+	promoted map[string]bool
+	// distributions is the latest distribution to each target the version was distributed to.
+	distributions []DistributionTargetStatus
+}
+
+// applyStep is a single stage of the plan. Skipped steps were already completed by an earlier run.
+type applyStep struct {
+	stage     string
+	target    string
+	action    string
+	skip      bool
+	promotion *PipelinePromotion
+	// sites are the targets a distribution is resumed on, instead of the distribution rules of the pipeline.
+	sites []string
+}
+
+type applyPlanRow struct {
+	Stage  string `col-name:"Stage"`
+	Target string `col-name:"Target"`
+	Action string `col-name:"Action"`
+}
+
+func buildApplyPlan(pipeline *ReleasePipeline, state releaseBundleState) []applyStep {
+	bundle := pipeline.Name + "/" + pipeline.Version
+	steps := []applyStep{{stage: applyStageCreate, target: bundle}}
+	if state.exists {
+		steps[0].action, steps[0].skip = "skip: the version already exists", true
+	} else {
+		var sourceTypes []string
+		seen := make(SourceTypeSet)
+		for _, sourceType := range pipeline.sourceTypes {
+			if !seen[sourceType] {
+				seen[sourceType] = true
+				sourceTypes = append(sourceTypes, string(sourceType))
+			}
+		}
+		steps[0].action = "create from " + coreutils.ListToText(sourceTypes)
+	}
+
+	for i := range pipeline.Promotions {
+		promotion := &pipeline.Promotions[i]
+		step := applyStep{stage: applyStagePromote, target: promotion.Environment, promotion: promotion}
+		if state.promoted[promotion.Environment] {
+			step.action, step.skip = "skip: already promoted", true
+		} else {
+			step.action = "promote (" + promotion.PromotionType + ")"
+		}
+		steps = append(steps, step)
+	}
+
+	if pipeline.Distribution != nil {
+		steps = append(steps, buildDistributeStep(pipeline.Distribution, state.distributions))
+	}
+
+	if pipeline.hasAnnotations() {
+		var changes []string
+		if pipeline.Tag != "" {
+			changes = append(changes, fmt.Sprintf("tag '%s'", pipeline.Tag))
+		}
+		if len(pipeline.Properties) > 0 {
+			changes = append(changes, "properties "+pipeline.propertiesString())
+		}
+		steps = append(steps, applyStep{stage: applyStageAnnotate, target: bundle, action: "set " + strings.Join(changes, " and ")})
+	}
+	return steps
+}
+
+// buildDistributeStep distributes the version by the rules of the pipeline. Once the version was distributed,
+// targets whose latest distribution completed are skipped and the distribution is resumed on the other targets only.
+func buildDistributeStep(pipelineDistribution *PipelineDistribution, distributions []DistributionTargetStatus) applyStep {
+	step := applyStep{stage: applyStageDistribute, target: "all edges", action: "distribute"}
+	if len(pipelineDistribution.Rules) > 0 {
+		step.target = fmt.Sprintf("%d distribution rule(s)", len(pipelineDistribution.Rules))
+	}
+	var distributed int
+	for _, target := range distributions {
+		switch {
+		// A distribution that is still queued doesn't report its targets yet.
+		case target.Target == "":
+		case distribution.DistributionStatus(target.Status) == distribution.Completed:
+			distributed++
+		default:
+			step.sites = append(step.sites, target.Target)
+		}
+	}
+	switch {
+	case distributed == 0:
+		step.sites = nil
+	case len(step.sites) == 0:
+		step.action, step.skip = fmt.Sprintf("skip: already distributed to %d target(s)", distributed), true
+	default:
+		step.target = strings.Join(step.sites, ", ")
+		step.action = fmt.Sprintf("resume distribution (%d target(s) already distributed)", distributed)
+	}
+	return step
+}
+
+// ReleaseBundleApplyCommand runs the stages of a release definition in order: create, promote, distribute and annotate.
+// Creation, promotions and distributions that already completed are skipped, so a failed run can simply be repeated.
+type ReleaseBundleApplyCommand struct {
+	serverDetails *config.ServerDetails
+	pipeline      *ReleasePipeline
+	dryRun        bool
+}
+
+func NewReleaseBundleApplyCommand() *ReleaseBundleApplyCommand {
+	return &ReleaseBundleApplyCommand{}
+}
+
+func (rba *ReleaseBundleApplyCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleApplyCommand {
+	rba.serverDetails = serverDetails
+	return rba
+}
+
+func (rba *ReleaseBundleApplyCommand) SetPipeline(pipeline *ReleasePipeline) *ReleaseBundleApplyCommand {
+	rba.pipeline = pipeline
+	return rba
+}
+
+func (rba *ReleaseBundleApplyCommand) SetDryRun(dryRun bool) *ReleaseBundleApplyCommand {
+	rba.dryRun = dryRun
+	return rba
+}
+
+func (rba *ReleaseBundleApplyCommand) CommandName() string {
+	return "rb_apply"
+}
+
+func (rba *ReleaseBundleApplyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rba.serverDetails, nil
+}
+
+func (rba *ReleaseBundleApplyCommand) Run() error {
+	if err := validateArtifactoryVersionSupported(rba.serverDetails); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateLifecycleServiceManager(rba.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	state, err := rba.getState(servicesManager)
+	if err != nil {
+		return err
+	}
+
+	steps := buildApplyPlan(rba.pipeline, state)
+	if rba.dryRun {
+		return printApplyPlan(steps)
+	}
+	for _, step := range steps {
+		if step.skip {
+			log.Info(fmt.Sprintf("Skipping %s %s (%s).", step.stage, step.target, strings.TrimPrefix(step.action, "skip: ")))
+			continue
+		}
+		log.Info(fmt.Sprintf("Running %s %s...", step.stage, step.target))
+		if err = rba.runStep(step); err != nil {
+			return errorutils.CheckErrorf("release-bundle-apply stopped at %s %s: %s\nCompleted stages are skipped when the command is run again.", step.stage, step.target, err.Error())
+		}
+	}
+	log.Info(fmt.Sprintf("Release bundle %s/%s applied.", rba.pipeline.Name, rba.pipeline.Version))
+	return nil
+}
+
+func (rba *ReleaseBundleApplyCommand) getState(servicesManager *lifecycle.LifecycleServicesManager) (releaseBundleState, error) {
+	state := releaseBundleState{promoted: map[string]bool{}}
+	exists, err := servicesManager.IsReleaseBundleExist(rba.pipeline.Name, rba.pipeline.Version, rba.pipeline.Project)
+	if err != nil || !exists {
+		return state, err
+	}
+	state.exists = true
+
+	rbDetails := services.ReleaseBundleDetails{ReleaseBundleName: rba.pipeline.Name, ReleaseBundleVersion: rba.pipeline.Version}
+	promotions, err := servicesManager.GetReleaseBundleVersionPromotions(rbDetails, services.GetPromotionsOptionalQueryParams{ProjectKey: rba.pipeline.Project})
+	if err != nil {
+		return state, err
+	}
+	for _, promotion := range promotions.Promotions {
+		if promotion.Status == services.Completed {
+			state.promoted[promotion.Environment] = true
+		}
+	}
+	if rba.pipeline.Distribution == nil {
+		return state, nil
+	}
+	trackers, err := getDistributionTrackers(rba.serverDetails, servicesManager, rba.pipeline.Name, rba.pipeline.Version, rba.pipeline.Project)
+	if err != nil {
+		return state, err
+	}
+	state.distributions = latestDistributionPerTarget(trackers)
+	return state, nil
+}
+
+func (rba *ReleaseBundleApplyCommand) runStep(step applyStep) error {
+	pipeline := rba.pipeline
+	switch step.stage {
+	case applyStageCreate:
+		return NewReleaseBundleCreateCommand().SetServerDetails(rba.serverDetails).
+			SetReleaseBundleName(pipeline.Name).SetReleaseBundleVersion(pipeline.Version).
+			SetReleaseBundleProject(pipeline.Project).SetSigningKeyName(pipeline.SigningKey).
+			SetSpec(pipeline.spec).SetSync(true).Run()
+	case applyStagePromote:
+		return NewReleaseBundlePromoteCommand().SetServerDetails(rba.serverDetails).
+			SetReleaseBundleName(pipeline.Name).SetReleaseBundleVersion(pipeline.Version).
+			SetReleaseBundleProject(pipeline.Project).SetSigningKeyName(pipeline.SigningKey).
+			SetEnvironment(step.promotion.Environment).SetPromotionType(step.promotion.PromotionType).
+			SetIncludeReposPatterns(step.promotion.IncludeRepos).SetExcludeReposPatterns(step.promotion.ExcludeRepos).
+			SetSync(true).Run()
+	case applyStageDistribute:
+		pipelineDistribution := pipeline.Distribution
+		maxWaitMinutes := pipelineDistribution.MaxWaitMinutes
+		if maxWaitMinutes == 0 {
+			maxWaitMinutes = defaultApplyMaxWaitMinutes
+		}
+		rules := pipelineDistribution.distributionRules()
+		if len(step.sites) > 0 {
+			rules = siteDistributionRules(step.sites)
+		}
+		return NewReleaseBundleDistributeCommand().SetServerDetails(rba.serverDetails).
+			SetReleaseBundleName(pipeline.Name).SetReleaseBundleVersion(pipeline.Version).
+			SetReleaseBundleProject(pipeline.Project).SetDistributionRules(rules).
+			SetAutoCreateRepo(pipelineDistribution.CreateRepo).SetPathMappingPattern(pipelineDistribution.PathMappingPattern).
+			SetPathMappingTarget(pipelineDistribution.PathMappingTarget).SetSync(true).SetMaxWaitMinutes(maxWaitMinutes).Run()
+	case applyStageAnnotate:
+		return NewReleaseBundleAnnotateCommand().SetServerDetails(rba.serverDetails).
+			SetReleaseBundleName(pipeline.Name).SetReleaseBundleVersion(pipeline.Version).
+			SetReleaseBundleProject(pipeline.Project).SetTag(pipeline.Tag, pipeline.Tag != "").
+			SetProps(pipeline.propertiesString()).SetRecursive(false, true).Run()
+	default:
+		return fmt.Errorf("unknown stage '%s'", step.stage)
+	}
+}
+
+func printApplyPlan(steps []applyStep) error {
+	rows := make([]applyPlanRow, len(steps))
+	for i, step := range steps {
+		rows[i] = applyPlanRow{Stage: step.stage, Target: step.target, Action: step.action}
+	}
+	return coreutils.PrintTable(rows, "Release plan (dry run)", "Nothing to apply", false)
+}
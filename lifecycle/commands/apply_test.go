@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const releaseDefinition = `name: my-app
+version: ${VERSION}
+project: my-project
+signingKey: my-key
+sources:
+  - build: my-app/${BUILD}
+    includeDeps: "true"
+  - bundle: libs/2.0.0
+  - aql:
+      items.find:
+        repo: generic-local
+        name: {"$match": "*.zip"}
+promotions:
+  - environment: QA
+    includeRepos: ["generic-qa-local"]
+  - environment: PROD
+    promotionType: move
+    excludeRepos: ["*-tmp-local"]
+distribution:
+  rules:
+    - siteName: "edge-*"
+      countryCodes: ["US"]
+  createRepo: true
+tag: release
+properties:
+  owner: team-a
+  approved: "true"
+`
+
+func writeReleaseDefinition(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "release.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadReleasePipeline(t *testing.T) {
+	pipeline, err := LoadReleasePipeline(writeReleaseDefinition(t, releaseDefinition), map[string]string{"VERSION": "1.4.3", "BUILD": "42"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-app", pipeline.Name)
+	assert.Equal(t, "1.4.3", pipeline.Version)
+	require.Len(t, pipeline.spec.Files, 3)
+	assert.Equal(t, "my-app/42", pipeline.spec.Files[0].Build)
+	assert.Equal(t, "true", pipeline.spec.Files[0].IncludeDeps)
+	assert.Equal(t, "libs/2.0.0", pipeline.spec.Files[1].Bundle)
+	assert.Contains(t, pipeline.spec.Files[2].Aql.ItemsFind, `"repo":"generic-local"`)
+	assert.Equal(t, []services.SourceType{services.Builds, services.ReleaseBundles, services.Aql}, pipeline.sourceTypes)
+
+	require.Len(t, pipeline.Promotions, 2)
+	assert.Equal(t, "copy", pipeline.Promotions[0].PromotionType)
+	assert.Equal(t, "move", pipeline.Promotions[1].PromotionType)
+	assert.Equal(t, []string{"*-tmp-local"}, pipeline.Promotions[1].ExcludeRepos)
+
+	rules := pipeline.Distribution.distributionRules()
+	require.Len(t, rules.DistributionRules, 1)
+	assert.Equal(t, "edge-*", rules.DistributionRules[0].SiteName)
+	assert.Equal(t, []string{"US"}, rules.DistributionRules[0].CountryCodes)
+	assert.Equal(t, "approved=true;owner=team-a", pipeline.propertiesString())
+}
+
+func TestLoadReleasePipeline_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		errMsg     string
+	}{
+		{"missing version", "name: my-app\nsources:\n  - bundle: libs/1.0.0\n", "'name' and 'version' are required"},
+		{"no sources", "name: my-app\nversion: 1.0.0\n", "at least one entry in 'sources' is required"},
+		{"unknown field", "name: my-app\nversion: 1.0.0\nsigning-key: k\n", "field signing-key not found"},
+		{"unsupported source field", "name: my-app\nversion: 1.0.0\nsources:\n  - pattern: a/*\n    target: b/\n", "unsupported fields were provided in file spec"},
+		{"duplicate environment", "name: my-app\nversion: 1.0.0\nsources:\n  - bundle: libs/1.0.0\npromotions:\n  - environment: QA\n  - environment: QA\n", "environment 'QA' appears in more than one promotion"},
+		{"invalid promotion type", "name: my-app\nversion: 1.0.0\nsources:\n  - bundle: libs/1.0.0\npromotions:\n  - environment: QA\n    promotionType: link\n", "invalid promotionType 'link'"},
+		{"property separator", "name: my-app\nversion: 1.0.0\nsources:\n  - bundle: libs/1.0.0\nproperties:\n  team: \"a;b=c\"\n", "property 'team' must not contain any of the characters ';,='"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadReleasePipeline(writeReleaseDefinition(t, test.definition), nil)
+			assert.ErrorContains(t, err, test.errMsg)
+		})
+	}
+}
+
+func TestBuildApplyPlan(t *testing.T) {
+	pipeline, err := LoadReleasePipeline(writeReleaseDefinition(t, releaseDefinition), map[string]string{"VERSION": "1.4.3", "BUILD": "42"})
+	require.NoError(t, err)
+
+	steps := buildApplyPlan(pipeline, releaseBundleState{})
+	require.Len(t, steps, 5)
+	assert.Equal(t, applyStep{stage: applyStageCreate, target: "my-app/1.4.3", action: "create from builds, release_bundles and aql"}, steps[0])
+	assert.Equal(t, "promote (copy)", steps[1].action)
+	assert.Equal(t, "QA", steps[1].target)
+	assert.Equal(t, "promote (move)", steps[2].action)
+	assert.Equal(t, applyStep{stage: applyStageDistribute, target: "1 distribution rule(s)", action: "distribute"}, steps[3])
+	assert.Equal(t, "set tag 'release' and properties approved=true;owner=team-a", steps[4].action)
+	for _, step := range steps {
+		assert.False(t, step.skip)
+	}
+
+	// A second run after a failure in the PROD promotion resumes from there.
+	steps = buildApplyPlan(pipeline, releaseBundleState{exists: true, promoted: map[string]bool{"QA": true}})
+	assert.True(t, steps[0].skip)
+	assert.True(t, steps[1].skip)
+	assert.Equal(t, "skip: already promoted", steps[1].action)
+	assert.False(t, steps[2].skip)
+	assert.False(t, steps[3].skip)
+	assert.False(t, steps[4].skip)
+}
+
+func TestBuildApplyPlan_Distribution(t *testing.T) {
+	pipeline, err := LoadReleasePipeline(writeReleaseDefinition(t, releaseDefinition), map[string]string{"VERSION": "1.4.3", "BUILD": "42"})
+	require.NoError(t, err)
+	state := releaseBundleState{exists: true, promoted: map[string]bool{"QA": true, "PROD": true}}
+
+	// The distribution to edge-2 failed, so it's resumed there only.
+	state.distributions = []DistributionTargetStatus{
+		{Target: "edge-1", Status: string(distribution.Completed)},
+		{Target: "edge-2", Status: string(distribution.Failed)},
+	}
+	step := buildApplyPlan(pipeline, state)[3]
+	assert.False(t, step.skip)
+	assert.Equal(t, "edge-2", step.target)
+	assert.Equal(t, []string{"edge-2"}, step.sites)
+	assert.Equal(t, []spec.DistributionRule{{SiteName: "edge-2"}}, siteDistributionRules(step.sites).DistributionRules)
+
+	state.distributions[1].Status = string(distribution.Completed)
+	step = buildApplyPlan(pipeline, state)[3]
+	assert.True(t, step.skip)
+	assert.Equal(t, "skip: already distributed to 2 target(s)", step.action)
+}
+
+func TestBuildApplyPlan_CreateOnly(t *testing.T) {
+	pipeline, err := LoadReleasePipeline(writeReleaseDefinition(t, "name: my-app\nversion: 1.0.0\nsources:\n  - build: a/1\n  - build: b/2\n"), nil)
+	require.NoError(t, err)
+
+	steps := buildApplyPlan(pipeline, releaseBundleState{})
+	require.Len(t, steps, 1)
+	assert.Equal(t, "create from builds", steps[0].action)
+}
@@ -0,0 +1,452 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	cleanupBatchSize             = 10
+	defaultCleanupMaxWaitMinutes = 60
+	cleanupStatusDeleted         = "deleted"
+	cleanupStatusSkipped         = "skipped"
+	cleanupStatusFailed          = "failed"
+	cleanupStatusNotDistributed  = "not distributed"
+)
+
+// RetentionPolicy decides which versions of a release bundle are kept by release-bundle-cleanup.
+// A version is kept if any of the rules match it. All other versions are deleted.
+type RetentionPolicy struct {
+	// KeepLast keeps the newest N versions.
+	KeepLast int
+	// KeepNewerThan keeps the versions created within this duration.
+	KeepNewerThan time.Duration
+	// ProtectedEnvironments keeps the versions promoted to any of these environments.
+	ProtectedEnvironments []string
+	// ProtectedTags keeps the versions carrying any of these tags.
+	ProtectedTags []string
+}
+
+func (rp *RetentionPolicy) validate() error {
+	if rp.KeepLast < 0 || rp.KeepNewerThan < 0 {
+		return errorutils.CheckErrorf("retention values must not be negative")
+	}
+	if rp.KeepLast == 0 && rp.KeepNewerThan == 0 {
+		return errorutils.CheckErrorf("at least one of the 'keep last' or 'keep newer than' retention rules must be provided")
+	}
+	return nil
+}
+
+// ParseRetentionDuration parses a duration such as "720h" or "30d". The "d" suffix stands for days.
+func ParseRetentionDuration(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, errorutils.CheckErrorf("invalid duration '%s': expected a number of days such as '30d'", value)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("invalid duration '%s': expected a duration such as '720h' or '30d'", value)
+	}
+	return duration, nil
+}
+
+// cleanupDecision is the retention decision for a single version. The reason explains why a version is kept.
+type cleanupDecision struct {
+	version services.ReleaseBundleVersion
+	keep    bool
+	reason  string
+}
+
+type cleanupPlanRow struct {
+	Version string `col-name:"Version"`
+	Created string `col-name:"Created"`
+	Action  string `col-name:"Action"`
+	Reason  string `col-name:"Reason"`
+}
+
+type cleanupReportRow struct {
+	Version string `col-name:"Version"`
+	Remote  string `col-name:"Remote"`
+	Local   string `col-name:"Local"`
+}
+
+// decideRetention applies the time and count based rules and the tag protection to the versions, newest first.
+// Promotion protection needs a request per version, so it is applied later on the versions left for deletion.
+func decideRetention(versions []services.ReleaseBundleVersion, policy RetentionPolicy, taggedVersions map[string]string, now time.Time) []cleanupDecision {
+	sorted := make([]services.ReleaseBundleVersion, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created.After(sorted[j].Created)
+	})
+
+	decisions := make([]cleanupDecision, len(sorted))
+	for i, version := range sorted {
+		decision := cleanupDecision{version: version, keep: true}
+		switch {
+		case i < policy.KeepLast:
+			decision.reason = fmt.Sprintf("one of the last %d versions", policy.KeepLast)
+		case policy.KeepNewerThan > 0 && version.Created.After(now.Add(-policy.KeepNewerThan)):
+			decision.reason = fmt.Sprintf("created within the last %s", policy.KeepNewerThan)
+		case taggedVersions[version.ReleaseBundleVersion] != "":
+			decision.reason = fmt.Sprintf("tagged '%s'", taggedVersions[version.ReleaseBundleVersion])
+		default:
+			decision.keep = false
+		}
+		decisions[i] = decision
+	}
+	return decisions
+}
+
+// findProtectedEnvironment returns the first protected environment the version was successfully promoted to, if any.
+func findProtectedEnvironment(promotions []services.RbPromotion, protectedEnvironments []string) string {
+	for _, promotion := range promotions {
+		if promotion.Status != services.Completed {
+			continue
+		}
+		for _, environment := range protectedEnvironments {
+			if strings.EqualFold(promotion.Environment, environment) {
+				return promotion.Environment
+			}
+		}
+	}
+	return ""
+}
+
+// ReleaseBundleCleanupCommand deletes the versions of a release bundle that are not kept by the retention policy,
+// from the distribution edges and from the local Artifactory.
+type ReleaseBundleCleanupCommand struct {
+	serverDetails     *config.ServerDetails
+	releaseBundleName string
+	rbProjectKey      string
+	policy            RetentionPolicy
+	localOnly         bool
+	dryRun            bool
+	quiet             bool
+	maxWaitMinutes    int
+}
+
+func NewReleaseBundleCleanupCommand() *ReleaseBundleCleanupCommand {
+	return &ReleaseBundleCleanupCommand{}
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleCleanupCommand {
+	rbc.serverDetails = serverDetails
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleCleanupCommand {
+	rbc.releaseBundleName = releaseBundleName
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleCleanupCommand {
+	rbc.rbProjectKey = rbProjectKey
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetRetentionPolicy(policy RetentionPolicy) *ReleaseBundleCleanupCommand {
+	rbc.policy = policy
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetLocalOnly(localOnly bool) *ReleaseBundleCleanupCommand {
+	rbc.localOnly = localOnly
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetDryRun(dryRun bool) *ReleaseBundleCleanupCommand {
+	rbc.dryRun = dryRun
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetQuiet(quiet bool) *ReleaseBundleCleanupCommand {
+	rbc.quiet = quiet
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) SetMaxWaitMinutes(maxWaitMinutes int) *ReleaseBundleCleanupCommand {
+	rbc.maxWaitMinutes = maxWaitMinutes
+	return rbc
+}
+
+func (rbc *ReleaseBundleCleanupCommand) CommandName() string {
+	return "rb_cleanup"
+}
+
+func (rbc *ReleaseBundleCleanupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbc.serverDetails, nil
+}
+
+func (rbc *ReleaseBundleCleanupCommand) Run() error {
+	if err := rbc.policy.validate(); err != nil {
+		return err
+	}
+	if err := validateArtifactoryVersionSupported(rbc.serverDetails); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateLifecycleServiceManager(rbc.serverDetails, false)
+	if err != nil {
+		return err
+	}
+
+	decisions, err := rbc.getDecisions(servicesManager)
+	if err != nil {
+		return err
+	}
+	var toDelete []services.ReleaseBundleVersion
+	for _, decision := range decisions {
+		if !decision.keep {
+			toDelete = append(toDelete, decision.version)
+		}
+	}
+
+	if rbc.dryRun {
+		return printCleanupPlan(decisions)
+	}
+	if len(toDelete) == 0 {
+		log.Info(fmt.Sprintf("No versions of release bundle '%s' need to be deleted.", rbc.releaseBundleName))
+		return nil
+	}
+	if !rbc.confirmDelete(len(toDelete)) {
+		return nil
+	}
+	return rbc.deleteVersions(servicesManager, toDelete)
+}
+
+func (rbc *ReleaseBundleCleanupCommand) getDecisions(servicesManager *lifecycle.LifecycleServicesManager) ([]cleanupDecision, error) {
+	versions, err := NewSearchVersionsCommand().SetReleaseBundleName(rbc.releaseBundleName).
+		SetProject(rbc.rbProjectKey).searchAllVersions(servicesManager)
+	if err != nil {
+		return nil, err
+	}
+
+	taggedVersions := map[string]string{}
+	for _, tag := range rbc.policy.ProtectedTags {
+		tagged, err := searchTaggedVersions(rbc.serverDetails, servicesManager, rbc.releaseBundleName, rbc.rbProjectKey, tag)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range tagged {
+			taggedVersions[version] = tag
+		}
+	}
+
+	decisions := decideRetention(versions, rbc.policy, taggedVersions, time.Now())
+	if len(rbc.policy.ProtectedEnvironments) == 0 {
+		return decisions, nil
+	}
+	for i := range decisions {
+		if decisions[i].keep {
+			continue
+		}
+		rbDetails := services.ReleaseBundleDetails{ReleaseBundleName: rbc.releaseBundleName, ReleaseBundleVersion: decisions[i].version.ReleaseBundleVersion}
+		promotions, err := servicesManager.GetReleaseBundleVersionPromotions(rbDetails, services.GetPromotionsOptionalQueryParams{ProjectKey: rbc.rbProjectKey})
+		if err != nil {
+			return nil, err
+		}
+		if environment := findProtectedEnvironment(promotions.Promotions, rbc.policy.ProtectedEnvironments); environment != "" {
+			decisions[i].keep = true
+			decisions[i].reason = fmt.Sprintf("promoted to %s", environment)
+		}
+	}
+	return decisions, nil
+}
+
+// taggedVersionsResponse is a page of the versions search. Unlike the client's response type, it includes the tag of the versions.
+type taggedVersionsResponse struct {
+	ReleaseBundles []struct {
+		ReleaseBundleVersion string `json:"release_bundle_version"`
+		Tag                  string `json:"tag"`
+	} `json:"release_bundles"`
+	Total int `json:"total"`
+}
+
+// searchTaggedVersions returns the versions of the release bundle whose tag is tag. The filter of the versions search
+// matches part of the version or of the tag, so it only narrows the search and the tags are compared exactly.
+func searchTaggedVersions(serverDetails *config.ServerDetails, servicesManager *lifecycle.LifecycleServicesManager,
+	releaseBundleName, projectKey, tag string) ([]string, error) {
+	lcDetails, err := serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := lcDetails.CreateHttpClientDetails()
+	var versions []string
+	for offset := 0; ; {
+		params := map[string]string{"filter_by": tag, "offset": strconv.Itoa(offset), "limit": strconv.Itoa(defaultSearchPageSize)}
+		if projectKey != "" {
+			params["project"] = projectKey
+		}
+		requestFullUrl, err := clientutils.BuildUrl(lcDetails.GetUrl(), services.GetReleaseBundleSearchVersionsApi(releaseBundleName), params)
+		if err != nil {
+			return nil, err
+		}
+		resp, body, _, err := servicesManager.Client().SendGet(requestFullUrl, true, &httpClientDetails)
+		if err != nil {
+			return nil, err
+		}
+		if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+			return nil, err
+		}
+		var page taggedVersionsResponse
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the versions of release bundle '%s': %s", releaseBundleName, err.Error())
+		}
+		versions = append(versions, versionsWithTag(page, tag)...)
+		offset += len(page.ReleaseBundles)
+		if len(page.ReleaseBundles) < defaultSearchPageSize || (page.Total > 0 && offset >= page.Total) {
+			return versions, nil
+		}
+	}
+}
+
+// versionsWithTag returns the versions of the page whose tag is exactly tag.
+func versionsWithTag(page taggedVersionsResponse, tag string) []string {
+	var versions []string
+	for _, version := range page.ReleaseBundles {
+		if version.Tag == tag {
+			versions = append(versions, version.ReleaseBundleVersion)
+		}
+	}
+	return versions
+}
+
+func (rbc *ReleaseBundleCleanupCommand) confirmDelete(count int) bool {
+	if rbc.quiet {
+		return true
+	}
+	location := "locally and from all edges"
+	if rbc.localOnly {
+		location = "locally"
+	}
+	return coreutils.AskYesNo(
+		fmt.Sprintf("Are you sure you want to delete %d versions of release bundle '%s' %s?\n"+avoidConfirmationMsg, count, rbc.releaseBundleName, location), false)
+}
+
+// deleteVersions deletes the versions in batches. Each version is removed from the edges it was distributed to
+// before it is removed locally, and is kept locally if that fails. A failure in one version doesn't stop the
+// deletion of the others.
+func (rbc *ReleaseBundleCleanupCommand) deleteVersions(servicesManager *lifecycle.LifecycleServicesManager, versions []services.ReleaseBundleVersion) (err error) {
+	maxWaitMinutes := rbc.maxWaitMinutes
+	if maxWaitMinutes == 0 {
+		maxWaitMinutes = defaultCleanupMaxWaitMinutes
+	}
+	queryParams := services.CommonOptionalQueryParams{ProjectKey: rbc.rbProjectKey}
+
+	report := make([]cleanupReportRow, 0, len(versions))
+	success := 0
+	for start := 0; start < len(versions); start += cleanupBatchSize {
+		end := min(start+cleanupBatchSize, len(versions))
+		log.Info(fmt.Sprintf("Deleting versions %d-%d of %d...", start+1, end, len(versions)))
+		for _, version := range versions[start:end] {
+			rbDetails := services.ReleaseBundleDetails{ReleaseBundleName: rbc.releaseBundleName, ReleaseBundleVersion: version.ReleaseBundleVersion}
+			row := cleanupReportRow{Version: version.ReleaseBundleVersion, Remote: cleanupStatusSkipped}
+			if !rbc.localOnly {
+				var remoteErr error
+				if row.Remote, remoteErr = rbc.deleteFromEdges(servicesManager, rbDetails, maxWaitMinutes, queryParams); remoteErr != nil {
+					// Deleting the version locally would leave it on the edges without a way to delete it from them.
+					err = errors.Join(err, fmt.Errorf("failed to delete release bundle '%s/%s' from the edges: %w", rbc.releaseBundleName, version.ReleaseBundleVersion, remoteErr))
+					row.Local = cleanupStatusSkipped
+					report = append(report, row)
+					continue
+				}
+			}
+			row.Local = cleanupStatusDeleted
+			if localErr := servicesManager.DeleteReleaseBundleVersion(rbDetails, queryParams); localErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to delete release bundle '%s/%s': %w", rbc.releaseBundleName, version.ReleaseBundleVersion, localErr))
+				row.Local = cleanupStatusFailed
+			} else {
+				success++
+			}
+			report = append(report, row)
+		}
+	}
+
+	if printErr := coreutils.PrintTable(report, "Release bundle cleanup", "No versions were deleted", false); printErr != nil {
+		err = errors.Join(err, printErr)
+	}
+	log.Info(fmt.Sprintf("Release bundle versions deleted successfully: %d, failed: %d", success, len(versions)-success))
+	return errorutils.CheckError(err)
+}
+
+// deleteFromEdges deletes the version from the edges it is distributed to, and returns the remote status of the report.
+// A version that isn't distributed to any edge can't be deleted from the edges, so it is skipped.
+func (rbc *ReleaseBundleCleanupCommand) deleteFromEdges(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails,
+	maxWaitMinutes int, queryParams services.CommonOptionalQueryParams) (string, error) {
+	trackers, err := getDistributionTrackers(rbc.serverDetails, servicesManager, rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion, rbc.rbProjectKey)
+	if err != nil {
+		return cleanupStatusFailed, err
+	}
+	if len(distributedTargets(trackers)) == 0 {
+		return cleanupStatusNotDistributed, nil
+	}
+	if err = servicesManager.RemoteDeleteReleaseBundle(rbDetails, services.ReleaseBundleRemoteDeleteParams{
+		DistributionRules:         []*distribution.DistributionCommonParams{{SiteName: "*"}},
+		MaxWaitMinutes:            maxWaitMinutes,
+		CommonOptionalQueryParams: queryParams,
+	}); err != nil {
+		return cleanupStatusFailed, err
+	}
+	return cleanupStatusDeleted, nil
+}
+
+// distributedTargets returns the targets a version is distributed to: the targets of its distributions,
+// less the targets it was deleted from by a later remote deletion.
+func distributedTargets(trackers []distribution.DistributionStatusResponse) []string {
+	sorted := append([]distribution.DistributionStatusResponse{}, trackers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return numberValue(sorted[i].Id) < numberValue(sorted[j].Id)
+	})
+	targets := map[string]bool{}
+	for _, tracker := range sorted {
+		switch tracker.Type {
+		case "", distribution.Distribute:
+			for _, site := range tracker.Sites {
+				targets[site.TargetArtifactory.Name] = true
+			}
+		case distribution.DeleteReleaseBundleVersion:
+			if tracker.Status != distribution.Completed {
+				continue
+			}
+			for _, site := range tracker.Sites {
+				delete(targets, site.TargetArtifactory.Name)
+			}
+		}
+	}
+	return sortedKeys(targets)
+}
+
+func printCleanupPlan(decisions []cleanupDecision) error {
+	rows := make([]cleanupPlanRow, len(decisions))
+	for i, decision := range decisions {
+		rows[i] = cleanupPlanRow{
+			Version: decision.version.ReleaseBundleVersion,
+			Created: decision.version.Created.Format(time.RFC3339),
+			Action:  "delete",
+			Reason:  "not kept by any retention rule",
+		}
+		if decision.keep {
+			rows[i].Action = "keep"
+			rows[i].Reason = decision.reason
+		}
+	}
+	return coreutils.PrintTable(rows, "Release bundle cleanup (dry run)", "No release bundle versions found", false)
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetentionDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		errMsg   string
+	}{
+		{"30d", 30 * 24 * time.Hour, ""},
+		{"0d", 0, ""},
+		{"720h", 720 * time.Hour, ""},
+		{"1h30m", 90 * time.Minute, ""},
+		{"xd", 0, "expected a number of days"},
+		{"-3d", 0, "expected a number of days"},
+		{"3 weeks", 0, "expected a duration"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			duration, err := ParseRetentionDuration(test.value)
+			if test.errMsg != "" {
+				assert.ErrorContains(t, err, test.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, duration)
+		})
+	}
+}
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	assert.ErrorContains(t, (&RetentionPolicy{ProtectedTags: []string{"ga"}}).validate(), "at least one of")
+	assert.ErrorContains(t, (&RetentionPolicy{KeepLast: -1}).validate(), "must not be negative")
+	assert.NoError(t, (&RetentionPolicy{KeepLast: 3}).validate())
+	assert.NoError(t, (&RetentionPolicy{KeepNewerThan: time.Hour}).validate())
+}
+
+func TestDecideRetention(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	// The search results aren't necessarily ordered by creation time.
+	versions := []services.ReleaseBundleVersion{
+		{ReleaseBundleVersion: "1.0.0", Created: daysAgo(90)},
+		{ReleaseBundleVersion: "1.3.0", Created: daysAgo(2)},
+		{ReleaseBundleVersion: "1.1.0", Created: daysAgo(60)},
+		{ReleaseBundleVersion: "1.2.0", Created: daysAgo(20)},
+		{ReleaseBundleVersion: "1.2.1", Created: daysAgo(10)},
+	}
+	policy := RetentionPolicy{KeepLast: 2, KeepNewerThan: 15 * 24 * time.Hour}
+
+	decisions := decideRetention(versions, policy, map[string]string{"1.0.0": "ga"}, now)
+	require.Len(t, decisions, 5)
+	var order []string
+	for _, decision := range decisions {
+		order = append(order, decision.version.ReleaseBundleVersion)
+	}
+	assert.Equal(t, []string{"1.3.0", "1.2.1", "1.2.0", "1.1.0", "1.0.0"}, order)
+
+	assert.Equal(t, cleanupDecision{version: versions[1], keep: true, reason: "one of the last 2 versions"}, decisions[0])
+	assert.Equal(t, "one of the last 2 versions", decisions[1].reason)
+	assert.False(t, decisions[2].keep)
+	assert.False(t, decisions[3].keep)
+	assert.Equal(t, cleanupDecision{version: versions[0], keep: true, reason: "tagged 'ga'"}, decisions[4])
+
+	// A version inside the time window is kept even when it's outside the last N.
+	decisions = decideRetention(versions, RetentionPolicy{KeepLast: 1, KeepNewerThan: 25 * 24 * time.Hour}, nil, now)
+	assert.True(t, decisions[1].keep)
+	assert.Equal(t, "created within the last 600h0m0s", decisions[2].reason)
+	assert.False(t, decisions[3].keep)
+}
+
+func TestFindProtectedEnvironment(t *testing.T) {
+	promotions := []services.RbPromotion{
+		{Environment: "DEV", Status: services.Completed},
+		{Environment: "PROD", Status: services.Failed},
+		{Environment: "QA", Status: services.Completed},
+	}
+	assert.Equal(t, "QA", findProtectedEnvironment(promotions, []string{"prod", "qa"}))
+	assert.Empty(t, findProtectedEnvironment(promotions, []string{"PROD"}))
+	assert.Empty(t, findProtectedEnvironment(nil, []string{"PROD"}))
+}
+
+func TestVersionsWithTag(t *testing.T) {
+	// The search filter matches part of the version or of the tag, so all of these versions are returned for "1".
+	var page taggedVersionsResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"release_bundles":[
+		{"release_bundle_version":"1.0.0","tag":"prod"},
+		{"release_bundle_version":"2.0.0","tag":"1"},
+		{"release_bundle_version":"3.0.0","tag":"1.1"},
+		{"release_bundle_version":"4.0.1"}],"total":4}`), &page))
+	assert.Equal(t, []string{"2.0.0"}, versionsWithTag(page, "1"))
+	assert.Empty(t, versionsWithTag(page, "pro"))
+}
+
+func TestDistributedTargets(t *testing.T) {
+	site := func(name string) distribution.DistributionSiteStatus {
+		return distribution.DistributionSiteStatus{TargetArtifactory: distribution.TargetArtifactory{Name: name}}
+	}
+	trackers := []distribution.DistributionStatusResponse{
+		{Id: "3", Type: distribution.DeleteReleaseBundleVersion, Status: distribution.Completed, Sites: []distribution.DistributionSiteStatus{site("edge-1")}},
+		{Id: "1", Type: distribution.Distribute, Status: distribution.Completed, Sites: []distribution.DistributionSiteStatus{site("edge-1"), site("edge-2")}},
+		{Id: "2", Type: distribution.Distribute, Status: distribution.Failed, Sites: []distribution.DistributionSiteStatus{site("edge-3")}},
+		{Id: "4", Type: distribution.DeleteReleaseBundleVersion, Status: distribution.Failed, Sites: []distribution.DistributionSiteStatus{site("edge-2")}},
+	}
+	// edge-1 was deleted after it was distributed, and the deletion from edge-2 failed.
+	assert.Equal(t, []string{"edge-2", "edge-3"}, distributedTargets(trackers))
+	assert.Empty(t, distributedTargets(nil))
+}
@@ -39,6 +39,10 @@ type ReleaseBundleCreateCommand struct {
 	buildsSpecPath         string
 	releaseBundlesSpecPath string
 
+	// Local build-info or SBOM file, whose artifacts are found in Artifactory by checksum
+	buildInfoFilePath string
+	sbomFilePath      string
+
 	// Multi-bundles and multi-builds sources from command-line
 	ReleaseBundleSources
 }
@@ -148,6 +152,10 @@ func (rbc *ReleaseBundleCreateCommand) Run() error {
 		return err
 	}
 
+	if rbc.buildInfoFilePath != "" || rbc.sbomFilePath != "" {
+		return rbc.createFromLocalFile(servicesManager, rbDetails, queryParams)
+	}
+
 	var isReleaseBundleCreationWithMultiSourcesSupported bool
 	if err = ValidateFeatureSupportedVersion(rbc.serverDetails, minArtifactoryVersionForMultiSourceAndPackagesSupport); err != nil {
 		isReleaseBundleCreationWithMultiSourcesSupported = false
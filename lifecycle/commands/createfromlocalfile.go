@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	rtServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	checksumsQueryBatchSize = 100
+	sha256ChecksumLength    = 64
+	sha1ChecksumLength      = 40
+)
+
+// localComponent is an artifact listed in a local build-info or SBOM file, identified by its checksums.
+type localComponent struct {
+	name   string
+	sha256 string
+	sha1   string
+}
+
+type unresolvedComponentRow struct {
+	Component string `col-name:"Component"`
+	Checksum  string `col-name:"Checksum"`
+	Reason    string `col-name:"Reason"`
+}
+
+type ambiguousComponentRow struct {
+	Component  string `col-name:"Component"`
+	Path       string `col-name:"Used path"`
+	OtherPaths string `col-name:"Other paths"`
+}
+
+type cycloneDxBom struct {
+	BomFormat  string               `json:"bomFormat"`
+	Components []cycloneDxComponent `json:"components"`
+}
+
+type cycloneDxComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Hashes  []struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	} `json:"hashes"`
+	Components []cycloneDxComponent `json:"components"`
+}
+
+type spdxDocument struct {
+	SpdxVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name        string         `json:"name"`
+		VersionInfo string         `json:"versionInfo"`
+		Checksums   []spdxChecksum `json:"checksums"`
+	} `json:"packages"`
+	Files []struct {
+		FileName  string         `json:"fileName"`
+		Checksums []spdxChecksum `json:"checksums"`
+	} `json:"files"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// SetBuildInfoFilePath sets a local build-info file. The artifacts of its modules are the content of the release bundle.
+func (rbc *ReleaseBundleCreateCommand) SetBuildInfoFilePath(buildInfoFilePath string) *ReleaseBundleCreateCommand {
+	rbc.buildInfoFilePath = buildInfoFilePath
+	return rbc
+}
+
+// SetSbomFilePath sets a local CycloneDX or SPDX JSON file. The components it lists are the content of the release bundle.
+func (rbc *ReleaseBundleCreateCommand) SetSbomFilePath(sbomFilePath string) *ReleaseBundleCreateCommand {
+	rbc.sbomFilePath = sbomFilePath
+	return rbc
+}
+
+// createFromLocalFile creates the release bundle from the artifacts listed in a local build-info or SBOM file.
+// Each artifact is found in Artifactory by its checksum. The artifacts that can't be found are reported and left out.
+func (rbc *ReleaseBundleCreateCommand) createFromLocalFile(lcServicesManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, queryParams services.CommonOptionalQueryParams) error {
+	filePath := rbc.buildInfoFilePath
+	readComponents := readBuildInfoComponents
+	if rbc.sbomFilePath != "" {
+		filePath, readComponents = rbc.sbomFilePath, readSbomComponents
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	components, err := readComponents(content)
+	if err != nil {
+		return err
+	}
+
+	rtServicesManager, err := rtUtils.CreateServiceManager(rbc.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	artifactsSource, unresolved, ambiguous, err := resolveComponents(rtServicesManager, rbc.rbProjectKey, components)
+	if err != nil {
+		return err
+	}
+	if len(ambiguous) > 0 {
+		if err = coreutils.PrintTable(ambiguous, "Components found in several locations", "", false); err != nil {
+			return err
+		}
+	}
+	if len(unresolved) > 0 {
+		if err = coreutils.PrintTable(unresolved, "Unresolved components", "", false); err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("Resolved %d of %d components of '%s'.", len(components)-len(unresolved), len(components), filePath))
+	if len(artifactsSource.Artifacts) == 0 {
+		return errorutils.CheckErrorf("none of the components of '%s' were found in Artifactory", filePath)
+	}
+	return lcServicesManager.CreateReleaseBundleFromArtifactsDraft(rbDetails, queryParams, rbc.signingKeyName, artifactsSource, rbc.draft)
+}
+
+// readBuildInfoComponents returns the artifacts of the modules of a build-info. Dependencies are not part of the release.
+func readBuildInfoComponents(content []byte) ([]localComponent, error) {
+	build := &buildinfo.BuildInfo{}
+	if err := json.Unmarshal(content, build); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the build-info file: %s", err.Error())
+	}
+	var components []localComponent
+	for _, module := range build.Modules {
+		for _, artifact := range module.Artifacts {
+			name := artifact.Path
+			if name == "" {
+				name = artifact.Name
+			}
+			components = append(components, localComponent{name: name, sha256: strings.ToLower(artifact.Sha256), sha1: strings.ToLower(artifact.Sha1)})
+		}
+	}
+	return components, nil
+}
+
+// readSbomComponents returns the components of a CycloneDX or SPDX JSON document, including nested CycloneDX components.
+func readSbomComponents(content []byte) ([]localComponent, error) {
+	var bom cycloneDxBom
+	if err := json.Unmarshal(content, &bom); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the SBOM file: %s", err.Error())
+	}
+	if strings.EqualFold(bom.BomFormat, "CycloneDX") {
+		return cycloneDxComponents(bom.Components), nil
+	}
+
+	var document spdxDocument
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the SBOM file: %s", err.Error())
+	}
+	if document.SpdxVersion == "" {
+		return nil, errorutils.CheckErrorf("unsupported SBOM format: only CycloneDX and SPDX JSON documents are supported")
+	}
+	var components []localComponent
+	for _, spdxPackage := range document.Packages {
+		component := spdxComponent(spdxPackage.Checksums)
+		component.name = strings.TrimSpace(spdxPackage.Name + " " + spdxPackage.VersionInfo)
+		components = append(components, component)
+	}
+	for _, file := range document.Files {
+		component := spdxComponent(file.Checksums)
+		component.name = file.FileName
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+func cycloneDxComponents(bomComponents []cycloneDxComponent) []localComponent {
+	var components []localComponent
+	for _, bomComponent := range bomComponents {
+		component := localComponent{name: strings.TrimSpace(bomComponent.Name + " " + bomComponent.Version)}
+		for _, hash := range bomComponent.Hashes {
+			switch strings.ToUpper(hash.Alg) {
+			case "SHA-256":
+				component.sha256 = strings.ToLower(hash.Content)
+			case "SHA-1":
+				component.sha1 = strings.ToLower(hash.Content)
+			}
+		}
+		components = append(components, component)
+		components = append(components, cycloneDxComponents(bomComponent.Components)...)
+	}
+	return components
+}
+
+func spdxComponent(checksums []spdxChecksum) localComponent {
+	var component localComponent
+	for _, checksum := range checksums {
+		switch strings.ToUpper(checksum.Algorithm) {
+		case "SHA256":
+			component.sha256 = strings.ToLower(checksum.ChecksumValue)
+		case "SHA1":
+			component.sha1 = strings.ToLower(checksum.ChecksumValue)
+		}
+	}
+	return component
+}
+
+// resolveComponents finds the components in Artifactory by their sha256, or by their sha1 if they have no valid sha256.
+// Files stored in release bundle repositories are ignored. When a file is found in several locations, the local
+// repositories of the project are preferred, then the first path. The components whose location is picked among
+// several equally preferred ones are returned as ambiguous.
+func resolveComponents(rtServicesManager artifactory.ArtifactoryServicesManager, projectKey string, components []localComponent) (
+	artifactsSource services.CreateFromArtifacts, unresolved []unresolvedComponentRow, ambiguous []ambiguousComponentRow, err error) {
+	var checksums []string
+	for _, component := range components {
+		// Only valid checksums are put in the query.
+		if checksum, valid := componentChecksum(component); valid {
+			checksums = append(checksums, checksum)
+		}
+	}
+	var results []rtServicesUtils.ResultItem
+	for start := 0; start < len(checksums); start += checksumsQueryBatchSize {
+		batch, queryErr := artUtils.ExecuteAqlQuery(rtServicesManager, buildChecksumsQuery(checksums[start:min(start+checksumsQueryBatchSize, len(checksums))]))
+		if queryErr != nil {
+			return artifactsSource, nil, nil, queryErr
+		}
+		results = append(results, batch...)
+	}
+	projectRepositories, err := getProjectLocalRepositories(rtServicesManager, projectKey)
+	if err != nil {
+		return artifactsSource, nil, nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return resultItemPath(results[i]) < resultItemPath(results[j])
+	})
+	locations := map[string][]rtServicesUtils.ResultItem{}
+	for i, result := range results {
+		// A file matching checksums of different batches is returned by each of them.
+		if i > 0 && resultItemPath(results[i-1]) == resultItemPath(result) {
+			continue
+		}
+		for _, checksum := range []string{result.Sha256, result.Actual_Sha1} {
+			if checksum != "" {
+				locations[checksum] = append(locations[checksum], result)
+			}
+		}
+	}
+
+	added := map[string]bool{}
+	for _, component := range components {
+		checksum, valid := componentChecksum(component)
+		if checksum == "" {
+			unresolved = append(unresolved, unresolvedComponentRow{Component: component.name, Reason: "no sha256 or sha1 checksum"})
+			continue
+		}
+		if !valid {
+			unresolved = append(unresolved, unresolvedComponentRow{Component: component.name, Checksum: checksum, Reason: "invalid sha256 or sha1 checksum"})
+			continue
+		}
+		if len(locations[checksum]) == 0 {
+			unresolved = append(unresolved, unresolvedComponentRow{Component: component.name, Checksum: checksum, Reason: "not found in Artifactory"})
+			continue
+		}
+		result, otherPaths := selectComponentLocation(locations[checksum], projectRepositories)
+		artifactPath := resultItemPath(result)
+		if added[artifactPath] {
+			continue
+		}
+		added[artifactPath] = true
+		artifactsSource.Artifacts = append(artifactsSource.Artifacts, services.ArtifactSource{Path: artifactPath, Sha256: result.Sha256})
+		if len(otherPaths) > 0 {
+			ambiguous = append(ambiguous, ambiguousComponentRow{Component: component.name, Path: artifactPath, OtherPaths: strings.Join(otherPaths, ", ")})
+		}
+	}
+	return artifactsSource, unresolved, ambiguous, nil
+}
+
+// getProjectLocalRepositories returns the keys of the local repositories of the project.
+func getProjectLocalRepositories(rtServicesManager artifactory.ArtifactoryServicesManager, projectKey string) (map[string]bool, error) {
+	repositories, err := rtServicesManager.GetAllRepositoriesFiltered(rtServices.RepositoriesFilterParams{RepoType: "local", ProjectKey: projectKey})
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]bool{}
+	if repositories != nil {
+		for _, repository := range *repositories {
+			keys[repository.Key] = true
+		}
+	}
+	return keys, nil
+}
+
+// selectComponentLocation picks the first of the sorted locations of a file, preferring the local repositories of the
+// project. It also returns the other locations the file could have been picked from.
+func selectComponentLocation(locations []rtServicesUtils.ResultItem, projectRepositories map[string]bool) (rtServicesUtils.ResultItem, []string) {
+	var preferred []rtServicesUtils.ResultItem
+	for _, location := range locations {
+		if projectRepositories[location.Repo] {
+			preferred = append(preferred, location)
+		}
+	}
+	if len(preferred) == 0 {
+		preferred = locations
+	}
+	var otherPaths []string
+	for _, location := range preferred[1:] {
+		otherPaths = append(otherPaths, resultItemPath(location))
+	}
+	return preferred[0], otherPaths
+}
+
+func resultItemPath(result rtServicesUtils.ResultItem) string {
+	return path.Join(result.Repo, result.Path, result.Name)
+}
+
+// componentChecksum returns the sha256 of the component, or its sha1 if it has no valid sha256. valid is false when
+// the returned checksum isn't a hex string of the expected length.
+func componentChecksum(component localComponent) (checksum string, valid bool) {
+	switch {
+	case isHexChecksum(component.sha256, sha256ChecksumLength):
+		return component.sha256, true
+	case isHexChecksum(component.sha1, sha1ChecksumLength):
+		return component.sha1, true
+	case component.sha256 != "":
+		return component.sha256, false
+	default:
+		return component.sha1, false
+	}
+}
+
+func isHexChecksum(checksum string, length int) bool {
+	if len(checksum) != length {
+		return false
+	}
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
+
+func buildChecksumsQuery(checksums []string) string {
+	clauses := make([]string, len(checksums))
+	for i, checksum := range checksums {
+		field := "sha256"
+		if len(checksum) == sha1ChecksumLength {
+			field = "actual_sha1"
+		}
+		clauses[i] = fmt.Sprintf(`{"%s":%s}`, field, artUtils.AqlString(checksum))
+	}
+	return fmt.Sprintf(`items.find({"repo":{"$nmatch":%s},"$or":[%s]}).include("repo","path","name","sha256","actual_sha1")`,
+		artUtils.AqlString("*"+releaseBundlesV2), strings.Join(clauses, ","))
+}
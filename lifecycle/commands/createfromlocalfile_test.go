@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	rtServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSha256App  = "1111111111111111111111111111111111111111111111111111111111111111"
+	testSha256Core = "2222222222222222222222222222222222222222222222222222222222222222"
+	testSha1Lib    = "3333333333333333333333333333333333333333"
+)
+
+func TestReadBuildInfoComponents(t *testing.T) {
+	components, err := readBuildInfoComponents([]byte(`{"name":"app","number":"12","modules":[
+		{"id":"org:app:1.0","artifacts":[{"name":"app-1.0.jar","path":"org/app/1.0/app-1.0.jar","sha256":"` + strings.ToUpper(testSha256App) + `"}],
+		 "dependencies":[{"id":"lib.jar","sha1":"` + testSha1Lib + `"}]},
+		{"id":"core","artifacts":[{"name":"core.jar","sha1":"` + testSha1Lib + `"}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []localComponent{
+		{name: "org/app/1.0/app-1.0.jar", sha256: testSha256App},
+		{name: "core.jar", sha1: testSha1Lib},
+	}, components)
+
+	_, err = readBuildInfoComponents([]byte("not json"))
+	assert.ErrorContains(t, err, "failed to parse the build-info file")
+}
+
+func TestReadSbomComponents(t *testing.T) {
+	components, err := readSbomComponents([]byte(`{"bomFormat":"CycloneDX","specVersion":"1.5","components":[
+		{"name":"app","version":"1.0","hashes":[{"alg":"SHA-1","content":"` + testSha1Lib + `"},{"alg":"SHA-256","content":"` + testSha256App + `"}],
+		 "components":[{"name":"core","version":"1.0","hashes":[{"alg":"SHA-256","content":"` + testSha256Core + `"}]}]},
+		{"name":"docs"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []localComponent{
+		{name: "app 1.0", sha256: testSha256App, sha1: testSha1Lib},
+		{name: "core 1.0", sha256: testSha256Core},
+		{name: "docs"},
+	}, components)
+
+	components, err = readSbomComponents([]byte(`{"spdxVersion":"SPDX-2.3","packages":[
+		{"name":"app","versionInfo":"1.0","checksums":[{"algorithm":"SHA256","checksumValue":"` + testSha256App + `"}]}],
+		"files":[{"fileName":"./lib.jar","checksums":[{"algorithm":"SHA1","checksumValue":"` + testSha1Lib + `"}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []localComponent{{name: "app 1.0", sha256: testSha256App}, {name: "./lib.jar", sha1: testSha1Lib}}, components)
+
+	_, err = readSbomComponents([]byte(`{"name":"not an sbom"}`))
+	assert.ErrorContains(t, err, "unsupported SBOM format")
+}
+
+func TestResolveComponents(t *testing.T) {
+	manager := &repositoriesServicesManagerMock{
+		// The local repositories of the project.
+		repositories: map[string]rtServices.RepositoryBaseParams{"libs-dev": {}, "libs-release": {}},
+		aqlResult: `{"results":[
+		{"repo":"libs-release","path":"org/app/1.0","name":"app-1.0.jar","sha256":"` + testSha256App + `"},
+		{"repo":"libs-dev","path":"org/app/1.0","name":"app-1.0.jar","sha256":"` + testSha256App + `"},
+		{"repo":"libs-remote-cache","path":"org/app/1.0","name":"app-1.0.jar","sha256":"` + testSha256App + `"},
+		{"repo":"libs-release","path":".","name":"lib.jar","sha256":"` + testSha256Core + `","actual_sha1":"` + testSha1Lib + `"},
+		{"repo":"archive","path":".","name":"lib.jar","sha256":"` + testSha256Core + `","actual_sha1":"` + testSha1Lib + `"}]}`}
+	artifactsSource, unresolved, ambiguous, err := resolveComponents(manager, "my-project", []localComponent{
+		{name: "app", sha256: testSha256App},
+		{name: "lib", sha1: testSha1Lib},
+		{name: "app again", sha256: testSha256App},
+		{name: "missing", sha256: strings.Repeat("4", 64)},
+		{name: "injected", sha256: `1"},{"repo":"secret`},
+		{name: "docs"},
+	})
+	require.NoError(t, err)
+	require.Len(t, manager.queries, 1)
+	assert.Contains(t, manager.queries[0], `{"repo":{"$nmatch":"*release-bundles-v2"},"$or":[{"sha256":"`+testSha256App+`"},{"actual_sha1":"`+testSha1Lib+`"}`)
+	assert.NotContains(t, manager.queries[0], "secret")
+
+	// The local repositories of the project are preferred, so lib.jar is taken from libs-release rather than archive.
+	assert.Equal(t, []services.ArtifactSource{
+		{Path: "libs-dev/org/app/1.0/app-1.0.jar", Sha256: testSha256App},
+		{Path: "libs-release/lib.jar", Sha256: testSha256Core},
+	}, artifactsSource.Artifacts)
+	assert.Equal(t, []ambiguousComponentRow{
+		{Component: "app", Path: "libs-dev/org/app/1.0/app-1.0.jar", OtherPaths: "libs-release/org/app/1.0/app-1.0.jar"},
+	}, ambiguous)
+	assert.Equal(t, []unresolvedComponentRow{
+		{Component: "missing", Checksum: strings.Repeat("4", 64), Reason: "not found in Artifactory"},
+		{Component: "injected", Checksum: `1"},{"repo":"secret`, Reason: "invalid sha256 or sha1 checksum"},
+		{Component: "docs", Reason: "no sha256 or sha1 checksum"},
+	}, unresolved)
+}
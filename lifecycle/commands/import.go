@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type ReleaseBundleImportCommand struct {
 	releaseBundleCmd
-	filePath string
+	filePath      string
+	verify        bool
+	publicKeyPath string
 }
 
 func (rbi *ReleaseBundleImportCommand) ServerDetails() (*config.ServerDetails, error) {
@@ -34,7 +37,22 @@ func (rbi *ReleaseBundleImportCommand) SetFilepath(filePath string) *ReleaseBund
 	return rbi
 }
 
+// SetVerify inspects the archive locally before it is uploaded, and fails if it doesn't pass verification.
+func (rbi *ReleaseBundleImportCommand) SetVerify(verify bool) *ReleaseBundleImportCommand {
+	rbi.verify = verify
+	return rbi
+}
+
+func (rbi *ReleaseBundleImportCommand) SetPublicKeyPath(publicKeyPath string) *ReleaseBundleImportCommand {
+	rbi.publicKeyPath = publicKeyPath
+	return rbi
+}
+
 func (rbi *ReleaseBundleImportCommand) Run() (err error) {
+	// Without the public key the signatures can't be verified, so the archive would pass unsigned.
+	if rbi.verify && rbi.publicKeyPath == "" {
+		return errorutils.CheckErrorf("verifying a release bundle archive requires the public key that signed it (--public-key)")
+	}
 	if err = validateArtifactoryVersionSupported(rbi.serverDetails); err != nil {
 		return
 	}
@@ -50,6 +68,11 @@ func (rbi *ReleaseBundleImportCommand) Run() (err error) {
 	if !exists {
 		return fmt.Errorf("file not found: %s", rbi.filePath)
 	}
+	if rbi.verify {
+		if err = rbi.verifyArchive(); err != nil {
+			return
+		}
+	}
 
 	log.Info("Importing the release bundle archive...")
 	if err = artService.ImportReleaseBundle(rbi.filePath); err != nil {
@@ -58,3 +81,16 @@ func (rbi *ReleaseBundleImportCommand) Run() (err error) {
 	log.Info("Successfully imported the release bundle archive")
 	return
 }
+
+func (rbi *ReleaseBundleImportCommand) verifyArchive() error {
+	log.Info("Verifying the release bundle archive...")
+	inspection, err := InspectReleaseBundleArchive(rbi.filePath, rbi.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	if err = inspection.validationError(); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Release bundle archive of %s/%s verified.", inspection.ReleaseBundleName, inspection.ReleaseBundleVersion))
+	return nil
+}
@@ -0,0 +1,348 @@
+package commands
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	evidenceExtension = ".evd"
+
+	artifactStatusVerified       = "verified"
+	artifactStatusMissing        = "missing"
+	artifactStatusMismatch       = "checksum mismatch"
+	artifactStatusNotInManifest  = "not in manifest"
+	signatureStatusVerified      = "verified"
+	signatureStatusNotVerified   = "not verified"
+	signatureStatusInvalidPrefix = "invalid: "
+)
+
+// ArchiveInspection is the result of inspecting an exported release bundle archive.
+type ArchiveInspection struct {
+	Archive              string               `json:"archive"`
+	ReleaseBundleName    string               `json:"release_bundle_name"`
+	ReleaseBundleVersion string               `json:"release_bundle_version"`
+	Created              string               `json:"created,omitempty"`
+	CreatedBy            string               `json:"created_by,omitempty"`
+	Repositories         []string             `json:"repositories"`
+	Artifacts            []InspectedArtifact  `json:"artifacts"`
+	Signatures           []InspectedSignature `json:"signatures"`
+	// Problems lists everything that makes the archive unsafe to import. An empty list means the archive is valid.
+	Problems []string `json:"problems"`
+}
+
+type InspectedArtifact struct {
+	Path       string `json:"path" col-name:"Path"`
+	Repository string `json:"repository" col-name:"Repository"`
+	Sha256     string `json:"sha256" col-name:"SHA-256"`
+	Status     string `json:"status" col-name:"Status"`
+}
+
+type InspectedSignature struct {
+	File   string `json:"file" col-name:"File"`
+	KeyID  string `json:"keyid,omitempty" col-name:"Key ID"`
+	Status string `json:"status" col-name:"Status"`
+}
+
+func (ai *ArchiveInspection) Valid() bool {
+	return len(ai.Problems) == 0
+}
+
+// archiveManifest is the content of the signed release bundle manifest. It is either the predicate of an in-toto
+// statement or the manifest itself.
+type archiveManifest struct {
+	ReleaseBundleName    string             `json:"release_bundle_name"`
+	ReleaseBundleVersion string             `json:"release_bundle_version"`
+	Created              string             `json:"created"`
+	CreatedBy            string             `json:"created_by"`
+	Artifacts            []manifestArtifact `json:"artifacts"`
+}
+
+type manifestArtifact struct {
+	Path                string `json:"path"`
+	Checksum            string `json:"checksum"`
+	SourceRepositoryKey string `json:"source_repository_key"`
+}
+
+func parseArchiveManifest(payload []byte) (*archiveManifest, error) {
+	var statement struct {
+		archiveManifest
+		Predicate *archiveManifest `json:"predicate"`
+		Subject   []struct {
+			Name   string            `json:"name"`
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+	}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("invalid release bundle manifest: %w", err)
+	}
+	manifest := &statement.archiveManifest
+	if statement.Predicate != nil {
+		manifest = statement.Predicate
+	}
+	// An in-toto statement without an artifacts list describes the artifacts as its subjects.
+	if len(manifest.Artifacts) == 0 {
+		for _, subject := range statement.Subject {
+			manifest.Artifacts = append(manifest.Artifacts, manifestArtifact{Path: subject.Name, Checksum: subject.Digest["sha256"]})
+		}
+	}
+	return manifest, nil
+}
+
+// InspectReleaseBundleArchive reads an exported release bundle archive without extracting it. It verifies every
+// artifact against the checksum recorded in the signed manifest (release-bundle.json.evd), and the signatures of all
+// the evidence files (*.evd) against the PEM public key at publicKeyPath, if one is provided.
+func InspectReleaseBundleArchive(archivePath, publicKeyPath string) (*ArchiveInspection, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to open release bundle archive %s: %s", archivePath, err.Error())
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	inspection := &ArchiveInspection{Archive: archivePath, Repositories: []string{}, Artifacts: []InspectedArtifact{}, Signatures: []InspectedSignature{}, Problems: []string{}}
+	var manifestFile *zip.File
+	var files []*zip.File
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if path.Base(file.Name) == rbV2manifestName && manifestFile == nil {
+			manifestFile = file
+		}
+		files = append(files, file)
+	}
+	if manifestFile == nil {
+		inspection.Problems = append(inspection.Problems, fmt.Sprintf("the archive doesn't contain a %s manifest", rbV2manifestName))
+		return inspection, nil
+	}
+
+	var manifestPayload []byte
+	for _, file := range files {
+		if strings.HasSuffix(file.Name, evidenceExtension) {
+			payload, signature := inspectEnvelope(file, publicKeyPath)
+			inspection.Signatures = append(inspection.Signatures, signature)
+			if strings.HasPrefix(signature.Status, signatureStatusInvalidPrefix) {
+				inspection.Problems = append(inspection.Problems, fmt.Sprintf("signature of %s is %s", file.Name, signature.Status))
+			}
+			if file == manifestFile {
+				manifestPayload = payload
+			}
+		}
+	}
+	if manifestPayload == nil {
+		return inspection, nil
+	}
+	manifest, err := parseArchiveManifest(manifestPayload)
+	if err != nil {
+		inspection.Problems = append(inspection.Problems, err.Error())
+		return inspection, nil
+	}
+	inspection.ReleaseBundleName = manifest.ReleaseBundleName
+	inspection.ReleaseBundleVersion = manifest.ReleaseBundleVersion
+	inspection.Created = manifest.Created
+	inspection.CreatedBy = manifest.CreatedBy
+	if err = inspection.verifyArtifacts(manifest.Artifacts, files); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+// inspectEnvelope decodes a DSSE envelope and verifies its signatures if a public key is provided.
+// The payload is nil if the envelope can't be decoded or its signature is invalid.
+func inspectEnvelope(file *zip.File, publicKeyPath string) ([]byte, InspectedSignature) {
+	signature := InspectedSignature{File: file.Name, Status: signatureStatusNotVerified}
+	content, err := readZipFile(file)
+	if err != nil {
+		signature.Status = signatureStatusInvalidPrefix + err.Error()
+		return nil, signature
+	}
+	envelope := &dsse.Envelope{}
+	if err = json.Unmarshal(content, envelope); err != nil {
+		signature.Status = signatureStatusInvalidPrefix + "not a DSSE envelope"
+		return nil, signature
+	}
+	if len(envelope.Signatures) > 0 {
+		signature.KeyID = envelope.Signatures[0].KeyID
+	}
+	if publicKeyPath == "" {
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			signature.Status = signatureStatusInvalidPrefix + "invalid envelope payload"
+			return nil, signature
+		}
+		return payload, signature
+	}
+	payload, err := envelope.Verify(publicKeyPath)
+	if err != nil {
+		signature.Status = signatureStatusInvalidPrefix + err.Error()
+		return nil, signature
+	}
+	signature.Status = signatureStatusVerified
+	return payload, signature
+}
+
+func (ai *ArchiveInspection) verifyArtifacts(artifacts []manifestArtifact, files []*zip.File) error {
+	listed := map[*zip.File]bool{}
+	repositories := map[string]bool{}
+	for _, artifact := range artifacts {
+		inspected := InspectedArtifact{Path: artifact.Path, Repository: artifact.SourceRepositoryKey, Status: artifactStatusMissing}
+		if inspected.Repository == "" {
+			inspected.Repository, _, _ = strings.Cut(artifact.Path, "/")
+		}
+		repositories[inspected.Repository] = true
+
+		if file := findArchiveFile(files, artifact.Path); file != nil {
+			listed[file] = true
+			checksum, err := sha256OfZipFile(file)
+			if err != nil {
+				return errorutils.CheckErrorf("failed to read %s from the archive: %s", file.Name, err.Error())
+			}
+			inspected.Sha256 = checksum
+			inspected.Status = artifactStatusVerified
+			if !strings.EqualFold(checksum, artifact.Checksum) {
+				inspected.Status = artifactStatusMismatch
+			}
+		}
+		if inspected.Status != artifactStatusVerified {
+			ai.Problems = append(ai.Problems, fmt.Sprintf("%s: %s", artifact.Path, inspected.Status))
+		}
+		ai.Artifacts = append(ai.Artifacts, inspected)
+	}
+
+	// Files that aren't listed in the manifest aren't imported as part of the release bundle, so they are only reported.
+	for _, file := range files {
+		if !listed[file] && !strings.HasSuffix(file.Name, evidenceExtension) {
+			ai.Artifacts = append(ai.Artifacts, InspectedArtifact{Path: file.Name, Status: artifactStatusNotInManifest})
+		}
+	}
+	for repository := range repositories {
+		ai.Repositories = append(ai.Repositories, repository)
+	}
+	sort.Strings(ai.Repositories)
+	return nil
+}
+
+// findArchiveFile finds an artifact in the archive, either at its path or under a top level directory.
+func findArchiveFile(files []*zip.File, artifactPath string) *zip.File {
+	artifactPath = strings.TrimPrefix(artifactPath, "/")
+	for _, file := range files {
+		if file.Name == artifactPath || strings.HasSuffix(file.Name, "/"+artifactPath) {
+			return file
+		}
+	}
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return io.ReadAll(reader)
+}
+
+func sha256OfZipFile(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	hash := sha256.New()
+	// #nosec G110 -- the content is only hashed, never written to disk
+	if _, err = io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ReleaseBundleInspectCommand inspects an exported release bundle archive locally, without a server.
+type ReleaseBundleInspectCommand struct {
+	archivePath   string
+	publicKeyPath string
+	format        string
+}
+
+func NewReleaseBundleInspectCommand() *ReleaseBundleInspectCommand {
+	return &ReleaseBundleInspectCommand{}
+}
+
+func (rbi *ReleaseBundleInspectCommand) SetArchivePath(archivePath string) *ReleaseBundleInspectCommand {
+	rbi.archivePath = archivePath
+	return rbi
+}
+
+func (rbi *ReleaseBundleInspectCommand) SetPublicKeyPath(publicKeyPath string) *ReleaseBundleInspectCommand {
+	rbi.publicKeyPath = publicKeyPath
+	return rbi
+}
+
+func (rbi *ReleaseBundleInspectCommand) SetOutputFormat(format string) *ReleaseBundleInspectCommand {
+	rbi.format = format
+	return rbi
+}
+
+func (rbi *ReleaseBundleInspectCommand) CommandName() string {
+	return "rb_inspect"
+}
+
+func (rbi *ReleaseBundleInspectCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (rbi *ReleaseBundleInspectCommand) Run() error {
+	inspection, err := InspectReleaseBundleArchive(rbi.archivePath, rbi.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	if rbi.format == "json" {
+		content, err := json.Marshal(inspection)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+	} else if err = printArchiveInspection(inspection); err != nil {
+		return err
+	}
+	return inspection.validationError()
+}
+
+func (ai *ArchiveInspection) validationError() error {
+	if ai.Valid() {
+		return nil
+	}
+	return errorutils.CheckErrorf("release bundle archive %s failed verification:\n%s", ai.Archive, strings.Join(ai.Problems, "\n"))
+}
+
+func printArchiveInspection(inspection *ArchiveInspection) error {
+	log.Output(fmt.Sprintf("Release bundle: %s/%s", inspection.ReleaseBundleName, inspection.ReleaseBundleVersion))
+	if inspection.Created != "" {
+		log.Output(fmt.Sprintf("Created: %s by %s", inspection.Created, inspection.CreatedBy))
+	}
+	log.Output("Repositories: " + strings.Join(inspection.Repositories, ", "))
+	if err := coreutils.PrintTable(inspection.Artifacts, "Artifacts", "No artifacts", false); err != nil {
+		return err
+	}
+	return coreutils.PrintTable(inspection.Signatures, "Signatures", "No signatures", false)
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"archive/zip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeSigningKey writes an ECDSA key pair and returns the paths of the private and the public keys.
+func writeSigningKey(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "signing.key")
+	pubPath := filepath.Join(dir, "signing.pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+	return privPath, pubPath
+}
+
+// writeTestBundleArchive writes an exported archive with a signed manifest listing the artifacts with the manifest
+// checksums, and the files with their actual content.
+func writeTestBundleArchive(t *testing.T, dir, privateKeyPath string, manifestChecksums, files map[string]string) string {
+	var artifacts []manifestArtifact
+	for artifactPath, checksum := range manifestChecksums {
+		artifacts = append(artifacts, manifestArtifact{Path: artifactPath, Checksum: checksum})
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"_type": dsse.InTotoStatementType,
+		"predicate": archiveManifest{
+			ReleaseBundleName:    "my-app",
+			ReleaseBundleVersion: "1.0.0",
+			CreatedBy:            "admin",
+			Artifacts:            artifacts,
+		},
+	})
+	require.NoError(t, err)
+	envelope, err := dsse.SignEnvelope(dsse.InTotoPayloadType, payload, privateKeyPath, "rb-key")
+	require.NoError(t, err)
+	manifest, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	archivePath := filepath.Join(dir, "my-app-1.0.0.zip")
+	archive, err := os.Create(archivePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(archive)
+	files["export/"+rbV2manifestName] = string(manifest)
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, archive.Close())
+	return archivePath
+}
+
+func TestInspectReleaseBundleArchive(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := writeSigningKey(t, dir)
+	archivePath := writeTestBundleArchive(t, dir, privPath,
+		map[string]string{"app-repo/app/app.jar": sha256Hex("jar"), "libs-repo/lib/lib.jar": sha256Hex("lib")},
+		map[string]string{"export/app-repo/app/app.jar": "jar", "export/libs-repo/lib/lib.jar": "lib"})
+
+	inspection, err := InspectReleaseBundleArchive(archivePath, pubPath)
+	require.NoError(t, err)
+	assert.True(t, inspection.Valid(), inspection.Problems)
+	assert.Equal(t, "my-app", inspection.ReleaseBundleName)
+	assert.Equal(t, "1.0.0", inspection.ReleaseBundleVersion)
+	assert.Equal(t, []string{"app-repo", "libs-repo"}, inspection.Repositories)
+	assert.ElementsMatch(t, []InspectedArtifact{
+		{Path: "app-repo/app/app.jar", Repository: "app-repo", Sha256: sha256Hex("jar"), Status: artifactStatusVerified},
+		{Path: "libs-repo/lib/lib.jar", Repository: "libs-repo", Sha256: sha256Hex("lib"), Status: artifactStatusVerified},
+	}, inspection.Artifacts)
+	assert.Equal(t, []InspectedSignature{{File: "export/" + rbV2manifestName, KeyID: "rb-key", Status: signatureStatusVerified}}, inspection.Signatures)
+
+	// Without a public key the checksums are still verified, but the signature isn't.
+	inspection, err = InspectReleaseBundleArchive(archivePath, "")
+	require.NoError(t, err)
+	assert.True(t, inspection.Valid(), inspection.Problems)
+	assert.Equal(t, signatureStatusNotVerified, inspection.Signatures[0].Status)
+}
+
+func TestInspectReleaseBundleArchive_Tampered(t *testing.T) {
+	dir := t.TempDir()
+	privPath, _ := writeSigningKey(t, dir)
+	archivePath := writeTestBundleArchive(t, dir, privPath,
+		map[string]string{"app-repo/app/app.jar": sha256Hex("jar"), "app-repo/app/app.pom": sha256Hex("pom")},
+		map[string]string{"app-repo/app/app.jar": "tampered", "app-repo/extra.txt": "extra"})
+
+	inspection, err := InspectReleaseBundleArchive(archivePath, "")
+	require.NoError(t, err)
+	assert.False(t, inspection.Valid())
+	assert.ElementsMatch(t, []string{"app-repo/app/app.jar: checksum mismatch", "app-repo/app/app.pom: missing"}, inspection.Problems)
+	assert.Contains(t, inspection.Artifacts, InspectedArtifact{Path: "app-repo/extra.txt", Status: artifactStatusNotInManifest})
+	assert.ErrorContains(t, inspection.validationError(), "failed verification")
+
+	// A manifest signed by another key is rejected, and its content isn't trusted.
+	_, otherPubPath := writeSigningKey(t, t.TempDir())
+	inspection, err = InspectReleaseBundleArchive(archivePath, otherPubPath)
+	require.NoError(t, err)
+	require.Len(t, inspection.Problems, 1)
+	assert.Contains(t, inspection.Problems[0], "signature does not match the public key")
+	assert.Empty(t, inspection.Artifacts)
+}
+
+func TestInspectReleaseBundleArchive_NoManifest(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "archive.zip")
+	archive, err := os.Create(archivePath)
+	require.NoError(t, err)
+	writer := zip.NewWriter(archive)
+	_, err = writer.Create("app-repo/app.jar")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, archive.Close())
+
+	inspection, err := InspectReleaseBundleArchive(archivePath, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the archive doesn't contain a " + rbV2manifestName + " manifest"}, inspection.Problems)
+
+	_, err = InspectReleaseBundleArchive(filepath.Join(t.TempDir(), "missing.zip"), "")
+	assert.ErrorContains(t, err, "failed to open release bundle archive")
+}
+
+func TestReleaseBundleImport_VerifyRequiresPublicKey(t *testing.T) {
+	err := NewReleaseBundleImportCommand().SetFilepath(filepath.Join(t.TempDir(), "archive.zip")).SetVerify(true).Run()
+	assert.ErrorContains(t, err, "requires the public key")
+}
+
+func TestParseArchiveManifest_Subjects(t *testing.T) {
+	manifest, err := parseArchiveManifest([]byte(`{"subject": [{"name": "app-repo/app.jar", "digest": {"sha256": "abc"}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []manifestArtifact{{Path: "app-repo/app.jar", Checksum: "abc"}}, manifest.Artifacts)
+}
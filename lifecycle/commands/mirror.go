@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/gofrog/crypto"
+	artUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	artServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	mirrorStateFileSuffix        = ".mirror.json"
+	mirrorImportPollIntervalSecs = 10
+	defaultMirrorMaxWaitMinutes  = 10
+)
+
+// mirrorState records a verified archive, so that a mirror whose import failed can resume without exporting again.
+type mirrorState struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+	ArchivePath          string `json:"archive_path"`
+	Sha256               string `json:"sha256"`
+}
+
+func mirrorStatePath(workingDir, name, version string) string {
+	return filepath.Join(workingDir, fmt.Sprintf("%s-%s%s", name, version, mirrorStateFileSuffix))
+}
+
+// loadMirrorState returns the state of a previous run, or nil if there is none or its archive is no longer valid.
+func loadMirrorState(statePath string) (*mirrorState, error) {
+	content, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	state := &mirrorState{}
+	if err = json.Unmarshal(content, state); err != nil {
+		log.Warn(fmt.Sprintf("Ignoring the invalid mirror state file %s: %s", statePath, err.Error()))
+		return nil, nil
+	}
+	if err = verifyArchiveChecksum(state.ArchivePath, state.Sha256); err != nil {
+		log.Warn(fmt.Sprintf("The archive of the previous run can't be reused: %s", err.Error()))
+		return nil, nil
+	}
+	return state, nil
+}
+
+func (ms *mirrorState) save(statePath string) error {
+	content, err := json.MarshalIndent(ms, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(statePath, content, 0600))
+}
+
+func verifyArchiveChecksum(archivePath, expectedSha256 string) error {
+	if expectedSha256 == "" {
+		return errorutils.CheckErrorf("no checksum is available for %s", archivePath)
+	}
+	checksums, err := crypto.GetFileChecksums(archivePath, crypto.SHA256)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !strings.EqualFold(checksums[crypto.SHA256], expectedSha256) {
+		return errorutils.CheckErrorf("checksum mismatch for %s: expected sha256 %s but got %s", archivePath, expectedSha256, checksums[crypto.SHA256])
+	}
+	return nil
+}
+
+// ReleaseBundleMirrorCommand copies a release bundle version from one JFrog instance to another:
+// it exports the version on the source, downloads and verifies the archive, imports it on the target and
+// waits for the version to exist there. When the import fails, the verified archive is kept in the working
+// directory and the next run imports it without exporting again.
+type ReleaseBundleMirrorCommand struct {
+	releaseBundleCmd
+	targetServerDetails    *config.ServerDetails
+	modifications          services.Modifications
+	downloadConfigurations artUtils.DownloadConfiguration
+	workingDir             string
+	maxWaitMinutes         int
+}
+
+func NewReleaseBundleMirrorCommand() *ReleaseBundleMirrorCommand {
+	return &ReleaseBundleMirrorCommand{}
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetSourceServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleMirrorCommand {
+	rbm.serverDetails = serverDetails
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetTargetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleMirrorCommand {
+	rbm.targetServerDetails = serverDetails
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleMirrorCommand {
+	rbm.releaseBundleName = releaseBundleName
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleMirrorCommand {
+	rbm.releaseBundleVersion = releaseBundleVersion
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleMirrorCommand {
+	rbm.rbProjectKey = rbProjectKey
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetModifications(modifications services.Modifications) *ReleaseBundleMirrorCommand {
+	rbm.modifications = modifications
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetDownloadConfiguration(downloadConfig artUtils.DownloadConfiguration) *ReleaseBundleMirrorCommand {
+	rbm.downloadConfigurations = downloadConfig
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetWorkingDir(workingDir string) *ReleaseBundleMirrorCommand {
+	rbm.workingDir = workingDir
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) SetMaxWaitMinutes(maxWaitMinutes int) *ReleaseBundleMirrorCommand {
+	rbm.maxWaitMinutes = maxWaitMinutes
+	return rbm
+}
+
+func (rbm *ReleaseBundleMirrorCommand) CommandName() string {
+	return "rb_mirror"
+}
+
+// ServerDetails returns the source server, to which the command usage is reported.
+func (rbm *ReleaseBundleMirrorCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbm.serverDetails, nil
+}
+
+func (rbm *ReleaseBundleMirrorCommand) Run() (err error) {
+	if rbm.serverDetails == nil || rbm.targetServerDetails == nil {
+		return errorutils.CheckErrorf("both the source and the target servers must be provided")
+	}
+	if err = validateArtifactoryVersionSupported(rbm.serverDetails); err != nil {
+		return
+	}
+	if err = validateArtifactoryVersionSupported(rbm.targetServerDetails); err != nil {
+		return
+	}
+	bundle := rbm.releaseBundleName + "/" + rbm.releaseBundleVersion
+	exists, err := rbm.existsOnTarget()
+	if err != nil {
+		return
+	}
+	if exists {
+		log.Info(fmt.Sprintf("Release bundle %s already exists on the target server.", bundle))
+		return
+	}
+
+	if rbm.workingDir == "" {
+		rbm.workingDir = "."
+	}
+	if err = fileutils.CreateDirIfNotExist(rbm.workingDir); err != nil {
+		return
+	}
+	statePath := mirrorStatePath(rbm.workingDir, rbm.releaseBundleName, rbm.releaseBundleVersion)
+	state, err := loadMirrorState(statePath)
+	if err != nil {
+		return
+	}
+	if state != nil {
+		log.Info(fmt.Sprintf("Resuming with the archive exported by a previous run: %s", state.ArchivePath))
+	} else {
+		if state, err = rbm.exportAndDownload(); err != nil {
+			return
+		}
+		if err = state.save(statePath); err != nil {
+			return
+		}
+	}
+
+	log.Info(fmt.Sprintf("Importing %s into the target server...", bundle))
+	if err = NewReleaseBundleImportCommand().SetServerDetails(rbm.targetServerDetails).SetFilepath(state.ArchivePath).Run(); err != nil {
+		return errorutils.CheckErrorf("failed to import %s: %s\nThe verified archive was kept, run the command again to retry the import.", bundle, err.Error())
+	}
+	if err = rbm.waitForTarget(); err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Release bundle %s was mirrored successfully.", bundle))
+	return errors.Join(os.Remove(state.ArchivePath), os.Remove(statePath))
+}
+
+// exportAndDownload exports the version on the source server, downloads the archive and verifies it against
+// the checksum reported by the source.
+func (rbm *ReleaseBundleMirrorCommand) exportAndDownload() (*mirrorState, error) {
+	servicesManager, rbDetails, queryParams, err := rbm.getPrerequisites()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Exporting Release Bundle archive...")
+	exportResponse, err := servicesManager.ExportReleaseBundle(rbDetails, rbm.modifications, queryParams)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed exporting release bundle: %s", err.Error())
+	}
+
+	archiveRelativePath := strings.TrimPrefix(exportResponse.RelativeUrl, "/")
+	artifactoryServiceManager, err := createArtifactoryServiceManager(rbm.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := artifactoryServiceManager.FileInfo(archiveRelativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Downloading Release Bundle archive...")
+	downloaded, failed, err := artifactoryServiceManager.DownloadFiles(artServices.DownloadParams{
+		CommonParams: &utils.CommonParams{
+			Pattern: archiveRelativePath,
+			Target:  clientutils.AddTrailingSlashIfNeeded(rbm.workingDir),
+		},
+		Flat:         true,
+		MinSplitSize: rbm.downloadConfigurations.MinSplitSize,
+		SplitCount:   rbm.downloadConfigurations.SplitCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failed > 0 || downloaded < 1 {
+		return nil, errorutils.CheckErrorf("failed to download the exported archive %s", archiveRelativePath)
+	}
+
+	state := &mirrorState{
+		ReleaseBundleName:    rbm.releaseBundleName,
+		ReleaseBundleVersion: rbm.releaseBundleVersion,
+		ArchivePath:          filepath.Join(rbm.workingDir, path.Base(archiveRelativePath)),
+		Sha256:               fileInfo.Checksums.Sha256,
+	}
+	if err = verifyArchiveChecksum(state.ArchivePath, state.Sha256); err != nil {
+		return nil, errors.Join(err, os.Remove(state.ArchivePath))
+	}
+	log.Info("Release Bundle archive verified: sha256 " + state.Sha256)
+	return state, nil
+}
+
+func (rbm *ReleaseBundleMirrorCommand) existsOnTarget() (bool, error) {
+	targetManager, err := artUtils.CreateLifecycleServiceManager(rbm.targetServerDetails, false)
+	if err != nil {
+		return false, err
+	}
+	return targetManager.IsReleaseBundleExist(rbm.releaseBundleName, rbm.releaseBundleVersion, rbm.rbProjectKey)
+}
+
+// waitForTarget waits for the imported version to become available on the target server.
+func (rbm *ReleaseBundleMirrorCommand) waitForTarget() error {
+	maxWaitMinutes := rbm.maxWaitMinutes
+	if maxWaitMinutes <= 0 {
+		maxWaitMinutes = defaultMirrorMaxWaitMinutes
+	}
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:               maxWaitMinutes * 60 / mirrorImportPollIntervalSecs,
+		RetriesIntervalMilliSecs: mirrorImportPollIntervalSecs * 1000,
+		ErrorMessage:             "The imported release bundle is not available on the target server yet",
+		ExecutionHandler: func() (bool, error) {
+			exists, err := rbm.existsOnTarget()
+			if err != nil {
+				return false, err
+			}
+			return !exists, nil
+		},
+	}
+	return retryExecutor.Execute()
+}
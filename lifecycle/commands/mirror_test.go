@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestArchive writes an archive and returns its path and SHA-256.
+func writeTestArchive(t *testing.T, dir string) (string, string) {
+	archivePath := filepath.Join(dir, "my-app-1.0.0.zip")
+	require.NoError(t, os.WriteFile(archivePath, []byte("archive content"), 0644))
+	return archivePath, "fa868b2818c90263b5c2c8e056180232a6f3c34547ca49b7f3ca10599a52db3d"
+}
+
+func TestVerifyArchiveChecksum(t *testing.T) {
+	archivePath, checksum := writeTestArchive(t, t.TempDir())
+	assert.NoError(t, verifyArchiveChecksum(archivePath, checksum))
+	assert.NoError(t, verifyArchiveChecksum(archivePath, strings.ToUpper(checksum)))
+	assert.ErrorContains(t, verifyArchiveChecksum(archivePath, strings.Repeat("0", 64)), "checksum mismatch")
+	assert.ErrorContains(t, verifyArchiveChecksum(archivePath, ""), "no checksum is available")
+	assert.Error(t, verifyArchiveChecksum(filepath.Join(t.TempDir(), "missing.zip"), checksum))
+}
+
+func TestMirrorState(t *testing.T) {
+	dir := t.TempDir()
+	statePath := mirrorStatePath(dir, "my-app", "1.0.0")
+	assert.Equal(t, filepath.Join(dir, "my-app-1.0.0.mirror.json"), statePath)
+
+	// No previous run.
+	state, err := loadMirrorState(statePath)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	archivePath, checksum := writeTestArchive(t, dir)
+	saved := &mirrorState{ReleaseBundleName: "my-app", ReleaseBundleVersion: "1.0.0", ArchivePath: archivePath, Sha256: checksum}
+	require.NoError(t, saved.save(statePath))
+	state, err = loadMirrorState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, saved, state)
+
+	// An archive that changed since it was verified must be exported again.
+	require.NoError(t, os.WriteFile(archivePath, []byte("truncated"), 0644))
+	state, err = loadMirrorState(statePath)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	// So does an archive that was removed, or an unreadable state file.
+	require.NoError(t, os.Remove(archivePath))
+	state, err = loadMirrorState(statePath)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+	require.NoError(t, os.WriteFile(statePath, []byte("{"), 0644))
+	state, err = loadMirrorState(statePath)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
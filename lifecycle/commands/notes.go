@@ -0,0 +1,546 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	artServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	NotesFormatMarkdown = "markdown"
+	NotesFormatHtml     = "html"
+	NotesFormatJson     = "json"
+
+	NotesAttachAnnotation = "annotation"
+	NotesAttachEvidence   = "evidence"
+
+	releaseNotesPredicateType = "https://jfrog.com/evidence/release-notes/v1"
+	releaseNotesIssuesProp    = "release.notes.issues"
+	releaseNotesBuildsProp    = "release.notes.builds"
+	releaseNotesSinceProp     = "release.notes.since"
+	otherArtifactsModule      = "Other artifacts"
+	shortRevisionLength       = 8
+)
+
+// ReleaseNotes describes the changes that a release bundle version brings, as collected from the build-info of its
+// source builds.
+type ReleaseNotes struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+	SinceVersion         string `json:"since_version,omitempty"`
+	// Builds lists the builds, as "name/number", whose commits and issues are included in the notes.
+	Builds  []string             `json:"builds"`
+	Issues  []ReleaseNotesIssue  `json:"issues"`
+	Commits []ReleaseNotesCommit `json:"commits"`
+	Modules []ReleaseNotesModule `json:"modules"`
+}
+
+type ReleaseNotesIssue struct {
+	Key     string `json:"key"`
+	Url     string `json:"url,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type ReleaseNotesCommit struct {
+	Revision string `json:"revision"`
+	Message  string `json:"message,omitempty"`
+	Url      string `json:"url,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Build    string `json:"build"`
+}
+
+type ReleaseNotesModule struct {
+	Name      string   `json:"name"`
+	Artifacts []string `json:"artifacts"`
+}
+
+// splitSourceBuild splits a "name/number" source build. Build names may contain slashes, build numbers usually don't.
+func splitSourceBuild(sourceBuild string) (name, number string) {
+	separator := strings.LastIndex(sourceBuild, "/")
+	if separator < 0 {
+		return sourceBuild, ""
+	}
+	return sourceBuild[:separator], sourceBuild[separator+1:]
+}
+
+// buildRunsInRange returns the numbers of the runs that started after the run fromNumber, up to and including the run
+// toNumber, from the oldest to the newest. If fromNumber isn't found, only toNumber is returned.
+func buildRunsInRange(runs []buildinfo.BuildRun, fromNumber, toNumber string) []string {
+	sorted := artUtils.SortBuildRuns(runs)
+	fromIndex, toIndex := -1, -1
+	for i, run := range sorted {
+		switch strings.TrimPrefix(run.Uri, "/") {
+		case fromNumber:
+			fromIndex = i
+		case toNumber:
+			toIndex = i
+		}
+	}
+	if fromIndex < 0 || toIndex < 0 {
+		return []string{toNumber}
+	}
+	// The runs are sorted from the latest, so the range is walked backwards to list the oldest first.
+	var numbers []string
+	for i := fromIndex - 1; i >= toIndex; i-- {
+		numbers = append(numbers, strings.TrimPrefix(sorted[i].Uri, "/"))
+	}
+	return numbers
+}
+
+// newReleaseNotes builds the notes of a version. bundleBuilds are the source builds of the version, used to find the
+// module of each artifact, and changeBuilds are the builds whose commits and issues are new in the version.
+func newReleaseNotes(name, version, sinceVersion string, content *releaseBundleContent, bundleBuilds, changeBuilds []*buildinfo.BuildInfo) *ReleaseNotes {
+	notes := &ReleaseNotes{
+		ReleaseBundleName:    name,
+		ReleaseBundleVersion: version,
+		SinceVersion:         sinceVersion,
+		Builds:               []string{},
+		Issues:               []ReleaseNotesIssue{},
+		Commits:              []ReleaseNotesCommit{},
+		Modules:              []ReleaseNotesModule{},
+	}
+
+	seenIssues, seenRevisions := map[string]bool{}, map[string]bool{}
+	for _, build := range changeBuilds {
+		buildId := build.Name + "/" + build.Number
+		notes.Builds = append(notes.Builds, buildId)
+		for _, vcs := range build.VcsList {
+			if vcs.Revision == "" || seenRevisions[vcs.Revision] {
+				continue
+			}
+			seenRevisions[vcs.Revision] = true
+			notes.Commits = append(notes.Commits, ReleaseNotesCommit{Revision: vcs.Revision, Message: vcs.Message, Url: vcs.Url, Branch: vcs.Branch, Build: buildId})
+		}
+		if build.Issues == nil {
+			continue
+		}
+		for _, issue := range build.Issues.AffectedIssues {
+			if issue.Key == "" || seenIssues[issue.Key] {
+				continue
+			}
+			seenIssues[issue.Key] = true
+			notes.Issues = append(notes.Issues, ReleaseNotesIssue{Key: issue.Key, Url: issue.Url, Summary: issue.Summary})
+		}
+	}
+	sort.Slice(notes.Issues, func(i, j int) bool {
+		return notes.Issues[i].Key < notes.Issues[j].Key
+	})
+
+	moduleBySha256 := map[string]string{}
+	for _, build := range bundleBuilds {
+		for _, module := range build.Modules {
+			for _, artifact := range module.Artifacts {
+				if _, exists := moduleBySha256[artifact.Sha256]; artifact.Sha256 != "" && !exists {
+					moduleBySha256[artifact.Sha256] = module.Id
+				}
+			}
+		}
+	}
+	artifactsByModule := map[string][]string{}
+	for _, path := range sortedKeys(content.artifacts) {
+		module, found := moduleBySha256[content.artifacts[path].sha256]
+		if !found {
+			module = otherArtifactsModule
+		}
+		artifactsByModule[module] = append(artifactsByModule[module], path)
+	}
+	for _, module := range sortedKeys(artifactsByModule) {
+		if module != otherArtifactsModule {
+			notes.Modules = append(notes.Modules, ReleaseNotesModule{Name: module, Artifacts: artifactsByModule[module]})
+		}
+	}
+	if others, found := artifactsByModule[otherArtifactsModule]; found {
+		notes.Modules = append(notes.Modules, ReleaseNotesModule{Name: otherArtifactsModule, Artifacts: others})
+	}
+	return notes
+}
+
+func renderReleaseNotes(notes *ReleaseNotes, format string) (string, error) {
+	switch format {
+	case "", NotesFormatMarkdown:
+		return renderReleaseNotesMarkdown(notes), nil
+	case NotesFormatHtml:
+		return renderReleaseNotesHtml(notes), nil
+	case NotesFormatJson:
+		content, err := json.Marshal(notes)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		return clientutils.IndentJson(content), nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported release notes format '%s'. Possible values: %s, %s, %s", format, NotesFormatMarkdown, NotesFormatHtml, NotesFormatJson)
+	}
+}
+
+func renderReleaseNotesMarkdown(notes *ReleaseNotes) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s %s\n", notes.ReleaseBundleName, notes.ReleaseBundleVersion))
+	if notes.SinceVersion != "" {
+		sb.WriteString(fmt.Sprintf("\nChanges since %s.\n", notes.SinceVersion))
+	}
+	if len(notes.Issues) > 0 {
+		sb.WriteString("\n## Issues\n\n")
+		for _, issue := range notes.Issues {
+			key := issue.Key
+			if issue.Url != "" {
+				key = fmt.Sprintf("[%s](%s)", issue.Key, issue.Url)
+			}
+			sb.WriteString(strings.TrimSpace(fmt.Sprintf("- %s %s", key, issue.Summary)) + "\n")
+		}
+	}
+	if len(notes.Commits) > 0 {
+		sb.WriteString("\n## Commits\n\n")
+		for _, commit := range notes.Commits {
+			sb.WriteString(fmt.Sprintf("- `%s` %s (%s)\n", shortRevision(commit.Revision), firstLine(commit.Message), commit.Build))
+		}
+	}
+	if len(notes.Modules) > 0 {
+		sb.WriteString("\n## Artifacts\n")
+		for _, module := range notes.Modules {
+			sb.WriteString(fmt.Sprintf("\n### %s\n\n", module.Name))
+			for _, artifact := range module.Artifacts {
+				sb.WriteString(fmt.Sprintf("- %s\n", artifact))
+			}
+		}
+	}
+	if len(notes.Builds) > 0 {
+		sb.WriteString("\n## Builds\n\n")
+		for _, build := range notes.Builds {
+			sb.WriteString(fmt.Sprintf("- %s\n", build))
+		}
+	}
+	return sb.String()
+}
+
+func renderReleaseNotesHtml(notes *ReleaseNotes) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<h1>%s %s</h1>\n", html.EscapeString(notes.ReleaseBundleName), html.EscapeString(notes.ReleaseBundleVersion)))
+	if notes.SinceVersion != "" {
+		sb.WriteString(fmt.Sprintf("<p>Changes since %s.</p>\n", html.EscapeString(notes.SinceVersion)))
+	}
+	if len(notes.Issues) > 0 {
+		sb.WriteString("<h2>Issues</h2>\n<ul>\n")
+		for _, issue := range notes.Issues {
+			key := html.EscapeString(issue.Key)
+			if issue.Url != "" {
+				key = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(issue.Url), key)
+			}
+			sb.WriteString(fmt.Sprintf("<li>%s %s</li>\n", key, html.EscapeString(issue.Summary)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+	if len(notes.Commits) > 0 {
+		sb.WriteString("<h2>Commits</h2>\n<ul>\n")
+		for _, commit := range notes.Commits {
+			sb.WriteString(fmt.Sprintf("<li><code>%s</code> %s (%s)</li>\n", html.EscapeString(shortRevision(commit.Revision)),
+				html.EscapeString(firstLine(commit.Message)), html.EscapeString(commit.Build)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+	if len(notes.Modules) > 0 {
+		sb.WriteString("<h2>Artifacts</h2>\n")
+		for _, module := range notes.Modules {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n<ul>\n", html.EscapeString(module.Name)))
+			for _, artifact := range module.Artifacts {
+				sb.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(artifact)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+	}
+	if len(notes.Builds) > 0 {
+		sb.WriteString("<h2>Builds</h2>\n<ul>\n")
+		for _, build := range notes.Builds {
+			sb.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(build)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+	return sb.String()
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > shortRevisionLength {
+		return revision[:shortRevisionLength]
+	}
+	return revision
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return line
+}
+
+// releaseNotesAnnotation returns the properties that summarize the notes, in the format of the annotate command.
+func releaseNotesAnnotation(notes *ReleaseNotes) string {
+	var issueKeys []string
+	for _, issue := range notes.Issues {
+		issueKeys = append(issueKeys, issue.Key)
+	}
+	props := []string{releaseNotesBuildsProp + "=" + strings.Join(notes.Builds, ",")}
+	if len(issueKeys) > 0 {
+		props = append(props, releaseNotesIssuesProp+"="+strings.Join(issueKeys, ","))
+	}
+	if notes.SinceVersion != "" {
+		props = append(props, releaseNotesSinceProp+"="+notes.SinceVersion)
+	}
+	return strings.Join(props, ";")
+}
+
+// ReleaseBundleNotesCommand generates release notes for a release bundle version from the build-info of its source
+// builds: the commits and issues of the builds since the previous version, and the artifacts grouped by build module.
+type ReleaseBundleNotesCommand struct {
+	releaseBundleCmd
+	sinceVersion string
+	format       string
+	attach       string
+	keyPath      string
+	keyAlias     string
+}
+
+func NewReleaseBundleNotesCommand() *ReleaseBundleNotesCommand {
+	return &ReleaseBundleNotesCommand{}
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleNotesCommand {
+	rbn.serverDetails = serverDetails
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleNotesCommand {
+	rbn.releaseBundleName = releaseBundleName
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleNotesCommand {
+	rbn.releaseBundleVersion = releaseBundleVersion
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleNotesCommand {
+	rbn.rbProjectKey = rbProjectKey
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetSinceVersion(sinceVersion string) *ReleaseBundleNotesCommand {
+	rbn.sinceVersion = sinceVersion
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) SetOutputFormat(format string) *ReleaseBundleNotesCommand {
+	rbn.format = format
+	return rbn
+}
+
+// SetAttach attaches the notes to the release bundle, either as an annotation or as evidence.
+func (rbn *ReleaseBundleNotesCommand) SetAttach(attach string) *ReleaseBundleNotesCommand {
+	rbn.attach = attach
+	return rbn
+}
+
+// SetEvidenceKey sets the private key that signs the evidence, when the notes are attached as evidence.
+func (rbn *ReleaseBundleNotesCommand) SetEvidenceKey(keyPath, keyAlias string) *ReleaseBundleNotesCommand {
+	rbn.keyPath = keyPath
+	rbn.keyAlias = keyAlias
+	return rbn
+}
+
+func (rbn *ReleaseBundleNotesCommand) CommandName() string {
+	return "rb_notes"
+}
+
+func (rbn *ReleaseBundleNotesCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbn.serverDetails, nil
+}
+
+func (rbn *ReleaseBundleNotesCommand) Run() error {
+	switch rbn.format {
+	case "", NotesFormatMarkdown, NotesFormatHtml, NotesFormatJson:
+	default:
+		return errorutils.CheckErrorf("unsupported release notes format '%s'. Possible values: %s, %s, %s", rbn.format, NotesFormatMarkdown, NotesFormatHtml, NotesFormatJson)
+	}
+	switch rbn.attach {
+	case "", NotesAttachAnnotation:
+	case NotesAttachEvidence:
+		if rbn.keyPath == "" {
+			return errorutils.CheckErrorf("attaching the release notes as evidence requires a signing key")
+		}
+	default:
+		return errorutils.CheckErrorf("unsupported attach option '%s'. Possible values: %s, %s", rbn.attach, NotesAttachAnnotation, NotesAttachEvidence)
+	}
+	if err := validateArtifactoryVersionSupported(rbn.serverDetails); err != nil {
+		return err
+	}
+	lcServicesManager, err := rtUtils.CreateLifecycleServiceManager(rbn.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	rtServicesManager, err := rtUtils.CreateServiceManager(rbn.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+
+	content, err := getReleaseBundleContent(rbn.serverDetails, lcServicesManager, rtServicesManager, rbn.rbProjectKey, rbn.releaseBundleName, rbn.releaseBundleVersion)
+	if err != nil {
+		return err
+	}
+	previousBuilds := map[string]bool{}
+	if rbn.sinceVersion != "" {
+		previous, err := getReleaseBundleContent(rbn.serverDetails, lcServicesManager, rtServicesManager, rbn.rbProjectKey, rbn.releaseBundleName, rbn.sinceVersion)
+		if err != nil {
+			return err
+		}
+		previousBuilds = sourceBuilds(previous)
+	}
+	bundleBuilds, changeBuilds, err := rbn.collectBuilds(rtServicesManager, sourceBuilds(content), previousBuilds)
+	if err != nil {
+		return err
+	}
+
+	notes := newReleaseNotes(rbn.releaseBundleName, rbn.releaseBundleVersion, rbn.sinceVersion, content, bundleBuilds, changeBuilds)
+	rendered, err := renderReleaseNotes(notes, rbn.format)
+	if err != nil {
+		return err
+	}
+	log.Output(rendered)
+
+	switch rbn.attach {
+	case NotesAttachAnnotation:
+		return NewReleaseBundleAnnotateCommand().
+			SetServerDetails(rbn.serverDetails).
+			SetReleaseBundleName(rbn.releaseBundleName).
+			SetReleaseBundleVersion(rbn.releaseBundleVersion).
+			SetReleaseBundleProject(rbn.rbProjectKey).
+			SetProps(releaseNotesAnnotation(notes)).
+			SetRecursive(false, true).
+			Run()
+	case NotesAttachEvidence:
+		return rbn.attachEvidence(rtServicesManager, notes)
+	}
+	return nil
+}
+
+// collectBuilds fetches the build-info of the source builds of the version, and of the builds whose changes are new
+// in it: for every build that changed since the previous version, all the runs after the one in the previous version.
+func (rbn *ReleaseBundleNotesCommand) collectBuilds(rtServicesManager artifactory.ArtifactoryServicesManager,
+	currentBuilds, previousBuilds map[string]bool) (bundleBuilds, changeBuilds []*buildinfo.BuildInfo, err error) {
+	previousNumbers := map[string]string{}
+	for _, previousBuild := range sortedKeys(previousBuilds) {
+		name, number := splitSourceBuild(previousBuild)
+		previousNumbers[name] = number
+	}
+
+	fetched := map[string]*buildinfo.BuildInfo{}
+	getBuild := func(name, number string) (*buildinfo.BuildInfo, error) {
+		key := name + "/" + number
+		if build, exists := fetched[key]; exists {
+			return build, nil
+		}
+		published, found, err := rtServicesManager.GetBuildInfo(artServices.BuildInfoParams{BuildName: name, BuildNumber: number, ProjectKey: rbn.rbProjectKey})
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Warn(fmt.Sprintf("The build-info of %s wasn't found, its changes are not included in the release notes.", key))
+			fetched[key] = nil
+			return nil, nil
+		}
+		fetched[key] = &published.BuildInfo
+		return fetched[key], nil
+	}
+
+	for _, currentBuild := range sortedKeys(currentBuilds) {
+		name, number := splitSourceBuild(currentBuild)
+		build, err := getBuild(name, number)
+		if err != nil {
+			return nil, nil, err
+		}
+		if build != nil {
+			bundleBuilds = append(bundleBuilds, build)
+		}
+
+		previousNumber, hasPrevious := previousNumbers[name]
+		if previousNumber == number {
+			continue
+		}
+		numbers := []string{number}
+		if hasPrevious {
+			runs, found, err := rtServicesManager.GetBuildRuns(artServices.BuildInfoParams{BuildName: name, ProjectKey: rbn.rbProjectKey})
+			if err != nil {
+				return nil, nil, err
+			}
+			if found {
+				numbers = buildRunsInRange(runs.BuildsNumbers, previousNumber, number)
+			}
+		}
+		for _, runNumber := range numbers {
+			run, err := getBuild(name, runNumber)
+			if err != nil {
+				return nil, nil, err
+			}
+			if run != nil {
+				changeBuilds = append(changeBuilds, run)
+			}
+		}
+	}
+	return bundleBuilds, changeBuilds, nil
+}
+
+// attachEvidence attaches the notes to the release bundle manifest as signed evidence, with the JSON notes as the
+// predicate and the Markdown notes as its markdown.
+func (rbn *ReleaseBundleNotesCommand) attachEvidence(rtServicesManager artifactory.ArtifactoryServicesManager, notes *ReleaseNotes) (err error) {
+	manifestPath := buildManifestPath(rbn.rbProjectKey, rbn.releaseBundleName, rbn.releaseBundleVersion)
+	manifestInfo, err := rtServicesManager.FileInfo(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	predicate, err := json.Marshal(notes)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	predicatePath := filepath.Join(tempDir, "release-notes.json")
+	markdownPath := filepath.Join(tempDir, "release-notes.md")
+	if err = os.WriteFile(predicatePath, predicate, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(markdownPath, []byte(renderReleaseNotesMarkdown(notes)), 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	if err = evidenceutils.CreateEvidence(rbn.serverDetails, evidenceutils.CreateEvidenceOpts{
+		SubjectRepoPath: manifestPath,
+		SubjectSHA256:   manifestInfo.Checksums.Sha256,
+		PredicatePath:   predicatePath,
+		PredicateType:   releaseNotesPredicateType,
+		MarkdownPath:    markdownPath,
+		KeyPath:         rbn.keyPath,
+		KeyAlias:        rbn.keyAlias,
+	}); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Release notes attached as evidence to %s/%s.", rbn.releaseBundleName, rbn.releaseBundleVersion))
+	return nil
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	artServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type buildsServicesManagerMock struct {
+	artifactory.EmptyArtifactoryServicesManager
+	builds map[string]*buildinfo.BuildInfo
+	runs   map[string][]buildinfo.BuildRun
+}
+
+func (bsm *buildsServicesManagerMock) GetBuildInfo(params artServices.BuildInfoParams) (*buildinfo.PublishedBuildInfo, bool, error) {
+	build, found := bsm.builds[params.BuildName+"/"+params.BuildNumber]
+	if !found {
+		return nil, false, nil
+	}
+	return &buildinfo.PublishedBuildInfo{BuildInfo: *build}, true, nil
+}
+
+func (bsm *buildsServicesManagerMock) GetBuildRuns(params artServices.BuildInfoParams) (*buildinfo.BuildRuns, bool, error) {
+	runs, found := bsm.runs[params.BuildName]
+	return &buildinfo.BuildRuns{BuildsNumbers: runs}, found, nil
+}
+
+func testBuild(name, number, revision, issueKey string) *buildinfo.BuildInfo {
+	build := &buildinfo.BuildInfo{Name: name, Number: number, VcsList: []buildinfo.Vcs{{Revision: revision, Message: "commit " + revision}}}
+	if issueKey != "" {
+		build.Issues = &buildinfo.Issues{AffectedIssues: []buildinfo.AffectedIssue{{Key: issueKey, Summary: "summary of " + issueKey}}}
+	}
+	return build
+}
+
+func TestSplitSourceBuild(t *testing.T) {
+	name, number := splitSourceBuild("org/app/42")
+	assert.Equal(t, "org/app", name)
+	assert.Equal(t, "42", number)
+	name, number = splitSourceBuild("app")
+	assert.Equal(t, "app", name)
+	assert.Empty(t, number)
+}
+
+func TestBuildRunsInRange(t *testing.T) {
+	runs := []buildinfo.BuildRun{
+		{Uri: "/13", Started: "2026-05-04T10:00:00.000+0000"},
+		{Uri: "/10", Started: "2026-05-01T10:00:00.000+0000"},
+		{Uri: "/11", Started: "2026-05-02T10:00:00.000+0000"},
+		{Uri: "/12", Started: "2026-05-03T12:00:00.000+0200"},
+	}
+	assert.Equal(t, []string{"11", "12"}, buildRunsInRange(runs, "10", "12"))
+	assert.Equal(t, []string{"13"}, buildRunsInRange(runs, "12", "13"))
+	assert.Equal(t, []string{"12"}, buildRunsInRange(runs, "9", "12"))
+	assert.Empty(t, buildRunsInRange(runs, "13", "12"))
+}
+
+func TestNewReleaseNotes(t *testing.T) {
+	content := &releaseBundleContent{artifacts: map[string]artifactContent{
+		"libs/app/app.jar":   {sha256: "sha-app"},
+		"libs/app/app.pom":   {sha256: "sha-pom"},
+		"libs/core/core.jar": {sha256: "sha-core"},
+		"libs/notes.txt":     {sha256: "sha-notes"},
+	}}
+	current := testBuild("app", "12", "c12", "APP-2")
+	current.Modules = []buildinfo.Module{
+		{Id: "org:app:1.2", Artifacts: []buildinfo.Artifact{{Checksum: buildinfo.Checksum{Sha256: "sha-app"}}, {Checksum: buildinfo.Checksum{Sha256: "sha-pom"}}}},
+		{Id: "org:core:1.2", Artifacts: []buildinfo.Artifact{{Checksum: buildinfo.Checksum{Sha256: "sha-core"}}}},
+	}
+	// The same commit can be built twice, and an issue can be referenced by several builds.
+	previous := testBuild("app", "11", "c11", "APP-3")
+	rebuilt := testBuild("app", "11.1", "c11", "APP-2")
+
+	notes := newReleaseNotes("my-app", "1.2.0", "1.1.0", content, []*buildinfo.BuildInfo{current}, []*buildinfo.BuildInfo{previous, rebuilt, current})
+	assert.Equal(t, []string{"app/11", "app/11.1", "app/12"}, notes.Builds)
+	assert.Equal(t, []ReleaseNotesCommit{
+		{Revision: "c11", Message: "commit c11", Build: "app/11"},
+		{Revision: "c12", Message: "commit c12", Build: "app/12"},
+	}, notes.Commits)
+	assert.Equal(t, []ReleaseNotesIssue{{Key: "APP-2", Summary: "summary of APP-2"}, {Key: "APP-3", Summary: "summary of APP-3"}}, notes.Issues)
+	assert.Equal(t, []ReleaseNotesModule{
+		{Name: "org:app:1.2", Artifacts: []string{"libs/app/app.jar", "libs/app/app.pom"}},
+		{Name: "org:core:1.2", Artifacts: []string{"libs/core/core.jar"}},
+		{Name: otherArtifactsModule, Artifacts: []string{"libs/notes.txt"}},
+	}, notes.Modules)
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	notes := &ReleaseNotes{
+		ReleaseBundleName:    "my-app",
+		ReleaseBundleVersion: "1.2.0",
+		SinceVersion:         "1.1.0",
+		Builds:               []string{"app/12"},
+		Issues:               []ReleaseNotesIssue{{Key: "APP-2", Url: "https://jira/APP-2", Summary: "Fix <script> injection"}, {Key: "APP-3"}},
+		Commits:              []ReleaseNotesCommit{{Revision: "0123456789abcdef", Message: "Fix injection\n\nDetails", Build: "app/12"}},
+		Modules:              []ReleaseNotesModule{{Name: "org:app:1.2", Artifacts: []string{"libs/app/app.jar"}}},
+	}
+
+	markdown, err := renderReleaseNotes(notes, "")
+	require.NoError(t, err)
+	assert.Equal(t, "# my-app 1.2.0\n\nChanges since 1.1.0.\n\n"+
+		"## Issues\n\n- [APP-2](https://jira/APP-2) Fix <script> injection\n- APP-3\n\n"+
+		"## Commits\n\n- `01234567` Fix injection (app/12)\n\n"+
+		"## Artifacts\n\n### org:app:1.2\n\n- libs/app/app.jar\n\n"+
+		"## Builds\n\n- app/12\n", markdown)
+
+	htmlNotes, err := renderReleaseNotes(notes, NotesFormatHtml)
+	require.NoError(t, err)
+	assert.Contains(t, htmlNotes, "<li><a href=\"https://jira/APP-2\">APP-2</a> Fix &lt;script&gt; injection</li>")
+	assert.Contains(t, htmlNotes, "<h3>org:app:1.2</h3>")
+
+	jsonNotes, err := renderReleaseNotes(notes, NotesFormatJson)
+	require.NoError(t, err)
+	assert.Contains(t, jsonNotes, `"since_version": "1.1.0"`)
+
+	_, err = renderReleaseNotes(notes, "pdf")
+	assert.ErrorContains(t, err, "unsupported release notes format 'pdf'")
+}
+
+func TestReleaseNotesAnnotation(t *testing.T) {
+	notes := &ReleaseNotes{Builds: []string{"app/11", "app/12"}, Issues: []ReleaseNotesIssue{{Key: "APP-2"}, {Key: "APP-3"}}, SinceVersion: "1.1.0"}
+	assert.Equal(t, "release.notes.builds=app/11,app/12;release.notes.issues=APP-2,APP-3;release.notes.since=1.1.0", releaseNotesAnnotation(notes))
+	assert.Equal(t, "release.notes.builds=", releaseNotesAnnotation(&ReleaseNotes{}))
+}
+
+func TestCollectBuilds(t *testing.T) {
+	manager := &buildsServicesManagerMock{
+		builds: map[string]*buildinfo.BuildInfo{
+			"app/10": testBuild("app", "10", "c10", ""),
+			"app/11": testBuild("app", "11", "c11", ""),
+			"app/12": testBuild("app", "12", "c12", ""),
+			"lib/5":  testBuild("lib", "5", "l5", ""),
+			"web/3":  testBuild("web", "3", "w3", ""),
+		},
+		runs: map[string][]buildinfo.BuildRun{
+			"app": {
+				{Uri: "/12", Started: "2026-05-03T10:00:00.000+0000"},
+				{Uri: "/11", Started: "2026-05-02T10:00:00.000+0000"},
+				{Uri: "/10", Started: "2026-05-01T10:00:00.000+0000"},
+			},
+		},
+	}
+	command := NewReleaseBundleNotesCommand()
+	bundleBuilds, changeBuilds, err := command.collectBuilds(manager,
+		map[string]bool{"app/12": true, "lib/5": true, "web/3": true, "missing/1": true},
+		map[string]bool{"app/10": true, "lib/5": true})
+	require.NoError(t, err)
+
+	var bundleIds, changeIds []string
+	for _, build := range bundleBuilds {
+		bundleIds = append(bundleIds, build.Name+"/"+build.Number)
+	}
+	for _, build := range changeBuilds {
+		changeIds = append(changeIds, build.Name+"/"+build.Number)
+	}
+	assert.Equal(t, []string{"app/12", "lib/5", "web/3"}, bundleIds)
+	// lib didn't change, web is new, and the runs of app since the previous version are included.
+	assert.Equal(t, []string{"app/11", "app/12", "web/3"}, changeIds)
+}
@@ -15,6 +15,7 @@ type ReleaseBundlePromoteCommand struct {
 	includeReposPatterns []string
 	excludeReposPatterns []string
 	promotionType        string
+	dryRun               bool
 }
 
 func NewReleaseBundlePromoteCommand() *ReleaseBundlePromoteCommand {
@@ -71,6 +72,12 @@ func (rbp *ReleaseBundlePromoteCommand) SetPromotionType(promotionType string) *
 	return rbp
 }
 
+// SetDryRun makes the command print the promotion plan instead of promoting the release bundle.
+func (rbp *ReleaseBundlePromoteCommand) SetDryRun(dryRun bool) *ReleaseBundlePromoteCommand {
+	rbp.dryRun = dryRun
+	return rbp
+}
+
 func (rbp *ReleaseBundlePromoteCommand) CommandName() string {
 	return "rb_promote"
 }
@@ -83,6 +90,9 @@ func (rbp *ReleaseBundlePromoteCommand) Run() error {
 	if err := validateArtifactoryVersionSupported(rbp.serverDetails); err != nil {
 		return err
 	}
+	if rbp.dryRun {
+		return rbp.printPromotionPlan()
+	}
 
 	servicesManager, rbDetails, queryParams, err := rbp.getPromotionPrerequisites()
 
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	artUtils "github.com/jfrog/jfrog-cli-artifactory/artifactory/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	existingFilesBatchSize = 100
+	plannedStatusNew       = "new"
+	plannedStatusIdentical = "exists (identical)"
+	plannedStatusConflict  = "conflict"
+	plannedStatusNoTarget  = "skipped"
+)
+
+// environmentRepository is a local repository assigned to the environment a release bundle is promoted to.
+type environmentRepository struct {
+	key         string
+	packageType string
+}
+
+// promotedArtifact is an artifact of the release bundle version, as listed in its specification.
+type promotedArtifact struct {
+	path        string
+	sha256      string
+	packageType string
+}
+
+type promotionPlanArtifactRow struct {
+	Source string `col-name:"Source"`
+	Target string `col-name:"Target"`
+	Status string `col-name:"Status"`
+}
+
+type promotionPlanRepositoryRow struct {
+	Repository  string `col-name:"Repository"`
+	PackageType string `col-name:"Package Type"`
+	Reason      string `col-name:"Reason"`
+}
+
+// printPromotionPlan prints where each artifact of the release bundle version would be promoted to,
+// without promoting it. The repositories of the environment are filtered with the include and exclude patterns
+// the same way the promotion filters them, and the target paths are checked for existing files.
+func (rbp *ReleaseBundlePromoteCommand) printPromotionPlan() error {
+	lcServicesManager, rbDetails, _, err := rbp.getPromotionPrerequisites()
+	if err != nil {
+		return err
+	}
+	rtServicesManager, err := rtUtils.CreateServiceManager(rbp.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+
+	specResponse, err := getReleaseBundleSpecification(rbp.serverDetails, lcServicesManager, rbDetails, rbp.rbProjectKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the content of release bundle %s/%s: %w", rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion, err)
+	}
+	environmentRepositories, err := getEnvironmentRepositories(rtServicesManager, rbp.rbProjectKey, rbp.environment)
+	if err != nil {
+		return err
+	}
+	if len(environmentRepositories) == 0 {
+		return errorutils.CheckErrorf("no local repositories are assigned to the '%s' environment", rbp.environment)
+	}
+
+	targets, skipped, unmatchedPatterns, err := selectPromotionRepositories(environmentRepositories, rbp.includeReposPatterns, rbp.excludeReposPatterns)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range unmatchedPatterns {
+		log.Warn(fmt.Sprintf("The repository pattern '%s' doesn't match any repository of the '%s' environment.", pattern, rbp.environment))
+	}
+	rows := planPromotion(toPromotedArtifacts(specResponse), targets)
+	existingFiles, err := findExistingFiles(rtServicesManager, rows)
+	if err != nil {
+		return err
+	}
+	markExistingFiles(rows, existingFiles)
+
+	promotionType := rbp.promotionType
+	if promotionType == "" {
+		promotionType = "copy"
+	}
+	title := fmt.Sprintf("Promotion plan of release bundle %s/%s to %s (%s, dry run)", rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion, rbp.environment, promotionType)
+	if err = coreutils.PrintTable(promotionPlanArtifactRows(rows), title, "No artifacts would be promoted", false); err != nil {
+		return err
+	}
+	if err = coreutils.PrintTable(skipped, "Skipped repositories", "No repositories of the environment are skipped", false); err != nil {
+		return err
+	}
+	log.Info(summarizePromotionPlan(rows))
+	return nil
+}
+
+// getEnvironmentRepositories returns the local repositories of the project that are assigned to the environment.
+// The repositories list doesn't include the environments, so each repository is fetched.
+func getEnvironmentRepositories(rtServicesManager artifactory.ArtifactoryServicesManager, projectKey, environment string) ([]environmentRepository, error) {
+	repositories, err := rtServicesManager.GetAllRepositoriesFiltered(rtServices.RepositoriesFilterParams{RepoType: "local", ProjectKey: projectKey})
+	if err != nil {
+		return nil, err
+	}
+	if repositories == nil {
+		return nil, nil
+	}
+	var environmentRepositories []environmentRepository
+	for _, repository := range *repositories {
+		details := rtServices.RepositoryBaseParams{}
+		if err = rtServicesManager.GetRepository(repository.Key, &details); err != nil {
+			return nil, err
+		}
+		for _, repositoryEnvironment := range details.Environments {
+			if strings.EqualFold(repositoryEnvironment, environment) {
+				environmentRepositories = append(environmentRepositories, environmentRepository{key: repository.Key, packageType: details.PackageType})
+				break
+			}
+		}
+	}
+	return environmentRepositories, nil
+}
+
+// selectPromotionRepositories applies the include and exclude patterns to the repositories of the environment.
+// Patterns may contain wildcards. When include patterns are provided, only the repositories matching them are promoted to.
+// It returns the patterns that don't match any repository, since such a pattern is usually a mistake.
+func selectPromotionRepositories(repositories []environmentRepository, includePatterns, excludePatterns []string) (
+	targets []environmentRepository, skipped []promotionPlanRepositoryRow, unmatchedPatterns []string, err error) {
+	matchedPatterns := map[string]bool{}
+	for _, repository := range repositories {
+		includedBy, matchErr := matchRepositoryPatterns(repository.key, includePatterns)
+		if matchErr != nil {
+			err = matchErr
+			return
+		}
+		excludedBy, matchErr := matchRepositoryPatterns(repository.key, excludePatterns)
+		if matchErr != nil {
+			err = matchErr
+			return
+		}
+		// Every matching pattern is recorded, so that only the patterns matching no repository are reported.
+		for _, pattern := range append(includedBy, excludedBy...) {
+			matchedPatterns[pattern] = true
+		}
+		included := len(includePatterns) == 0 || len(includedBy) > 0
+
+		switch {
+		case !included:
+			skipped = append(skipped, promotionPlanRepositoryRow{Repository: repository.key, PackageType: repository.packageType, Reason: "not matched by the include patterns"})
+		case len(excludedBy) > 0:
+			skipped = append(skipped, promotionPlanRepositoryRow{Repository: repository.key, PackageType: repository.packageType, Reason: fmt.Sprintf("excluded by '%s'", excludedBy[0])})
+		default:
+			targets = append(targets, repository)
+		}
+	}
+	for _, pattern := range append(append([]string{}, includePatterns...), excludePatterns...) {
+		if !matchedPatterns[pattern] {
+			unmatchedPatterns = append(unmatchedPatterns, pattern)
+		}
+	}
+	return
+}
+
+// matchRepositoryPatterns returns the patterns matching the repository key.
+func matchRepositoryPatterns(repositoryKey string, patterns []string) ([]string, error) {
+	var matches []string
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, repositoryKey)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("invalid repository pattern '%s': %s", pattern, err.Error())
+		}
+		if matched {
+			matches = append(matches, pattern)
+		}
+	}
+	return matches, nil
+}
+
+func toPromotedArtifacts(specResponse services.ReleaseBundleSpecResponse) []promotedArtifact {
+	artifacts := make([]promotedArtifact, len(specResponse.Artifacts))
+	for i, artifact := range specResponse.Artifacts {
+		artifacts[i] = promotedArtifact{path: artifact.Path, sha256: artifact.Checksum, packageType: artifact.PackageType}
+	}
+	return artifacts
+}
+
+// plannedArtifact is an artifact and one of the repositories it would be promoted to.
+// The target repository is empty if no repository of the environment matches the artifact's package type.
+type plannedArtifact struct {
+	artifact         promotedArtifact
+	targetRepository string
+	status           string
+	existingSha256   string
+}
+
+// planPromotion maps each artifact to the target repositories with its package type. An artifact keeps its path in the target repository.
+func planPromotion(artifacts []promotedArtifact, targets []environmentRepository) []plannedArtifact {
+	sorted := append([]promotedArtifact{}, artifacts...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].path < sorted[j].path
+	})
+	var rows []plannedArtifact
+	for _, artifact := range sorted {
+		found := false
+		for _, target := range targets {
+			if strings.EqualFold(target.packageType, artifact.packageType) {
+				rows = append(rows, plannedArtifact{artifact: artifact, targetRepository: target.key, status: plannedStatusNew})
+				found = true
+			}
+		}
+		if !found {
+			rows = append(rows, plannedArtifact{artifact: artifact, status: plannedStatusNoTarget})
+		}
+	}
+	return rows
+}
+
+// findExistingFiles searches the target repositories for files at the target paths, and returns their sha256 by "repo/path".
+func findExistingFiles(rtServicesManager artifactory.ArtifactoryServicesManager, rows []plannedArtifact) (map[string]string, error) {
+	pathsByRepository := map[string][]string{}
+	for _, row := range rows {
+		if row.targetRepository != "" {
+			pathsByRepository[row.targetRepository] = append(pathsByRepository[row.targetRepository], row.artifact.path)
+		}
+	}
+	existingFiles := map[string]string{}
+	for _, repository := range sortedKeys(pathsByRepository) {
+		paths := pathsByRepository[repository]
+		for start := 0; start < len(paths); start += existingFilesBatchSize {
+			results, err := artUtils.ExecuteAqlQuery(rtServicesManager, buildExistingFilesQuery(repository, paths[start:min(start+existingFilesBatchSize, len(paths))]))
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range results {
+				existingFiles[path.Join(result.Repo, result.Path, result.Name)] = result.Sha256
+			}
+		}
+	}
+	return existingFiles, nil
+}
+
+func buildExistingFilesQuery(repository string, paths []string) string {
+	clauses := make([]string, len(paths))
+	for i, filePath := range paths {
+		dir, name := path.Split(filePath)
+		dir = strings.Trim(dir, "/")
+		if dir == "" {
+			dir = "."
+		}
+		clauses[i] = fmt.Sprintf(`{"$and":[{"path":%s},{"name":%s}]}`, artUtils.AqlString(dir), artUtils.AqlString(name))
+	}
+	return fmt.Sprintf(`items.find({"repo":%s,"$or":[%s]}).include("repo","path","name","sha256")`, artUtils.AqlString(repository), strings.Join(clauses, ","))
+}
+
+// markExistingFiles marks the artifacts whose target path is already taken, either by the same file or by a different one.
+func markExistingFiles(rows []plannedArtifact, existingFiles map[string]string) {
+	for i := range rows {
+		if rows[i].targetRepository == "" {
+			continue
+		}
+		existingSha256, exists := existingFiles[path.Join(rows[i].targetRepository, rows[i].artifact.path)]
+		if !exists {
+			continue
+		}
+		rows[i].existingSha256 = existingSha256
+		rows[i].status = plannedStatusIdentical
+		if existingSha256 != rows[i].artifact.sha256 {
+			rows[i].status = plannedStatusConflict
+		}
+	}
+}
+
+func promotionPlanArtifactRows(rows []plannedArtifact) []promotionPlanArtifactRow {
+	tableRows := make([]promotionPlanArtifactRow, len(rows))
+	for i, row := range rows {
+		tableRows[i] = promotionPlanArtifactRow{Source: row.artifact.path, Target: path.Join(row.targetRepository, row.artifact.path), Status: row.status}
+		switch row.status {
+		case plannedStatusNoTarget:
+			tableRows[i].Target = "-"
+			tableRows[i].Status = fmt.Sprintf("%s: no %s repository in the environment", plannedStatusNoTarget, row.artifact.packageType)
+		case plannedStatusConflict:
+			tableRows[i].Status = fmt.Sprintf("%s: existing sha256 %s", plannedStatusConflict, row.existingSha256)
+		}
+	}
+	return tableRows
+}
+
+func summarizePromotionPlan(rows []plannedArtifact) string {
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.status]++
+	}
+	return fmt.Sprintf("Promotion plan: %d new, %d identical, %d conflicts, %d artifacts without a target repository.",
+		counts[plannedStatusNew], counts[plannedStatusIdentical], counts[plannedStatusConflict], counts[plannedStatusNoTarget])
+}
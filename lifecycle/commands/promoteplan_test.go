@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rtServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repositoriesServicesManagerMock struct {
+	artifactory.EmptyArtifactoryServicesManager
+	repositories map[string]rtServices.RepositoryBaseParams
+	aqlResult    string
+	queries      []string
+}
+
+func (rsm *repositoriesServicesManagerMock) GetAllRepositoriesFiltered(rtServices.RepositoriesFilterParams) (*[]rtServices.RepositoryDetails, error) {
+	var repositories []rtServices.RepositoryDetails
+	for _, key := range sortedKeys(rsm.repositories) {
+		repositories = append(repositories, rtServices.RepositoryDetails{Key: key})
+	}
+	return &repositories, nil
+}
+
+func (rsm *repositoriesServicesManagerMock) GetRepository(repoKey string, repoDetails interface{}) error {
+	*repoDetails.(*rtServices.RepositoryBaseParams) = rsm.repositories[repoKey]
+	return nil
+}
+
+func (rsm *repositoriesServicesManagerMock) Aql(query string) (io.ReadCloser, error) {
+	rsm.queries = append(rsm.queries, query)
+	return io.NopCloser(strings.NewReader(rsm.aqlResult)), nil
+}
+
+func TestGetEnvironmentRepositories(t *testing.T) {
+	manager := &repositoriesServicesManagerMock{repositories: map[string]rtServices.RepositoryBaseParams{
+		"docker-prod": {PackageType: "docker", Environments: []string{"PROD"}},
+		"maven-prod":  {PackageType: "maven", Environments: []string{"DEV", "prod"}},
+		"maven-qa":    {PackageType: "maven", Environments: []string{"QA"}},
+	}}
+	repositories, err := getEnvironmentRepositories(manager, "", "PROD")
+	require.NoError(t, err)
+	assert.Equal(t, []environmentRepository{{key: "docker-prod", packageType: "docker"}, {key: "maven-prod", packageType: "maven"}}, repositories)
+}
+
+func TestSelectPromotionRepositories(t *testing.T) {
+	repositories := []environmentRepository{
+		{key: "maven-prod", packageType: "maven"},
+		{key: "maven-prod-legacy", packageType: "maven"},
+		{key: "npm-prod", packageType: "npm"},
+	}
+	targets, skipped, unmatched, err := selectPromotionRepositories(repositories, []string{"maven-*", "mvn-prod"}, []string{"*-legacy"})
+	require.NoError(t, err)
+	assert.Equal(t, []environmentRepository{{key: "maven-prod", packageType: "maven"}}, targets)
+	assert.Equal(t, []promotionPlanRepositoryRow{
+		{Repository: "maven-prod-legacy", PackageType: "maven", Reason: "excluded by '*-legacy'"},
+		{Repository: "npm-prod", PackageType: "npm", Reason: "not matched by the include patterns"},
+	}, skipped)
+	// A misspelled pattern silently matches nothing.
+	assert.Equal(t, []string{"mvn-prod"}, unmatched)
+
+	targets, skipped, unmatched, err = selectPromotionRepositories(repositories, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, repositories, targets)
+	assert.Empty(t, skipped)
+	assert.Empty(t, unmatched)
+
+	// A pattern is matched even when an earlier pattern also matches the same repository.
+	_, _, unmatched, err = selectPromotionRepositories(repositories, []string{"*-prod", "maven-*"}, []string{"*-legacy", "maven-prod-*"})
+	require.NoError(t, err)
+	assert.Empty(t, unmatched)
+
+	_, _, _, err = selectPromotionRepositories(repositories, []string{"maven-["}, nil)
+	assert.ErrorContains(t, err, "invalid repository pattern 'maven-['")
+}
+
+func TestPlanPromotion(t *testing.T) {
+	artifacts := []promotedArtifact{
+		{path: "org/app/1.0/app-1.0.jar", sha256: "sha-app", packageType: "maven"},
+		{path: "app.tgz", sha256: "sha-npm", packageType: "npm"},
+		{path: "org/app/1.0/app-1.0.pom", sha256: "sha-pom", packageType: "maven"},
+	}
+	targets := []environmentRepository{{key: "maven-prod", packageType: "maven"}, {key: "maven-dr", packageType: "Maven"}}
+	rows := planPromotion(artifacts, targets)
+
+	manager := &repositoriesServicesManagerMock{aqlResult: `{"results":[
+		{"repo":"maven-prod","path":"org/app/1.0","name":"app-1.0.jar","sha256":"sha-app"},
+		{"repo":"maven-dr","path":"org/app/1.0","name":"app-1.0.jar","sha256":"sha-old"}]}`}
+	existingFiles, err := findExistingFiles(manager, rows)
+	require.NoError(t, err)
+	require.Len(t, manager.queries, 2)
+	assert.Equal(t, `items.find({"repo":"maven-dr","$or":[{"$and":[{"path":"org/app/1.0"},{"name":"app-1.0.jar"}]},{"$and":[{"path":"org/app/1.0"},{"name":"app-1.0.pom"}]}]}).include("repo","path","name","sha256")`, manager.queries[0])
+	markExistingFiles(rows, existingFiles)
+
+	assert.Equal(t, []promotionPlanArtifactRow{
+		{Source: "app.tgz", Target: "-", Status: "skipped: no npm repository in the environment"},
+		{Source: "org/app/1.0/app-1.0.jar", Target: "maven-prod/org/app/1.0/app-1.0.jar", Status: plannedStatusIdentical},
+		{Source: "org/app/1.0/app-1.0.jar", Target: "maven-dr/org/app/1.0/app-1.0.jar", Status: "conflict: existing sha256 sha-old"},
+		{Source: "org/app/1.0/app-1.0.pom", Target: "maven-prod/org/app/1.0/app-1.0.pom", Status: plannedStatusNew},
+		{Source: "org/app/1.0/app-1.0.pom", Target: "maven-dr/org/app/1.0/app-1.0.pom", Status: plannedStatusNew},
+	}, promotionPlanArtifactRows(rows))
+	assert.Equal(t, "Promotion plan: 2 new, 1 identical, 1 conflicts, 1 artifacts without a target repository.", summarizePromotionPlan(rows))
+}
+
+func TestBuildExistingFilesQueryRootPath(t *testing.T) {
+	assert.Equal(t, `items.find({"repo":"generic-prod","$or":[{"$and":[{"path":"."},{"name":"notes.txt"}]}]}).include("repo","path","name","sha256")`,
+		buildExistingFilesQuery("generic-prod", []string{"notes.txt"}))
+	// Quotes and backslashes in a path can't change the query.
+	assert.Equal(t, `items.find({"repo":"generic-prod","$or":[{"$and":[{"path":"docs/\\\"x"},{"name":"a\"},{\"name\":\"b"}]}]}).include("repo","path","name","sha256")`,
+		buildExistingFilesQuery("generic-prod", []string{`docs/\"x/a"},{"name":"b`}))
+}
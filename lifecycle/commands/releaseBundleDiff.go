@@ -0,0 +1,398 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/jfrog/jfrog-cli-artifactory/stats"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	buildNameProperty   = "build.name"
+	buildNumberProperty = "build.number"
+)
+
+// ReleaseBundleDiffCommand compares the contents of two versions of the same release bundle.
+type ReleaseBundleDiffCommand struct {
+	serverDetails     *config.ServerDetails
+	releaseBundleName string
+	fromVersion       string
+	toVersion         string
+	rbProjectKey      string
+	format            string
+}
+
+// ReleaseBundleDiff is the result of comparing two release bundle versions.
+type ReleaseBundleDiff struct {
+	ReleaseBundleName string          `json:"release_bundle_name"`
+	FromVersion       string          `json:"from_version"`
+	ToVersion         string          `json:"to_version"`
+	Added             []ArtifactDiff  `json:"added"`
+	Removed           []ArtifactDiff  `json:"removed"`
+	Changed           []ArtifactDiff  `json:"changed"`
+	SourceBuilds      SourceBuildDiff `json:"source_builds"`
+	Annotations       []PropertyDiff  `json:"annotations"`
+}
+
+// ArtifactDiff describes an artifact that was added, removed or changed between the versions.
+type ArtifactDiff struct {
+	Path       string         `json:"path"`
+	FromSHA256 string         `json:"from_sha256,omitempty"`
+	ToSHA256   string         `json:"to_sha256,omitempty"`
+	Properties []PropertyDiff `json:"properties,omitempty"`
+}
+
+// SourceBuildDiff lists the source builds, as "name/number", found in only one of the versions.
+type SourceBuildDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// PropertyDiff describes a property whose values differ between the versions.
+// From is empty for added properties and To is empty for removed ones.
+type PropertyDiff struct {
+	Key  string   `json:"key"`
+	From []string `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+}
+
+// releaseBundleContent is the part of a release bundle version that is compared.
+type releaseBundleContent struct {
+	artifacts   map[string]artifactContent
+	annotations map[string][]string
+}
+
+type artifactContent struct {
+	sha256     string
+	properties map[string][]string
+}
+
+func NewReleaseBundleDiffCommand() *ReleaseBundleDiffCommand {
+	return &ReleaseBundleDiffCommand{}
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleDiffCommand {
+	rbd.serverDetails = serverDetails
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleDiffCommand {
+	rbd.releaseBundleName = releaseBundleName
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetFromVersion(fromVersion string) *ReleaseBundleDiffCommand {
+	rbd.fromVersion = fromVersion
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetToVersion(toVersion string) *ReleaseBundleDiffCommand {
+	rbd.toVersion = toVersion
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleDiffCommand {
+	rbd.rbProjectKey = rbProjectKey
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) SetOutputFormat(format string) *ReleaseBundleDiffCommand {
+	rbd.format = format
+	return rbd
+}
+
+func (rbd *ReleaseBundleDiffCommand) CommandName() string {
+	return "rb_diff"
+}
+
+func (rbd *ReleaseBundleDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbd.serverDetails, nil
+}
+
+func (rbd *ReleaseBundleDiffCommand) Run() error {
+	if err := validateArtifactoryVersionSupported(rbd.serverDetails); err != nil {
+		return err
+	}
+	lcServicesManager, err := rtUtils.CreateLifecycleServiceManager(rbd.serverDetails, false)
+	if err != nil {
+		return err
+	}
+	rtServicesManager, err := rtUtils.CreateServiceManager(rbd.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	from, err := getReleaseBundleContent(rbd.serverDetails, lcServicesManager, rtServicesManager, rbd.rbProjectKey, rbd.releaseBundleName, rbd.fromVersion)
+	if err != nil {
+		return err
+	}
+	to, err := getReleaseBundleContent(rbd.serverDetails, lcServicesManager, rtServicesManager, rbd.rbProjectKey, rbd.releaseBundleName, rbd.toVersion)
+	if err != nil {
+		return err
+	}
+
+	diff := diffReleaseBundleContents(from, to)
+	diff.ReleaseBundleName = rbd.releaseBundleName
+	diff.FromVersion = rbd.fromVersion
+	diff.ToVersion = rbd.toVersion
+	if rbd.format == "json" {
+		content, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	return printReleaseBundleDiffTables(diff)
+}
+
+// getReleaseBundleContent fetches the artifacts of a release bundle version and the annotations set on its manifest.
+func getReleaseBundleContent(serverDetails *config.ServerDetails, lcServicesManager *lifecycle.LifecycleServicesManager,
+	rtServicesManager artifactory.ArtifactoryServicesManager, projectKey, name, version string) (*releaseBundleContent, error) {
+	specResponse, err := getReleaseBundleSpecification(serverDetails, lcServicesManager, services.ReleaseBundleDetails{
+		ReleaseBundleName:    name,
+		ReleaseBundleVersion: version,
+	}, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the content of release bundle %s/%s: %w", name, version, err)
+	}
+
+	manifestProps, err := rtServicesManager.GetItemProps(buildManifestPath(projectKey, name, version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the annotations of release bundle %s/%s: %w", name, version, err)
+	}
+	var annotations map[string][]string
+	if manifestProps != nil {
+		annotations = manifestProps.Properties
+	}
+	return toReleaseBundleContent(specResponse, annotations), nil
+}
+
+// getReleaseBundleSpecification fetches the content of a release bundle version. The client doesn't send the project
+// of the release bundle, so the content is fetched directly.
+func getReleaseBundleSpecification(serverDetails *config.ServerDetails, lcServicesManager *lifecycle.LifecycleServicesManager,
+	rbDetails services.ReleaseBundleDetails, projectKey string) (specResponse services.ReleaseBundleSpecResponse, err error) {
+	lcDetails, err := serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return
+	}
+	requestFullUrl, err := clientutils.BuildUrl(lcDetails.GetUrl(), services.GetReleaseBundleSpecificationRestApi(rbDetails), distribution.GetProjectQueryParam(projectKey))
+	if err != nil {
+		return
+	}
+	httpClientDetails := lcDetails.CreateHttpClientDetails()
+	resp, body, _, err := lcServicesManager.Client().SendGet(requestFullUrl, true, &httpClientDetails)
+	if err != nil {
+		return
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return
+	}
+	err = errorutils.CheckError(json.Unmarshal(body, &specResponse))
+	return
+}
+
+func toReleaseBundleContent(specResponse services.ReleaseBundleSpecResponse, annotations map[string][]string) *releaseBundleContent {
+	content := &releaseBundleContent{
+		artifacts:   make(map[string]artifactContent, len(specResponse.Artifacts)),
+		annotations: annotations,
+	}
+	for _, artifact := range specResponse.Artifacts {
+		properties := make(map[string][]string, len(artifact.Properties))
+		for _, property := range artifact.Properties {
+			properties[property.Key] = property.Values
+		}
+		content.artifacts[artifact.Path] = artifactContent{sha256: artifact.Checksum, properties: properties}
+	}
+	return content
+}
+
+func diffReleaseBundleContents(from, to *releaseBundleContent) ReleaseBundleDiff {
+	diff := ReleaseBundleDiff{
+		Added:       []ArtifactDiff{},
+		Removed:     []ArtifactDiff{},
+		Changed:     []ArtifactDiff{},
+		Annotations: diffProperties(from.annotations, to.annotations),
+	}
+	for _, path := range sortedKeys(from.artifacts, to.artifacts) {
+		fromArtifact, inFrom := from.artifacts[path]
+		toArtifact, inTo := to.artifacts[path]
+		switch {
+		case !inFrom:
+			diff.Added = append(diff.Added, ArtifactDiff{Path: path, ToSHA256: toArtifact.sha256})
+		case !inTo:
+			diff.Removed = append(diff.Removed, ArtifactDiff{Path: path, FromSHA256: fromArtifact.sha256})
+		default:
+			properties := diffProperties(fromArtifact.properties, toArtifact.properties)
+			if fromArtifact.sha256 != toArtifact.sha256 || len(properties) > 0 {
+				diff.Changed = append(diff.Changed, ArtifactDiff{
+					Path:       path,
+					FromSHA256: fromArtifact.sha256,
+					ToSHA256:   toArtifact.sha256,
+					Properties: properties,
+				})
+			}
+		}
+	}
+	diff.SourceBuilds.Added, diff.SourceBuilds.Removed = diffSets(sourceBuilds(from), sourceBuilds(to))
+	return diff
+}
+
+// diffProperties returns the properties whose values differ, sorted by key.
+func diffProperties(from, to map[string][]string) []PropertyDiff {
+	diffs := []PropertyDiff{}
+	for _, key := range sortedKeys(from, to) {
+		fromValues, toValues := sortedCopy(from[key]), sortedCopy(to[key])
+		if !reflect.DeepEqual(fromValues, toValues) {
+			diffs = append(diffs, PropertyDiff{Key: key, From: fromValues, To: toValues})
+		}
+	}
+	return diffs
+}
+
+// sourceBuilds returns the builds the artifacts of a version were taken from, as "name/number".
+func sourceBuilds(content *releaseBundleContent) map[string]bool {
+	builds := map[string]bool{}
+	for _, artifact := range content.artifacts {
+		names, numbers := artifact.properties[buildNameProperty], artifact.properties[buildNumberProperty]
+		if len(names) == 0 || len(numbers) == 0 {
+			continue
+		}
+		builds[names[0]+"/"+numbers[0]] = true
+	}
+	return builds
+}
+
+func diffSets(from, to map[string]bool) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for _, key := range sortedKeys(from, to) {
+		switch {
+		case !from[key]:
+			added = append(added, key)
+		case !to[key]:
+			removed = append(removed, key)
+		}
+	}
+	return
+}
+
+func sortedKeys[V any](maps ...map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func printReleaseBundleDiffTables(diff ReleaseBundleDiff) error {
+	title := text.FgCyan.Sprintf("Release Bundle %s: %s -> %s", diff.ReleaseBundleName, diff.FromVersion, diff.ToVersion)
+
+	artifactRows := []stats.TableRow{{Metric: "Artifact", Value: "Change"}}
+	for _, artifact := range diff.Added {
+		artifactRows = append(artifactRows, stats.TableRow{
+			Metric: text.FgHiBlue.Sprint(artifact.Path),
+			Value:  text.FgHiGreen.Sprintf("added (sha256: %s)", artifact.ToSHA256),
+		})
+	}
+	for _, artifact := range diff.Removed {
+		artifactRows = append(artifactRows, stats.TableRow{
+			Metric: text.FgHiBlue.Sprint(artifact.Path),
+			Value:  text.FgHiRed.Sprintf("removed (sha256: %s)", artifact.FromSHA256),
+		})
+	}
+	for _, artifact := range diff.Changed {
+		artifactRows = append(artifactRows, stats.TableRow{
+			Metric: text.FgHiBlue.Sprint(artifact.Path),
+			Value:  text.FgHiYellow.Sprint(describeArtifactChange(artifact)),
+		})
+	}
+	footer := fmt.Sprintf("%d added, %d removed, %d changed.", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	if len(artifactRows) == 1 {
+		artifactRows, footer = nil, ""
+	}
+	if err := coreutils.PrintTableWithBorderless(artifactRows, title, footer, "No Artifact Changes Found", false); err != nil {
+		return errors.New("failed to print ReleaseBundleDiff artifacts table")
+	}
+
+	var buildRows []stats.TableRow
+	for _, build := range diff.SourceBuilds.Added {
+		buildRows = append(buildRows, stats.TableRow{Metric: text.FgHiBlue.Sprint(build), Value: text.FgHiGreen.Sprint("added")})
+	}
+	for _, build := range diff.SourceBuilds.Removed {
+		buildRows = append(buildRows, stats.TableRow{Metric: text.FgHiBlue.Sprint(build), Value: text.FgHiRed.Sprint("removed")})
+	}
+	if len(buildRows) > 0 {
+		buildRows = append([]stats.TableRow{{Metric: "Source Build", Value: "Change"}}, buildRows...)
+	}
+	if err := coreutils.PrintTableWithBorderless(buildRows, text.FgCyan.Sprint("Source Builds"), "", "No Source Build Changes Found", false); err != nil {
+		return errors.New("failed to print ReleaseBundleDiff source builds table")
+	}
+
+	var annotationRows []stats.TableRow
+	for _, annotation := range diff.Annotations {
+		annotationRows = append(annotationRows, stats.TableRow{
+			Metric: text.FgHiBlue.Sprint(annotation.Key),
+			Value:  text.FgHiYellow.Sprint(describePropertyChange(annotation)),
+		})
+	}
+	if len(annotationRows) > 0 {
+		annotationRows = append([]stats.TableRow{{Metric: "Annotation", Value: "Change"}}, annotationRows...)
+	}
+	if err := coreutils.PrintTableWithBorderless(annotationRows, text.FgCyan.Sprint("Annotations"), "", "No Annotation Changes Found", false); err != nil {
+		return errors.New("failed to print ReleaseBundleDiff annotations table")
+	}
+	return nil
+}
+
+func describeArtifactChange(artifact ArtifactDiff) string {
+	var changes []string
+	if artifact.FromSHA256 != artifact.ToSHA256 {
+		changes = append(changes, fmt.Sprintf("sha256: %s -> %s", artifact.FromSHA256, artifact.ToSHA256))
+	}
+	for _, property := range artifact.Properties {
+		changes = append(changes, fmt.Sprintf("%s: %s", property.Key, describePropertyChange(property)))
+	}
+	return strings.Join(changes, "; ")
+}
+
+func describePropertyChange(property PropertyDiff) string {
+	switch {
+	case len(property.From) == 0:
+		return "added " + strings.Join(property.To, ",")
+	case len(property.To) == 0:
+		return "removed " + strings.Join(property.From, ",")
+	default:
+		return strings.Join(property.From, ",") + " -> " + strings.Join(property.To, ",")
+	}
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fromSpecResponse = `{
+  "artifacts": [
+    {"path": "app-repo/app/1.4.2/app.jar", "checksum": "aaa", "properties": [{"key": "build.name", "values": ["app"]}, {"key": "build.number", "values": ["41"]}]},
+    {"path": "app-repo/app/1.4.2/app.pom", "checksum": "bbb"},
+    {"path": "libs-repo/lib/lib.jar", "checksum": "ccc", "properties": [{"key": "qa", "values": ["passed"]}]},
+    {"path": "libs-repo/legacy/legacy.jar", "checksum": "ddd"}
+  ]
+}`
+
+const toSpecResponse = `{
+  "artifacts": [
+    {"path": "app-repo/app/1.4.2/app.jar", "checksum": "eee", "properties": [{"key": "build.name", "values": ["app"]}, {"key": "build.number", "values": ["42"]}]},
+    {"path": "app-repo/app/1.4.2/app.pom", "checksum": "bbb"},
+    {"path": "libs-repo/lib/lib.jar", "checksum": "ccc", "properties": [{"key": "qa", "values": ["failed"]}]},
+    {"path": "libs-repo/new/new.jar", "checksum": "fff"}
+  ]
+}`
+
+func toTestContent(t *testing.T, specResponse string, annotations map[string][]string) *releaseBundleContent {
+	var response services.ReleaseBundleSpecResponse
+	require.NoError(t, json.Unmarshal([]byte(specResponse), &response))
+	return toReleaseBundleContent(response, annotations)
+}
+
+func TestDiffReleaseBundleContents(t *testing.T) {
+	from := toTestContent(t, fromSpecResponse, map[string][]string{"release.tag": {"rc"}, "owner": {"team-a"}})
+	to := toTestContent(t, toSpecResponse, map[string][]string{"release.tag": {"ga"}, "approved": {"true"}})
+
+	diff := diffReleaseBundleContents(from, to)
+	assert.Equal(t, []ArtifactDiff{{Path: "libs-repo/new/new.jar", ToSHA256: "fff"}}, diff.Added)
+	assert.Equal(t, []ArtifactDiff{{Path: "libs-repo/legacy/legacy.jar", FromSHA256: "ddd"}}, diff.Removed)
+	assert.Equal(t, []ArtifactDiff{
+		{
+			Path:       "app-repo/app/1.4.2/app.jar",
+			FromSHA256: "aaa",
+			ToSHA256:   "eee",
+			Properties: []PropertyDiff{{Key: "build.number", From: []string{"41"}, To: []string{"42"}}},
+		},
+		{
+			Path:       "libs-repo/lib/lib.jar",
+			FromSHA256: "ccc",
+			ToSHA256:   "ccc",
+			Properties: []PropertyDiff{{Key: "qa", From: []string{"passed"}, To: []string{"failed"}}},
+		},
+	}, diff.Changed)
+	assert.Equal(t, SourceBuildDiff{Added: []string{"app/42"}, Removed: []string{"app/41"}}, diff.SourceBuilds)
+	assert.Equal(t, []PropertyDiff{
+		{Key: "approved", To: []string{"true"}},
+		{Key: "owner", From: []string{"team-a"}},
+		{Key: "release.tag", From: []string{"rc"}, To: []string{"ga"}},
+	}, diff.Annotations)
+}
+
+func TestDiffReleaseBundleContents_Identical(t *testing.T) {
+	from := toTestContent(t, fromSpecResponse, map[string][]string{"env": {"prod", "qa"}})
+	to := toTestContent(t, fromSpecResponse, map[string][]string{"env": {"qa", "prod"}})
+
+	diff := diffReleaseBundleContents(from, to)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+	assert.Empty(t, diff.SourceBuilds.Added)
+	assert.Empty(t, diff.SourceBuilds.Removed)
+	assert.Empty(t, diff.Annotations)
+
+	// Empty lists are kept in the JSON output, so scripts can rely on every field being present.
+	content, err := json.Marshal(diff)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"release_bundle_name": "", "from_version": "", "to_version": "", "added": [], "removed": [], "changed": [],
+		"source_builds": {"added": [], "removed": []}, "annotations": []}`, string(content))
+}
+
+func TestDescribeArtifactChange(t *testing.T) {
+	assert.Equal(t, "sha256: aaa -> eee; build.number: 41 -> 42", describeArtifactChange(ArtifactDiff{
+		FromSHA256: "aaa",
+		ToSHA256:   "eee",
+		Properties: []PropertyDiff{{Key: "build.number", From: []string{"41"}, To: []string{"42"}}},
+	}))
+	assert.Equal(t, "added true", describePropertyChange(PropertyDiff{Key: "approved", To: []string{"true"}}))
+	assert.Equal(t, "removed team-a", describePropertyChange(PropertyDiff{Key: "owner", From: []string{"team-a"}}))
+}
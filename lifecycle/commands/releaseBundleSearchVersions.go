@@ -8,11 +8,14 @@ import (
 	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+const defaultSearchPageSize = 100
+
 type SearchVersionsCommand struct {
 	serverDetails     *config.ServerDetails
 	releaseBundleName string
@@ -97,15 +100,7 @@ func (svc *SearchVersionsCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	queryParameters := services.GetSearchOptionalQueryParams{
-		Offset:   svc.offset,
-		Limit:    svc.limit,
-		FilterBy: svc.filterBy,
-		OrderBy:  svc.orderBy,
-		OrderAsc: svc.orderAsc,
-		Project:  svc.project,
-	}
-	searchVersionResponse, err := lcServicesManager.ReleaseBundlesSearchVersions(svc.releaseBundleName, queryParameters)
+	searchVersionResponse, err := lcServicesManager.ReleaseBundlesSearchVersions(svc.releaseBundleName, svc.queryParams())
 	if err != nil {
 		return err
 	}
@@ -121,6 +116,38 @@ func (svc *SearchVersionsCommand) Run() error {
 	}
 }
 
+func (svc *SearchVersionsCommand) queryParams() services.GetSearchOptionalQueryParams {
+	return services.GetSearchOptionalQueryParams{
+		Offset:   svc.offset,
+		Limit:    svc.limit,
+		FilterBy: svc.filterBy,
+		OrderBy:  svc.orderBy,
+		OrderAsc: svc.orderAsc,
+		Project:  svc.project,
+	}
+}
+
+// searchAllVersions pages through all the versions matching the command's filter, starting at its offset.
+// The command's limit is used as the page size.
+func (svc *SearchVersionsCommand) searchAllVersions(lcServicesManager *lifecycle.LifecycleServicesManager) ([]services.ReleaseBundleVersion, error) {
+	queryParameters := svc.queryParams()
+	if queryParameters.Limit <= 0 {
+		queryParameters.Limit = defaultSearchPageSize
+	}
+	var versions []services.ReleaseBundleVersion
+	for {
+		response, err := lcServicesManager.ReleaseBundlesSearchVersions(svc.releaseBundleName, queryParameters)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, response.ReleaseBundles...)
+		queryParameters.Offset += len(response.ReleaseBundles)
+		if len(response.ReleaseBundles) < queryParameters.Limit || (response.Total > 0 && queryParameters.Offset >= response.Total) {
+			return versions, nil
+		}
+	}
+}
+
 func printReleaseBundleSearchVersionsTable(searchVersionResponse services.ReleaseBundleVersionsResponse) error {
 	displayLimit := 10
 	actualCount := len(searchVersionResponse.ReleaseBundles)
@@ -0,0 +1,350 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	distributionTrackersApi     = "api/v2/distribution/trackers"
+	statusPollIntervalSecs      = 10
+	defaultStatusMaxWaitMinutes = 60
+
+	rbStatusCompleted  = "completed"
+	rbStatusInProgress = "in_progress"
+	rbStatusFailed     = "failed"
+)
+
+// ReleaseBundleStatusReport is the state of the promotions and the distributions of a release bundle version.
+// Status is "in_progress" while any of them is in flight, "failed" if the latest promotion to an environment or the
+// latest distribution to a target failed, and "completed" otherwise.
+type ReleaseBundleStatusReport struct {
+	ReleaseBundleName    string                     `json:"release_bundle_name"`
+	ReleaseBundleVersion string                     `json:"release_bundle_version"`
+	Status               string                     `json:"status"`
+	Promotions           []PromotionStatus          `json:"promotions"`
+	Distributions        []DistributionTargetStatus `json:"distributions"`
+}
+
+type PromotionStatus struct {
+	Environment string `json:"environment" col-name:"Environment"`
+	Status      string `json:"status" col-name:"Status"`
+	Created     string `json:"created" col-name:"Created"`
+	CreatedBy   string `json:"created_by" col-name:"Created By"`
+	// Latest is set on the most recent promotion to the environment, which is the one that counts in the status.
+	Latest bool `json:"latest" col-name:"Latest"`
+}
+
+type DistributionTargetStatus struct {
+	Target           string      `json:"target" col-name:"Target"`
+	Type             string      `json:"type" col-name:"Type"`
+	DistributionId   string      `json:"distribution_id" col-name:"Distribution ID"`
+	Status           string      `json:"status" col-name:"Status"`
+	DistributedFiles json.Number `json:"distributed_files" col-name:"Distributed Files"`
+	TotalFiles       json.Number `json:"total_files" col-name:"Total Files"`
+	Error            string      `json:"error,omitempty" col-name:"Error"`
+}
+
+// promotionHistory groups the promotions by environment, newest first, and marks the latest promotion to each one.
+func promotionHistory(promotions []services.RbPromotion) []PromotionStatus {
+	sorted := append([]services.RbPromotion{}, promotions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Environment != sorted[j].Environment {
+			return sorted[i].Environment < sorted[j].Environment
+		}
+		return numberValue(sorted[i].CreatedMillis) > numberValue(sorted[j].CreatedMillis)
+	})
+	history := []PromotionStatus{}
+	for i, promotion := range sorted {
+		history = append(history, PromotionStatus{
+			Environment: promotion.Environment,
+			Status:      string(promotion.Status),
+			Created:     promotion.Created,
+			CreatedBy:   promotion.CreatedBy,
+			Latest:      i == 0 || sorted[i-1].Environment != promotion.Environment,
+		})
+	}
+	return history
+}
+
+// latestDistributionPerTarget returns the status of the most recent distribution to each target.
+func latestDistributionPerTarget(trackers []distribution.DistributionStatusResponse) []DistributionTargetStatus {
+	latest := map[string]DistributionTargetStatus{}
+	latestId := map[string]int64{}
+	for _, tracker := range trackers {
+		if tracker.Type != "" && tracker.Type != distribution.Distribute {
+			continue
+		}
+		id := numberValue(tracker.Id)
+		targets := make([]DistributionTargetStatus, 0, len(tracker.Sites))
+		for _, site := range tracker.Sites {
+			siteErrors := site.FileErrors
+			if site.Error != "" {
+				siteErrors = append([]string{site.Error}, siteErrors...)
+			}
+			targets = append(targets, DistributionTargetStatus{
+				Target:           site.TargetArtifactory.Name,
+				Type:             site.TargetArtifactory.Type,
+				DistributionId:   tracker.Id.String(),
+				Status:           string(site.Status),
+				DistributedFiles: site.DistributedFiles,
+				TotalFiles:       site.TotalFiles,
+				Error:            strings.Join(siteErrors, "; "),
+			})
+		}
+		// A distribution that is still queued doesn't report its targets yet.
+		if len(targets) == 0 {
+			targets = append(targets, DistributionTargetStatus{DistributionId: tracker.Id.String(), Status: string(tracker.Status)})
+		}
+		for _, target := range targets {
+			if previousId, exists := latestId[target.Target]; !exists || id > previousId {
+				latest[target.Target] = target
+				latestId[target.Target] = id
+			}
+		}
+	}
+	distributions := []DistributionTargetStatus{}
+	for _, target := range latest {
+		distributions = append(distributions, target)
+	}
+	sort.Slice(distributions, func(i, j int) bool {
+		return distributions[i].Target < distributions[j].Target
+	})
+	return distributions
+}
+
+func numberValue(number json.Number) int64 {
+	value, err := number.Int64()
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+func summarizeStatus(promotions []PromotionStatus, distributions []DistributionTargetStatus) string {
+	failed := false
+	for _, promotion := range promotions {
+		if !promotion.Latest {
+			continue
+		}
+		switch services.RbStatus(promotion.Status) {
+		case services.Pending, services.Processing, services.InProgress, services.Started:
+			return rbStatusInProgress
+		case services.Failed, services.Rejected:
+			failed = true
+		}
+	}
+	for _, target := range distributions {
+		switch distribution.DistributionStatus(target.Status) {
+		case distribution.NotDistributed, distribution.InProgress, distribution.InQueue:
+			return rbStatusInProgress
+		case distribution.Failed:
+			failed = true
+		}
+	}
+	if failed {
+		return rbStatusFailed
+	}
+	return rbStatusCompleted
+}
+
+// failureError describes every failed promotion and distribution, or returns nil if the release bundle didn't fail.
+func (rsr *ReleaseBundleStatusReport) failureError() error {
+	if rsr.Status != rbStatusFailed {
+		return nil
+	}
+	var failures []string
+	for _, promotion := range rsr.Promotions {
+		if promotion.Latest && (promotion.Status == string(services.Failed) || promotion.Status == string(services.Rejected)) {
+			failures = append(failures, fmt.Sprintf("promotion to %s: %s", promotion.Environment, promotion.Status))
+		}
+	}
+	for _, target := range rsr.Distributions {
+		if target.Status == string(distribution.Failed) {
+			failures = append(failures, fmt.Sprintf("distribution to %s: %s", target.Target, target.Error))
+		}
+	}
+	return errorutils.CheckErrorf("release bundle %s/%s failed:\n%s", rsr.ReleaseBundleName, rsr.ReleaseBundleVersion, strings.Join(failures, "\n"))
+}
+
+// ReleaseBundleStatusCommand reports the promotions and the distributions of a release bundle version, including
+// the ones started by other pipelines. In wait mode it polls until none of them is in progress and fails if any failed.
+type ReleaseBundleStatusCommand struct {
+	releaseBundleCmd
+	wait           bool
+	maxWaitMinutes int
+	format         string
+}
+
+func NewReleaseBundleStatusCommand() *ReleaseBundleStatusCommand {
+	return &ReleaseBundleStatusCommand{}
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *ReleaseBundleStatusCommand {
+	rbs.serverDetails = serverDetails
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetReleaseBundleName(releaseBundleName string) *ReleaseBundleStatusCommand {
+	rbs.releaseBundleName = releaseBundleName
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetReleaseBundleVersion(releaseBundleVersion string) *ReleaseBundleStatusCommand {
+	rbs.releaseBundleVersion = releaseBundleVersion
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetReleaseBundleProject(rbProjectKey string) *ReleaseBundleStatusCommand {
+	rbs.rbProjectKey = rbProjectKey
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetWait(wait bool) *ReleaseBundleStatusCommand {
+	rbs.wait = wait
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetMaxWaitMinutes(maxWaitMinutes int) *ReleaseBundleStatusCommand {
+	rbs.maxWaitMinutes = maxWaitMinutes
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) SetOutputFormat(format string) *ReleaseBundleStatusCommand {
+	rbs.format = format
+	return rbs
+}
+
+func (rbs *ReleaseBundleStatusCommand) CommandName() string {
+	return "rb_status"
+}
+
+func (rbs *ReleaseBundleStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rbs.serverDetails, nil
+}
+
+func (rbs *ReleaseBundleStatusCommand) Run() error {
+	if err := validateArtifactoryVersionSupported(rbs.serverDetails); err != nil {
+		return err
+	}
+	servicesManager, rbDetails, _, err := rbs.getPrerequisites()
+	if err != nil {
+		return err
+	}
+
+	var report *ReleaseBundleStatusReport
+	if !rbs.wait {
+		if report, err = rbs.getStatus(servicesManager, rbDetails); err != nil {
+			return err
+		}
+		return rbs.printStatus(report)
+	}
+
+	waitErr := rbs.waitForCompletion(servicesManager, rbDetails, &report)
+	if report == nil {
+		return waitErr
+	}
+	if err = rbs.printStatus(report); err != nil {
+		return err
+	}
+	return errors.Join(waitErr, report.failureError())
+}
+
+// waitForCompletion polls the status until nothing is in progress, and leaves the last status it got in report.
+func (rbs *ReleaseBundleStatusCommand) waitForCompletion(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails, report **ReleaseBundleStatusReport) error {
+	maxWaitMinutes := rbs.maxWaitMinutes
+	if maxWaitMinutes <= 0 {
+		maxWaitMinutes = defaultStatusMaxWaitMinutes
+	}
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:               maxWaitMinutes * 60 / statusPollIntervalSecs,
+		RetriesIntervalMilliSecs: statusPollIntervalSecs * 1000,
+		ErrorMessage:             fmt.Sprintf("Release bundle %s/%s is still in progress", rbs.releaseBundleName, rbs.releaseBundleVersion),
+		ExecutionHandler: func() (bool, error) {
+			current, err := rbs.getStatus(servicesManager, rbDetails)
+			if err != nil {
+				return false, err
+			}
+			*report = current
+			return current.Status == rbStatusInProgress, nil
+		},
+	}
+	return retryExecutor.Execute()
+}
+
+func (rbs *ReleaseBundleStatusCommand) getStatus(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails) (*ReleaseBundleStatusReport, error) {
+	promotions, err := servicesManager.GetReleaseBundleVersionPromotions(rbDetails, services.GetPromotionsOptionalQueryParams{ProjectKey: rbs.rbProjectKey})
+	if err != nil {
+		return nil, err
+	}
+	trackers, err := getDistributionTrackers(rbs.serverDetails, servicesManager, rbs.releaseBundleName, rbs.releaseBundleVersion, rbs.rbProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	report := &ReleaseBundleStatusReport{
+		ReleaseBundleName:    rbs.releaseBundleName,
+		ReleaseBundleVersion: rbs.releaseBundleVersion,
+		Promotions:           promotionHistory(promotions.Promotions),
+		Distributions:        latestDistributionPerTarget(trackers),
+	}
+	report.Status = summarizeStatus(report.Promotions, report.Distributions)
+	return report, nil
+}
+
+// getDistributionTrackers lists all the distributions of the version. The client only tracks a distribution it started
+// itself, so the trackers are fetched directly.
+func getDistributionTrackers(serverDetails *config.ServerDetails, servicesManager *lifecycle.LifecycleServicesManager,
+	releaseBundleName, releaseBundleVersion, projectKey string) ([]distribution.DistributionStatusResponse, error) {
+	lcDetails, err := serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	restApi := path.Join(distributionTrackersApi, releaseBundleName, releaseBundleVersion)
+	requestFullUrl, err := clientutils.BuildUrl(lcDetails.GetUrl(), restApi, distribution.GetProjectQueryParam(projectKey))
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := lcDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(requestFullUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var trackers []distribution.DistributionStatusResponse
+	if err = json.Unmarshal(body, &trackers); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the distributions of %s/%s: %s", releaseBundleName, releaseBundleVersion, err.Error())
+	}
+	return trackers, nil
+}
+
+func (rbs *ReleaseBundleStatusCommand) printStatus(report *ReleaseBundleStatusReport) error {
+	if rbs.format == "json" {
+		content, err := json.Marshal(report)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	log.Output(fmt.Sprintf("Release bundle %s/%s: %s", report.ReleaseBundleName, report.ReleaseBundleVersion, report.Status))
+	if err := coreutils.PrintTable(report.Promotions, "Promotions", "No promotions", false); err != nil {
+		return err
+	}
+	return coreutils.PrintTable(report.Distributions, "Distributions", "No distributions", false)
+}
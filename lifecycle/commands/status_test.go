@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionHistory(t *testing.T) {
+	history := promotionHistory([]services.RbPromotion{
+		{Environment: "QA", Status: services.Completed, CreatedMillis: "100"},
+		{Environment: "PROD", Status: services.Failed, CreatedMillis: "200"},
+		{Environment: "PROD", Status: services.Processing, CreatedMillis: "300"},
+		{Environment: "QA", Status: services.Failed, CreatedMillis: "50"},
+	})
+	assert.Equal(t, []PromotionStatus{
+		{Environment: "PROD", Status: "PROCESSING", Latest: true},
+		{Environment: "PROD", Status: "FAILED"},
+		{Environment: "QA", Status: "COMPLETED", Latest: true},
+		{Environment: "QA", Status: "FAILED"},
+	}, history)
+	assert.Empty(t, promotionHistory(nil))
+}
+
+func TestLatestDistributionPerTarget(t *testing.T) {
+	site := func(name string, status distribution.DistributionStatus, generalError string, fileErrors ...string) distribution.DistributionSiteStatus {
+		return distribution.DistributionSiteStatus{
+			Status:            status,
+			Error:             generalError,
+			FileErrors:        fileErrors,
+			TargetArtifactory: distribution.TargetArtifactory{Name: name, Type: "edge"},
+			TotalFiles:        "10",
+			DistributedFiles:  "4",
+		}
+	}
+	distributions := latestDistributionPerTarget([]distribution.DistributionStatusResponse{
+		{Id: "2", Type: distribution.Distribute, Sites: []distribution.DistributionSiteStatus{site("edge-1", distribution.InProgress, "")}},
+		{Id: "1", Type: distribution.Distribute, Sites: []distribution.DistributionSiteStatus{
+			site("edge-1", distribution.Failed, "timeout"),
+			site("edge-2", distribution.Failed, "disk full", "a.jar", "b.jar"),
+		}},
+		{Id: "3", Type: distribution.DeleteReleaseBundleVersion, Sites: []distribution.DistributionSiteStatus{site("edge-2", distribution.Completed, "")}},
+		{Id: "4", Status: distribution.InQueue},
+	})
+	require.Len(t, distributions, 3)
+	assert.Equal(t, DistributionTargetStatus{DistributionId: "4", Status: "In queue"}, distributions[0])
+	assert.Equal(t, DistributionTargetStatus{Target: "edge-1", Type: "edge", DistributionId: "2", Status: "In progress", DistributedFiles: "4", TotalFiles: "10"}, distributions[1])
+	assert.Equal(t, "1", distributions[2].DistributionId)
+	assert.Equal(t, "disk full; a.jar; b.jar", distributions[2].Error)
+}
+
+func TestSummarizeStatus(t *testing.T) {
+	completedPromotion := PromotionStatus{Environment: "QA", Status: "COMPLETED", Latest: true}
+	failedPromotion := PromotionStatus{Environment: "PROD", Status: "FAILED", Latest: true}
+	completedTarget := DistributionTargetStatus{Target: "edge-1", Status: "Completed"}
+	failedTarget := DistributionTargetStatus{Target: "edge-2", Status: "Failed", Error: "disk full"}
+
+	assert.Equal(t, rbStatusCompleted, summarizeStatus(nil, nil))
+	assert.Equal(t, rbStatusCompleted, summarizeStatus([]PromotionStatus{completedPromotion, {Environment: "QA", Status: "FAILED"}}, []DistributionTargetStatus{completedTarget}))
+	assert.Equal(t, rbStatusFailed, summarizeStatus([]PromotionStatus{completedPromotion, failedPromotion}, nil))
+	assert.Equal(t, rbStatusFailed, summarizeStatus(nil, []DistributionTargetStatus{completedTarget, failedTarget}))
+	// Nothing is final while anything is still in flight.
+	assert.Equal(t, rbStatusInProgress, summarizeStatus([]PromotionStatus{failedPromotion, {Environment: "QA", Status: "PENDING", Latest: true}}, nil))
+	assert.Equal(t, rbStatusInProgress, summarizeStatus([]PromotionStatus{failedPromotion}, []DistributionTargetStatus{{Status: "Not distributed"}}))
+}
+
+func TestReleaseBundleStatusReportFailureError(t *testing.T) {
+	report := &ReleaseBundleStatusReport{ReleaseBundleName: "my-app", ReleaseBundleVersion: "1.0.0", Status: rbStatusCompleted}
+	assert.NoError(t, report.failureError())
+
+	report.Status = rbStatusFailed
+	report.Promotions = []PromotionStatus{{Environment: "PROD", Status: "REJECTED", Latest: true}, {Environment: "QA", Status: "FAILED"}}
+	report.Distributions = []DistributionTargetStatus{{Target: "edge-2", Status: "Failed", Error: "disk full"}}
+	assert.EqualError(t, report.failureError(), "release bundle my-app/1.0.0 failed:\npromotion to PROD: REJECTED\ndistribution to edge-2: disk full")
+}
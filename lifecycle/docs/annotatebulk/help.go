@@ -0,0 +1,15 @@
+package annotatebulk
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbab [command options] <release bundle name>"}
+
+func GetDescription() string {
+	return "Annotate many versions of a release bundle at once. The versions are selected with the search filter and order, or listed in a versions file. The matched versions are listed before the tag and properties are applied, and a result is reported for each version."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle to annotate."},
+	}
+}
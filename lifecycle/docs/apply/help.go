@@ -0,0 +1,7 @@
+package apply
+
+var Usage = []string{"rbapply [command options]"}
+
+func GetDescription() string {
+	return "Run a release pipeline declared in a YAML release definition: create the release bundle version, promote it to its environments, distribute it and annotate it. Stages that already completed are skipped, so a failed run can be repeated."
+}
@@ -0,0 +1,15 @@
+package cleanup
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbclean [command options] <release bundle name>"}
+
+func GetDescription() string {
+	return "Delete the versions of a release bundle that are not kept by the retention rules, locally and from the distribution edges. A version is kept if it is one of the newest versions, was created recently, was promoted to a protected environment or carries a protected tag."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle to clean up."},
+	}
+}
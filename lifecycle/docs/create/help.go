@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 var Usage = []string{"rbc [command options] <release bundle name> <release bundle version>"}
 
 func GetDescription() string {
-	return "Create a release bundle from builds or from existing release bundles"
+	return "Create a release bundle from builds, from existing release bundles, or from the artifacts listed in a local build-info or SBOM file"
 }
 
 func GetArguments() []components.Argument {
@@ -0,0 +1,17 @@
+package diff
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbdiff [command options] <release bundle name> <from version> <to version>"}
+
+func GetDescription() string {
+	return "Compare the contents of two versions of a release bundle: added, removed and changed artifacts (by path and SHA-256), source builds and annotations."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle to compare."},
+		{Name: "from version", Description: "Version of the Release Bundle to compare from."},
+		{Name: "to version", Description: "Version of the Release Bundle to compare to."},
+	}
+}
@@ -0,0 +1,15 @@
+package inspect
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbinspect [command options] <path to archive>"}
+
+func GetDescription() string {
+	return "Inspect an exported release bundle archive without a server: list its artifacts, verify them against the checksums in the signed manifest and verify the signatures of its evidence files. The command fails if the archive doesn't pass verification."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "path to archive", Description: "Path to the release bundle archive on the filesystem."},
+	}
+}
@@ -0,0 +1,16 @@
+package mirror
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbmirror [command options] <release bundle name> <release bundle version>"}
+
+func GetDescription() string {
+	return "Copy a release bundle version between two JFrog instances: export it on the source, download and verify the archive, import it on the target and wait for the version to be available there. If the import fails, run the command again to retry it with the downloaded archive."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle to mirror."},
+		{Name: "release bundle version", Description: "Version of the Release Bundle to mirror."},
+	}
+}
@@ -0,0 +1,16 @@
+package notes
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbnotes [command options] <release bundle name> <release bundle version>"}
+
+func GetDescription() string {
+	return "Generate release notes for a release bundle version from the build-info of its source builds: the commits and issues of the builds since the previous version, and the artifacts grouped by build module. The notes can be attached to the release bundle as an annotation or as evidence."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle."},
+		{Name: "release bundle version", Description: "Version of the Release Bundle to generate the notes for."},
+	}
+}
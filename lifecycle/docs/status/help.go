@@ -0,0 +1,16 @@
+package status
+
+import "github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+
+var Usage = []string{"rbstatus [command options] <release bundle name> <release bundle version>"}
+
+func GetDescription() string {
+	return "Show the promotion history of a release bundle version per environment and its distribution status per target, including operations started by other pipelines. With --wait, wait for them to complete and exit with an error if any of them failed."
+}
+
+func GetArguments() []components.Argument {
+	return []components.Argument{
+		{Name: "release bundle name", Description: "Name of the Release Bundle."},
+		{Name: "release bundle version", Description: "Version of the Release Bundle."},
+	}
+}
@@ -4,8 +4,16 @@ import (
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/delete"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/install"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/lint"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/list"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/pack"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/promote"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/scanstatus"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/search"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/sync"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/trust"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/update"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
 )
 
@@ -14,14 +22,14 @@ func GetCommands() []components.Command {
 		{
 			Name:        "publish",
 			Flags:       flagkit.GetCommandFlags(flagkit.SkillsPublish),
-			Description: "Publish a skill to Artifactory. Signs and attaches evidence if a signing key is provided. Runs Xray security scan after upload (use --skip-scan or JFROG_CLI_SKIP_SKILLS_SCAN=true to bypass). Scan timeout is configurable via JFROG_CLI_SKILLS_SCAN_TIMEOUT (default: 5m, e.g. 2m, 30s).",
+			Description: "Publish a skill to Artifactory. Dependencies declared in SKILL.md must already exist in the repository. Signs and attaches evidence if a signing key is provided; use --detached-signature to also upload a signature that installs can verify against a local trust store on any Artifactory tier. Runs Xray security scan after upload (use --skip-scan or JFROG_CLI_SKIP_SKILLS_SCAN=true to bypass). Scan timeout is configurable via JFROG_CLI_SKILLS_SCAN_TIMEOUT (default: 5m, e.g. 2m, 30s). Use --scan-report to save the scan result as JSON or SARIF and --fail-on to fail on unscanned, unknown, timed-out or errored scans.",
 			Arguments:   getPublishArguments(),
 			Action:      publish.RunPublish,
 		},
 		{
 			Name:        "install",
 			Flags:       flagkit.GetCommandFlags(flagkit.SkillsInstall),
-			Description: "Install a skill from Artifactory. Verifies evidence using Artifactory keys automatically.",
+			Description: "Install a skill and the dependencies declared in its SKILL.md from Artifactory. Verifies evidence using Artifactory keys automatically, writes an install manifest (.jfrog-skill.json) into each skill folder and records the installed versions in skills.lock. Refuses to overwrite locally modified skill files unless --force is set. Use --signature-policy to verify detached signatures against the keys added with 'jf skills trust'. Use --from-file to install an offline bundle created by 'jf skills pack' without contacting Artifactory. Use --target to install into the skills directories of one or more coding agents or editors (e.g. --target claude,cursor). Cursor, Windsurf and Kiro only load rule files, so a rule file pointing at the installed skill is written for them too. Sync, update and list follow the targets recorded in skills.lock.",
 			Arguments:   getInstallArguments(),
 			Action:      install.RunInstall,
 		},
@@ -39,6 +47,60 @@ func GetCommands() []components.Command {
 			Arguments:   getDeleteArguments(),
 			Action:      delete.RunDelete,
 		},
+		{
+			Name:        "sync",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsSync),
+			Description: "Install every skill pinned in skills.lock, verifying each zip against its recorded SHA256. Use --update to upgrade to the newest allowed versions and rewrite the lockfile.",
+			Action:      sync.RunSync,
+		},
+		{
+			Name:        "lint",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsLint),
+			Description: "Validate a skill folder locally: checks that the SKILL.md frontmatter is valid YAML matching the skill schema. Runs automatically before publish. Use --schema to print the schema.",
+			Arguments:   getLintArguments(),
+			Action:      lint.RunLint,
+		},
+		{
+			Name:        "pack",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsPack),
+			Description: "Build an offline skill bundle: the same deterministic zip that publish uploads, plus a <zip>.metadata.json file pinning its SHA256. Signs the bundle if a signing key is provided.",
+			Arguments:   getPublishArguments(),
+			Action:      pack.RunPack,
+		},
+		{
+			Name:        "list",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsList),
+			Description: "List the skills installed in the install path with their version, source repository, evidence status, the trusted key that signed them and whether their files were modified locally.",
+			Action:      list.RunList,
+		},
+		{
+			Name:        "update",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsUpdate),
+			Description: "Update installed skills in place to the newest version allowed by skills.lock, showing the changed files first. Refuses to overwrite local modifications unless --force is set. Use --dry-run to only show the changes.",
+			Arguments:   getUpdateArguments(),
+			Action:      update.RunUpdate,
+		},
+		{
+			Name:        "trust",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsTrust),
+			Description: "Manage the local trust store of public keys used to verify detached skill signatures and offline bundles: 'add <public-key>', 'list' or 'remove <name>'. The trust store is in the JFrog home directory unless JFROG_SKILLS_TRUST_STORE is set.",
+			Arguments:   getTrustArguments(),
+			Action:      trust.RunTrust,
+		},
+		{
+			Name:        "scan-status",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsScanStatus),
+			Description: "Run the Xray scan gate against an already-published skill version and show its result. Never deletes the version. Use --scan-report to save the result as JSON or SARIF and --fail-on to fail on unscanned, unknown, timed-out or errored scans.",
+			Arguments:   getPublishedVersionArguments(),
+			Action:      scanstatus.RunScanStatus,
+		},
+		{
+			Name:        "promote",
+			Flags:       flagkit.GetCommandFlags(flagkit.SkillsPromote),
+			Description: "Promote a published skill version between skills repositories (e.g. dev to staging to prod): copies the zip and its detached signature to the --to repository, runs the Xray scan gate there, copies the evidence of the zip, such as its publish attestation, and attaches a promotion attestation if a signing key is provided. Use --delete-source to remove the version from the --from repository and --dry-run to only check the promotion.",
+			Arguments:   getPublishedVersionArguments(),
+			Action:      promote.RunPromote,
+		},
 	}
 }
 
@@ -51,6 +113,16 @@ func getPublishArguments() []components.Argument {
 	}
 }
 
+func getLintArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "path",
+			Description: "Path to the skill folder containing SKILL.md. Defaults to the current directory.",
+			Optional:    true,
+		},
+	}
+}
+
 func getSearchArguments() []components.Argument {
 	return []components.Argument{
 		{
@@ -64,7 +136,44 @@ func getInstallArguments() []components.Argument {
 	return []components.Argument{
 		{
 			Name:        "slug",
-			Description: "Skill name/slug to install.",
+			Description: "Skill name/slug to install. Optional with --from-file.",
+		},
+	}
+}
+
+func getUpdateArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "slug",
+			Description: "Installed skill to update. Defaults to every directly installed skill.",
+			Optional:    true,
+		},
+	}
+}
+
+func getTrustArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "action",
+			Description: "One of add, list or remove.",
+		},
+		{
+			Name:        "key",
+			Description: "Public key file to add, or name of the key to remove.",
+			Optional:    true,
+		},
+	}
+}
+
+func getPublishedVersionArguments() []components.Argument {
+	return []components.Argument{
+		{
+			Name:        "slug",
+			Description: "Skill name/slug.",
+		},
+		{
+			Name:        "version",
+			Description: "Published skill version.",
 		},
 	}
 }
@@ -0,0 +1,158 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// installFromFile installs an offline bundle created by `skills pack`. The zip must match the
+// SHA-256 pinned in its sidecar metadata. A signed attestation of the skill, version and digest is
+// verified with the public key, if given, or else against the trust store unless the signature policy is off.
+// Nothing is requested from Artifactory, so declared dependencies must be installed from their own bundles.
+func (ic *InstallCommand) installFromFile() ([]common.LockEntry, error) {
+	zipPath, err := filepath.Abs(ic.fromFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle path: %w", err)
+	}
+	metadata, err := common.ReadBundleMetadata(zipPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w. Bundles are created with 'jf skills pack', which writes %s next to the zip", err, filepath.Base(common.BundleMetadataPath(zipPath)))
+		}
+		return nil, err
+	}
+	// The sidecar is not signed, so its skill and version must be safe to use in paths before anything else.
+	if err := publish.ValidateSlug(metadata.Slug); err != nil {
+		return nil, fmt.Errorf("bundle metadata of %s: %w", ic.fromFile, err)
+	}
+	if err := publish.ValidateVersion(metadata.Version); err != nil {
+		return nil, fmt.Errorf("bundle metadata of %s: %w", ic.fromFile, err)
+	}
+	if ic.slug != "" && ic.slug != metadata.Slug {
+		return nil, fmt.Errorf("bundle %s contains skill '%s', not '%s'", ic.fromFile, metadata.Slug, ic.slug)
+	}
+	if ic.version != "" && ic.version != metadata.Version {
+		return nil, fmt.Errorf("bundle %s contains version '%s', not '%s'", ic.fromFile, metadata.Version, ic.version)
+	}
+	ic.slug = metadata.Slug
+	ic.version = metadata.Version
+
+	log.Info(fmt.Sprintf("Installing skill '%s' version '%s' from %s", ic.slug, ic.version, ic.fromFile))
+	sha256Hex, err := ic.verifyChecksum(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(sha256Hex, metadata.SHA256) {
+		return nil, fmt.Errorf("integrity check failed for bundle %s: its metadata pins SHA256 %s but the zip has %s", ic.fromFile, metadata.SHA256, sha256Hex)
+	}
+
+	signedBy := ""
+	evidenceStatus := common.EvidenceStatusUnverified
+	// An explicit --public-key asks for the attestation to be verified, whatever the policy.
+	if ic.signaturePolicy != common.SignaturePolicyOff || ic.publicKeyPath != "" {
+		var verifyErr error
+		signedBy, verifyErr = ic.verifyBundleAttestation(metadata)
+		if verifyErr != nil && ic.signaturePolicy == common.SignaturePolicyRequire {
+			return nil, fmt.Errorf("signature verification failed for bundle %s: %w", ic.fromFile, verifyErr)
+		}
+		if evidenceStatus, err = ic.checkEvidence(verifyErr); err != nil {
+			return nil, err
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "skill-install-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	unzipDir := filepath.Join(tmpDir, fmt.Sprintf("%s-%s", ic.slug, ic.version))
+	if err := unzipFile(zipPath, unzipDir); err != nil {
+		return nil, fmt.Errorf("unzip failed: %w", err)
+	}
+
+	if !ic.skipDependencies {
+		deps, err := readDependencies(unzipDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dependencies of skill '%s' version '%s': %w", ic.slug, ic.version, err)
+		}
+		ic.warnMissingDependencies(deps)
+	}
+
+	entries := []common.LockEntry{{
+		Slug:     ic.slug,
+		Version:  ic.version,
+		SHA256:   sha256Hex,
+		Evidence: evidenceStatus,
+		SignedBy: signedBy,
+		Targets:  common.TargetNames(ic.targets),
+	}}
+	if err := ic.place(entries, []string{unzipDir}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// verifyBundleAttestation checks the bundle's signed attestation offline and returns the name
+// of the key that verified it: the --public-key file name or the matching trust store key.
+func (ic *InstallCommand) verifyBundleAttestation(metadata *common.BundleMetadata) (string, error) {
+	if metadata.Attestation == nil {
+		return "", fmt.Errorf("the bundle is not signed")
+	}
+	if ic.publicKeyPath != "" {
+		if err := metadata.VerifyAttestation(ic.publicKeyPath); err != nil {
+			return "", err
+		}
+		log.Info("Bundle attestation verified.")
+		return filepath.Base(ic.publicKeyPath), nil
+	}
+
+	trustStore, err := ic.loadTrustStore()
+	if err != nil {
+		return "", err
+	}
+	if len(trustStore.Keys) == 0 {
+		return "", fmt.Errorf("the bundle is signed, but no --public-key was provided and the trust store %s has no keys", trustStore.Dir)
+	}
+	signedBy, err := trustStore.VerifyAttestation(metadata.Attestation, metadata.Slug, metadata.Version, metadata.SHA256)
+	if err != nil {
+		return "", err
+	}
+	log.Info(fmt.Sprintf("Bundle attestation verified with trusted key '%s'.", signedBy))
+	return signedBy, nil
+}
+
+// warnMissingDependencies reports declared dependencies that are not installed next to the bundle's skill,
+// in the install path or in any of the install targets.
+func (ic *InstallCommand) warnMissingDependencies(deps []publish.Dependency) {
+	var missing []string
+	for _, dep := range deps {
+		destinations, err := ic.skillDestinations(dep.Slug)
+		if err != nil {
+			log.Debug("Could not check dependencies:", err.Error())
+			return
+		}
+		for _, dest := range destinations {
+			m, err := common.ReadManifest(dest.dir)
+			if err == nil && common.SatisfiesVersionSpec(m.Version, dep.Version) {
+				continue
+			}
+			name := dep.Slug + "@" + dep.Version
+			if dest.target != "" {
+				name += " (" + dest.target + ")"
+			}
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		log.Warn(fmt.Sprintf("Skill '%s' depends on skills that are not installed: %s. Install their bundles with --from-file.", ic.slug, strings.Join(missing, ", ")))
+	}
+}
@@ -0,0 +1,171 @@
+package install
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createBundle writes a bundle zip with its sidecar metadata, signed when keyPath is set.
+func createBundle(t *testing.T, dir, keyPath string) string {
+	zipPath := filepath.Join(dir, "my-skill-1.0.0.zip")
+	createTestZip(t, zipPath, map[string]string{
+		"SKILL.md":  "---\nname: my-skill\ndescription: test\nversion: 1.0.0\n---\n# My Skill\n",
+		"README.md": "readme\n",
+	})
+	sha, err := common.ComputeSHA256(zipPath)
+	require.NoError(t, err)
+
+	metadata := &common.BundleMetadata{Slug: "my-skill", Version: "1.0.0", FileName: filepath.Base(zipPath), SHA256: sha}
+	if keyPath != "" {
+		statement, err := common.NewInTotoStatement(metadata.FileName, sha, "https://jfrog.com/evidence/skill/v1", []byte(`{"skill":"my-skill","version":"1.0.0"}`))
+		require.NoError(t, err)
+		metadata.Attestation, err = dsse.SignEnvelope(dsse.InTotoPayloadType, statement, keyPath, "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, metadata.Save(zipPath))
+	return zipPath
+}
+
+func writeSigningKey(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "signing.key")
+	pubPath := filepath.Join(dir, "signing.pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+	return privPath, pubPath
+}
+
+func TestInstallFromFile_Signed(t *testing.T) {
+	bundleDir := t.TempDir()
+	privPath, pubPath := writeSigningKey(t, bundleDir)
+	zipPath := createBundle(t, bundleDir, privPath)
+	installPath := t.TempDir()
+
+	entries, err := NewInstallCommand().SetFromFile(zipPath).SetPublicKeyPath(pubPath).SetInstallPath(installPath).SetQuiet(true).Install()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, common.EvidenceStatusVerified, entries[0].Evidence)
+
+	manifest, err := common.ReadManifest(filepath.Join(installPath, "my-skill"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", manifest.Version)
+	assert.Empty(t, manifest.Repo)
+	assert.FileExists(t, filepath.Join(installPath, "my-skill", "README.md"))
+}
+
+func TestInstallFromFile_Unsigned(t *testing.T) {
+	zipPath := createBundle(t, t.TempDir(), "")
+
+	_, err := NewInstallCommand().SetFromFile(zipPath).SetSignaturePolicy(common.SignaturePolicyWarn).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	assert.ErrorContains(t, err, "the bundle is not signed")
+
+	// With the signature policy off, the attestation is not checked at all.
+	entries, err := NewInstallCommand().SetFromFile(zipPath).SetSignaturePolicy(common.SignaturePolicyOff).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	require.NoError(t, err)
+	assert.Equal(t, common.EvidenceStatusUnverified, entries[0].Evidence)
+
+	t.Setenv("JFROG_SKILLS_DISABLE_QUIET_FAILURE", "true")
+	entries, err = NewInstallCommand().SetFromFile(zipPath).SetSignaturePolicy(common.SignaturePolicyWarn).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	require.NoError(t, err)
+	assert.Equal(t, common.EvidenceStatusUnverified, entries[0].Evidence)
+}
+
+func TestInstallFromFile_Rejected(t *testing.T) {
+	bundleDir := t.TempDir()
+	privPath, pubPath := writeSigningKey(t, bundleDir)
+	zipPath := createBundle(t, bundleDir, privPath)
+
+	_, err := NewInstallCommand().SetFromFile(zipPath).SetSlug("other").SetPublicKeyPath(pubPath).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	assert.ErrorContains(t, err, "contains skill 'my-skill', not 'other'")
+
+	_, err = NewInstallCommand().SetFromFile(filepath.Join(bundleDir, "missing.zip")).SetInstallPath(t.TempDir()).Install()
+	assert.ErrorContains(t, err, "jf skills pack")
+
+	// The metadata is not signed: a crafted slug must not escape the install path,
+	// and a renamed skill must not be vouched for by the attestation of the original one.
+	metadata, err := common.ReadBundleMetadata(zipPath)
+	require.NoError(t, err)
+	metadata.Slug = "../../escaped"
+	require.NoError(t, metadata.Save(zipPath))
+	_, err = NewInstallCommand().SetFromFile(zipPath).SetPublicKeyPath(pubPath).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	assert.ErrorContains(t, err, "invalid skill slug '../../escaped'")
+
+	metadata.Slug = "other-skill"
+	require.NoError(t, metadata.Save(zipPath))
+	_, err = NewInstallCommand().SetFromFile(zipPath).SetPublicKeyPath(pubPath).SetSignaturePolicy(common.SignaturePolicyRequire).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	assert.ErrorContains(t, err, "attestation is for skill 'my-skill' version '1.0.0', not 'other-skill' version '1.0.0'")
+	metadata.Slug = "my-skill"
+	require.NoError(t, metadata.Save(zipPath))
+
+	// Replacing the zip after packing breaks the digest pinned in the metadata.
+	createTestZip(t, zipPath, map[string]string{"SKILL.md": "---\nname: my-skill\ndescription: tampered\n---\n"})
+	_, err = NewInstallCommand().SetFromFile(zipPath).SetPublicKeyPath(pubPath).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	assert.ErrorContains(t, err, "integrity check failed for bundle")
+}
+
+func TestInstallFromFile_TrustStore(t *testing.T) {
+	bundleDir := t.TempDir()
+	privPath, pubPath := writeSigningKey(t, bundleDir)
+	zipPath := createBundle(t, bundleDir, privPath)
+
+	empty := &common.TrustStore{Dir: t.TempDir()}
+	_, err := NewInstallCommand().SetFromFile(zipPath).SetTrustStore(empty).SetSignaturePolicy(common.SignaturePolicyRequire).SetInstallPath(t.TempDir()).Install()
+	assert.ErrorContains(t, err, "has no keys")
+
+	trustStore := &common.TrustStore{Dir: t.TempDir()}
+	_, err = trustStore.Add(pubPath, "release")
+	require.NoError(t, err)
+	entries, err := NewInstallCommand().SetFromFile(zipPath).SetTrustStore(trustStore).SetSignaturePolicy(common.SignaturePolicyWarn).SetInstallPath(t.TempDir()).SetQuiet(true).Install()
+	require.NoError(t, err)
+	assert.Equal(t, common.EvidenceStatusVerified, entries[0].Evidence)
+	assert.Equal(t, "release", entries[0].SignedBy)
+}
+
+func TestInstallFromFile_Targets(t *testing.T) {
+	bundleDir := t.TempDir()
+	privPath, pubPath := writeSigningKey(t, bundleDir)
+	zipPath := createBundle(t, bundleDir, privPath)
+	project := t.TempDir()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	targets, err := common.ParseInstallTargets("cursor,claude:global")
+	require.NoError(t, err)
+	entries, err := NewInstallCommand().SetFromFile(zipPath).SetPublicKeyPath(pubPath).SetInstallPath(project).SetTargets(targets).SetQuiet(true).Install()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, []string{"claude:global", "cursor"}, entries[0].Targets)
+
+	for dir, target := range map[string]string{
+		filepath.Join(project, ".cursor", "skills", "my-skill"): "cursor",
+		filepath.Join(home, ".claude", "skills", "my-skill"):    "claude:global",
+	} {
+		manifest, err := common.ReadManifest(dir)
+		require.NoError(t, err)
+		assert.Equal(t, target, manifest.Target)
+		assert.FileExists(t, filepath.Join(dir, "SKILL.md"))
+	}
+	// Cursor only loads rule files, so a rule pointing at the installed skill is written for it.
+	rule, err := os.ReadFile(filepath.Join(project, ".cursor", "rules", "my-skill.mdc"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\ndescription: \"test\"\nalwaysApply: false\n---\n\nThe files of this skill are installed in `.cursor/skills/my-skill`. Resolve the paths below against this directory.\n\n# My Skill\n", string(rule))
+	// With targets, the install path is the project root and nothing is installed into it directly.
+	assert.NoDirExists(t, filepath.Join(project, "my-skill"))
+}
@@ -0,0 +1,267 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// maxResolutionSteps bounds dependency resolution so that pathological graphs
+// (e.g. requirements that keep flipping each other's versions) fail instead of looping.
+const maxResolutionSteps = 1000
+
+// skillSource provides the repository data needed to resolve a dependency graph.
+type skillSource interface {
+	listVersions(slug string) ([]string, error)
+	fetch(slug, version string) (*fetchedSkill, error)
+}
+
+// fetchedSkill is a downloaded, verified and unpacked skill version.
+type fetchedSkill struct {
+	dir          string
+	sha256       string
+	evidence     string
+	signedBy     string
+	dependencies []publish.Dependency
+}
+
+// remoteSource lists and fetches skills from the install command's repository.
+type remoteSource struct {
+	ic     *InstallCommand
+	tmpDir string
+}
+
+func (s *remoteSource) listVersions(slug string) ([]string, error) {
+	versions, err := common.ListVersions(s.ic.serverDetails, s.ic.repoKey, slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return nil, fmt.Errorf("skill '%s' not found in repository '%s'", slug, s.ic.repoKey)
+		}
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	versionStrs := make([]string, len(versions))
+	for i, v := range versions {
+		versionStrs[i] = v.Version
+	}
+	return versionStrs, nil
+}
+
+func (s *remoteSource) fetch(slug, version string) (*fetchedSkill, error) {
+	skillCmd := *s.ic
+	skillCmd.slug = slug
+	skillCmd.version = version
+	if slug != s.ic.slug {
+		// A pinned digest only applies to the requested skill.
+		skillCmd.expectedSHA256 = ""
+		log.Info(fmt.Sprintf("Fetching dependency '%s' version '%s'", slug, version))
+	}
+	return skillCmd.fetch(s.tmpDir)
+}
+
+// readDependencies returns the dependencies declared in an unpacked skill's SKILL.md.
+// A skill without SKILL.md has no dependencies.
+func readDependencies(skillDir string) ([]publish.Dependency, error) {
+	meta, err := publish.ParseSkillMeta(skillDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if errors.Is(err, publish.ErrInvalidFrontmatter) {
+			// Skills published before frontmatter validation may not parse; install them without dependencies.
+			log.Warn(fmt.Sprintf("Ignoring dependencies of the skill in %s: %s", skillDir, err))
+			return nil, nil
+		}
+		return nil, err
+	}
+	return meta.Dependencies, nil
+}
+
+// requirement is a version range placed on a skill, either by the user or by another skill.
+type requirement struct {
+	spec string
+	// requiredBy is the slug of the dependent skill, empty for the skill requested by the user.
+	requiredBy string
+}
+
+func (r requirement) String() string {
+	if r.requiredBy == "" {
+		return fmt.Sprintf("%s (requested)", r.spec)
+	}
+	return fmt.Sprintf("%s (required by %s)", r.spec, r.requiredBy)
+}
+
+// resolvedSkill is a node of the resolved dependency closure.
+type resolvedSkill struct {
+	slug       string
+	version    string
+	skill      *fetchedSkill
+	requiredBy []string
+}
+
+type dependencyResolver struct {
+	source   skillSource
+	versions map[string][]string
+	reqs     map[string][]requirement
+	picked   map[string]*resolvedSkill
+}
+
+// resolveDependencies resolves the transitive closure of rootSlug@rootVersion.
+// Every skill gets the newest version satisfying all ranges placed on it; when no
+// such version exists the error lists each conflicting range and who required it.
+// The root is returned first, followed by its dependencies sorted by slug.
+func resolveDependencies(source skillSource, rootSlug, rootVersion string) ([]*resolvedSkill, error) {
+	r := &dependencyResolver{
+		source:   source,
+		versions: map[string][]string{},
+		reqs:     map[string][]requirement{rootSlug: {{spec: rootVersion}}},
+		picked:   map[string]*resolvedSkill{},
+	}
+
+	queue := []string{rootSlug}
+	for steps := 0; len(queue) > 0; steps++ {
+		if steps >= maxResolutionSteps {
+			return nil, fmt.Errorf("dependency resolution for skill '%s' did not converge after %d steps", rootSlug, maxResolutionSteps)
+		}
+		slug := queue[0]
+		queue = queue[1:]
+
+		if len(r.reqs[slug]) == 0 {
+			// No longer required by anything (its dependents moved to other versions).
+			continue
+		}
+		var version string
+		if slug == rootSlug {
+			// The requested version is fixed; skills depending back on it must accept it.
+			version = rootVersion
+			if !satisfiesAll(version, r.reqs[slug]) {
+				return nil, conflictError(slug, r.reqs[slug], []string{version})
+			}
+		} else {
+			var err error
+			if version, err = r.pickVersion(slug); err != nil {
+				return nil, err
+			}
+		}
+
+		current := r.picked[slug]
+		if current != nil && current.version == version {
+			continue
+		}
+		if current != nil {
+			// The requirements of the previously picked version no longer apply.
+			for _, dep := range current.skill.dependencies {
+				r.dropRequirement(dep.Slug, slug)
+				queue = append(queue, dep.Slug)
+			}
+		}
+
+		skill, err := source.fetch(slug, version)
+		if err != nil {
+			return nil, err
+		}
+		r.picked[slug] = &resolvedSkill{slug: slug, version: version, skill: skill}
+		for _, dep := range skill.dependencies {
+			r.reqs[dep.Slug] = append(r.reqs[dep.Slug], requirement{spec: dep.Version, requiredBy: slug})
+			queue = append(queue, dep.Slug)
+		}
+	}
+
+	return r.closure(rootSlug), nil
+}
+
+func (r *dependencyResolver) pickVersion(slug string) (string, error) {
+	available, ok := r.versions[slug]
+	if !ok {
+		var err error
+		if available, err = r.source.listVersions(slug); err != nil {
+			return "", fmt.Errorf("dependency '%s': %w", slug, err)
+		}
+		r.versions[slug] = available
+	}
+
+	reqs := r.reqs[slug]
+	var candidates []string
+	for _, v := range available {
+		if satisfiesAll(v, reqs) {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", conflictError(slug, reqs, available)
+	}
+	if version, err := common.LatestVersion(candidates); err == nil {
+		return version, nil
+	}
+	// Only non-semver versions matched (exact pins such as "custom").
+	return candidates[0], nil
+}
+
+func satisfiesAll(version string, reqs []requirement) bool {
+	for _, req := range reqs {
+		if !common.SatisfiesVersionSpec(version, req.spec) {
+			return false
+		}
+	}
+	return true
+}
+
+func conflictError(slug string, reqs []requirement, available []string) error {
+	descriptions := make([]string, len(reqs))
+	for i, req := range reqs {
+		descriptions[i] = req.String()
+	}
+	if len(reqs) == 1 {
+		return fmt.Errorf("no version of dependency '%s' satisfies %s (available: %s)", slug, descriptions[0], strings.Join(common.SortVersions(available), ", "))
+	}
+	return fmt.Errorf("dependency conflict for skill '%s': no single version satisfies %s (available: %s)", slug, strings.Join(descriptions, ", "), strings.Join(common.SortVersions(available), ", "))
+}
+
+func (r *dependencyResolver) dropRequirement(slug, requiredBy string) {
+	kept := r.reqs[slug][:0]
+	for _, req := range r.reqs[slug] {
+		if req.requiredBy != requiredBy {
+			kept = append(kept, req)
+		}
+	}
+	r.reqs[slug] = kept
+}
+
+// closure returns the picked skills still reachable from the root.
+func (r *dependencyResolver) closure(rootSlug string) []*resolvedSkill {
+	reachable := map[string]bool{rootSlug: true}
+	stack := []string{rootSlug}
+	for len(stack) > 0 {
+		slug := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range r.picked[slug].skill.dependencies {
+			if !reachable[dep.Slug] {
+				reachable[dep.Slug] = true
+				stack = append(stack, dep.Slug)
+			}
+		}
+	}
+
+	var deps []*resolvedSkill
+	for slug := range reachable {
+		if slug == rootSlug {
+			// The requested skill stays a direct install, even when a dependency requires it back.
+			continue
+		}
+		node := r.picked[slug]
+		for _, req := range r.reqs[slug] {
+			if req.requiredBy != "" && reachable[req.requiredBy] {
+				node.requiredBy = append(node.requiredBy, req.requiredBy)
+			}
+		}
+		sort.Strings(node.requiredBy)
+		deps = append(deps, node)
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].slug < deps[j].slug })
+	return append([]*resolvedSkill{r.picked[rootSlug]}, deps...)
+}
@@ -0,0 +1,153 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource serves skill versions and their dependencies from memory.
+type fakeSource struct {
+	// skills maps slug -> version -> dependencies.
+	skills  map[string]map[string][]publish.Dependency
+	fetched []string
+}
+
+func (f *fakeSource) listVersions(slug string) ([]string, error) {
+	versions, ok := f.skills[slug]
+	if !ok {
+		return nil, fmt.Errorf("skill '%s' not found", slug)
+	}
+	var result []string
+	for v := range versions {
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func (f *fakeSource) fetch(slug, version string) (*fetchedSkill, error) {
+	deps, ok := f.skills[slug][version]
+	if !ok {
+		return nil, fmt.Errorf("skill '%s' version '%s' not found", slug, version)
+	}
+	f.fetched = append(f.fetched, slug+"@"+version)
+	return &fetchedSkill{dependencies: deps}, nil
+}
+
+func deps(pairs ...string) []publish.Dependency {
+	var result []publish.Dependency
+	for i := 0; i < len(pairs); i += 2 {
+		result = append(result, publish.Dependency{Slug: pairs[i], Version: pairs[i+1]})
+	}
+	return result
+}
+
+func summarize(skills []*resolvedSkill) []string {
+	var result []string
+	for _, s := range skills {
+		result = append(result, fmt.Sprintf("%s@%s<-%v", s.slug, s.version, s.requiredBy))
+	}
+	return result
+}
+
+func TestResolveDependencies_Transitive(t *testing.T) {
+	source := &fakeSource{skills: map[string]map[string][]publish.Dependency{
+		"app":     {"1.0.0": deps("helpers", "^1.0", "lint", "~2.1.0")},
+		"helpers": {"1.0.0": nil, "1.4.0": deps("core", ">=3"), "2.0.0": nil},
+		"lint":    {"2.1.0": nil, "2.1.5": deps("core", "^3.1"), "2.2.0": nil},
+		"core":    {"3.0.0": nil, "3.2.0": nil, "4.0.0-beta.1": nil},
+	}}
+
+	skills, err := resolveDependencies(source, "app", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"app@1.0.0<-[]",
+		"core@3.2.0<-[helpers lint]",
+		"helpers@1.4.0<-[app]",
+		"lint@2.1.5<-[app]",
+	}, summarize(skills))
+}
+
+func TestResolveDependencies_Conflict(t *testing.T) {
+	source := &fakeSource{skills: map[string]map[string][]publish.Dependency{
+		"app":     {"1.0.0": deps("a", "^1", "b", "^1")},
+		"a":       {"1.0.0": deps("helpers", "^1.0")},
+		"b":       {"1.0.0": deps("helpers", "^2.0")},
+		"helpers": {"1.5.0": nil, "2.3.0": nil},
+	}}
+
+	_, err := resolveDependencies(source, "app", "1.0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency conflict for skill 'helpers'")
+	assert.Contains(t, err.Error(), "^1.0 (required by a)")
+	assert.Contains(t, err.Error(), "^2.0 (required by b)")
+	assert.Contains(t, err.Error(), "available: 1.5.0, 2.3.0")
+}
+
+func TestResolveDependencies_RepicksWhenNarrowed(t *testing.T) {
+	// "a" first picks helpers@1.9.0, whose own dependency must be dropped once
+	// "b" (reached later through "c") narrows helpers to ~1.2.
+	source := &fakeSource{skills: map[string]map[string][]publish.Dependency{
+		"app":     {"1.0.0": deps("a", "^1", "c", "^1")},
+		"a":       {"1.0.0": deps("helpers", "^1.0")},
+		"c":       {"1.0.0": deps("b", "^1")},
+		"b":       {"1.0.0": deps("helpers", "~1.2")},
+		"helpers": {"1.2.3": nil, "1.9.0": deps("extra", "^1")},
+		"extra":   {"1.0.0": nil},
+	}}
+
+	skills, err := resolveDependencies(source, "app", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"app@1.0.0<-[]",
+		"a@1.0.0<-[app]",
+		"b@1.0.0<-[c]",
+		"c@1.0.0<-[app]",
+		"helpers@1.2.3<-[a b]",
+	}, summarize(skills))
+	assert.Contains(t, source.fetched, "helpers@1.9.0")
+	assert.Contains(t, source.fetched, "extra@1.0.0")
+}
+
+func TestResolveDependencies_Cycle(t *testing.T) {
+	source := &fakeSource{skills: map[string]map[string][]publish.Dependency{
+		"app":     {"1.0.0": deps("helpers", "^1")},
+		"helpers": {"1.0.0": deps("app", "^1")},
+	}}
+
+	skills, err := resolveDependencies(source, "app", "1.0.0")
+	require.NoError(t, err)
+	// The requested skill is not a dependency, even though helpers requires it back.
+	assert.Equal(t, []string{"app@1.0.0<-[]", "helpers@1.0.0<-[app]"}, summarize(skills))
+
+	source.skills["helpers"]["1.0.0"] = deps("app", "^2")
+	_, err = resolveDependencies(source, "app", "1.0.0")
+	assert.ErrorContains(t, err, "^2 (required by helpers)")
+}
+
+func TestResolveDependencies_MissingDependency(t *testing.T) {
+	source := &fakeSource{skills: map[string]map[string][]publish.Dependency{
+		"app": {"1.0.0": deps("ghost", "^1")},
+	}}
+
+	_, err := resolveDependencies(source, "app", "1.0.0")
+	assert.ErrorContains(t, err, "dependency 'ghost'")
+}
+
+func TestReadDependencies(t *testing.T) {
+	dir := t.TempDir()
+	found, err := readDependencies(dir)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	skillMD := "---\nname: app\ndependencies:\n  helpers: ^1.2\n---\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+	found, err = readDependencies(dir)
+	require.NoError(t, err)
+	assert.Equal(t, deps("helpers", "^1.2"), found)
+}
@@ -2,12 +2,15 @@ package install
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
@@ -26,10 +29,33 @@ type InstallCommand struct {
 	version       string
 	installPath   string
 	quiet         bool
+	// expectedSHA256, when set, pins the downloaded zip to a known digest (e.g. from skills.lock).
+	expectedSHA256 string
+	// lockFilePath, when set, records the installed skill in the given lockfile.
+	lockFilePath string
+	// skipDependencies installs only the requested skill, ignoring SKILL.md dependencies.
+	skipDependencies bool
+	// force overwrites files that were edited locally since the skill was installed.
+	force bool
+	// showDiff prints the file changes of every skill before it is installed.
+	showDiff bool
+	// dryRun resolves and downloads the skills but leaves the install path untouched.
+	dryRun bool
+	// fromFile installs an offline bundle created by `skills pack` instead of downloading from Artifactory.
+	fromFile string
+	// publicKeyPath verifies the signed attestation of an offline bundle.
+	publicKeyPath string
+	// signaturePolicy controls how missing or invalid detached signatures are handled.
+	signaturePolicy common.SignaturePolicy
+	// trustStore holds the keys signatures are verified against; loaded from TrustStoreDir when nil.
+	trustStore *common.TrustStore
+	// targets installs every skill into the skills directory of each agent target instead of the install path,
+	// which is then the project root of project-local targets.
+	targets []common.InstallTarget
 }
 
 func NewInstallCommand() *InstallCommand {
-	return &InstallCommand{}
+	return &InstallCommand{signaturePolicy: common.SignaturePolicyOff}
 }
 
 func (ic *InstallCommand) SetServerDetails(details *config.ServerDetails) *InstallCommand {
@@ -62,6 +88,61 @@ func (ic *InstallCommand) SetQuiet(quiet bool) *InstallCommand {
 	return ic
 }
 
+func (ic *InstallCommand) SetExpectedSHA256(sha256 string) *InstallCommand {
+	ic.expectedSHA256 = sha256
+	return ic
+}
+
+func (ic *InstallCommand) SetLockFilePath(path string) *InstallCommand {
+	ic.lockFilePath = path
+	return ic
+}
+
+func (ic *InstallCommand) SetSkipDependencies(skip bool) *InstallCommand {
+	ic.skipDependencies = skip
+	return ic
+}
+
+func (ic *InstallCommand) SetForce(force bool) *InstallCommand {
+	ic.force = force
+	return ic
+}
+
+func (ic *InstallCommand) SetShowDiff(showDiff bool) *InstallCommand {
+	ic.showDiff = showDiff
+	return ic
+}
+
+func (ic *InstallCommand) SetDryRun(dryRun bool) *InstallCommand {
+	ic.dryRun = dryRun
+	return ic
+}
+
+func (ic *InstallCommand) SetFromFile(path string) *InstallCommand {
+	ic.fromFile = path
+	return ic
+}
+
+func (ic *InstallCommand) SetPublicKeyPath(path string) *InstallCommand {
+	ic.publicKeyPath = path
+	return ic
+}
+
+func (ic *InstallCommand) SetSignaturePolicy(policy common.SignaturePolicy) *InstallCommand {
+	ic.signaturePolicy = policy
+	return ic
+}
+
+func (ic *InstallCommand) SetTrustStore(trustStore *common.TrustStore) *InstallCommand {
+	ic.trustStore = trustStore
+	return ic
+}
+
+func (ic *InstallCommand) SetTargets(targets []common.InstallTarget) *InstallCommand {
+	ic.targets = targets
+	return ic
+}
+
 func (ic *InstallCommand) ServerDetails() (*config.ServerDetails, error) {
 	return ic.serverDetails, nil
 }
@@ -71,103 +152,359 @@ func (ic *InstallCommand) CommandName() string {
 }
 
 func (ic *InstallCommand) Run() error {
-	version, err := ic.resolveVersion()
+	entries, err := ic.Install()
 	if err != nil {
 		return err
 	}
+	// Bundles are not tied to a repository, so they cannot be re-installed by `skills sync`.
+	if ic.lockFilePath == "" || ic.dryRun || ic.fromFile != "" {
+		return nil
+	}
+	return recordLockEntries(ic.lockFilePath, entries, !ic.skipDependencies)
+}
+
+// Install resolves the requested skill and, unless disabled, its transitive dependencies,
+// then downloads, verifies and copies each of them into the install path, next to an
+// install manifest (.jfrog-skill.json). Files edited since a previous install are only
+// overwritten when force is set. It returns the lockfile entries describing exactly what was installed, requested skill first.
+func (ic *InstallCommand) Install() ([]common.LockEntry, error) {
+	if ic.fromFile != "" {
+		return ic.installFromFile()
+	}
+
+	requestedVersion := ic.version
+	version, err := ic.resolveVersion()
+	if err != nil {
+		return nil, err
+	}
 	ic.version = version
 
 	log.Info(fmt.Sprintf("Installing skill '%s' version '%s'", ic.slug, ic.version))
 
 	tmpDir, err := os.MkdirTemp("", "skill-install-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temp dir: %w", err)
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 	defer func() {
 		_ = os.RemoveAll(tmpDir)
 	}()
 
+	source := &remoteSource{ic: ic, tmpDir: tmpDir}
+	var skills []*resolvedSkill
+	if ic.skipDependencies {
+		skill, err := source.fetch(ic.slug, ic.version)
+		if err != nil {
+			return nil, err
+		}
+		skills = []*resolvedSkill{{slug: ic.slug, version: ic.version, skill: skill}}
+	} else {
+		skills, err = resolveDependencies(source, ic.slug, ic.version)
+		if err != nil {
+			return nil, err
+		}
+		if len(skills) > 1 {
+			resolved := make([]string, 0, len(skills)-1)
+			for _, dep := range skills[1:] {
+				resolved = append(resolved, dep.slug+"@"+dep.version)
+			}
+			log.Info(fmt.Sprintf("Resolved %d dependencies: %s", len(resolved), strings.Join(resolved, ", ")))
+		}
+	}
+
+	entries := make([]common.LockEntry, 0, len(skills))
+	for _, resolved := range skills {
+		entries = append(entries, common.LockEntry{
+			Slug:       resolved.slug,
+			Version:    resolved.version,
+			Repo:       ic.repoKey,
+			SHA256:     resolved.skill.sha256,
+			Evidence:   resolved.skill.evidence,
+			SignedBy:   resolved.skill.signedBy,
+			Targets:    common.TargetNames(ic.targets),
+			RequiredBy: resolved.requiredBy,
+		})
+	}
+	if common.IsVersionRange(requestedVersion) {
+		entries[0].Constraint = requestedVersion
+	}
+
+	srcDirs := make([]string, len(skills))
+	for i, resolved := range skills {
+		srcDirs[i] = resolved.skill.dir
+	}
+	if err := ic.place(entries, srcDirs); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// place copies each skill from its source directory into the install path, or into every install target.
+func (ic *InstallCommand) place(entries []common.LockEntry, srcDirs []string) error {
+	// Check every skill for local changes before touching any of them.
+	var placements []*placement
+	var errs []error
+	for i, entry := range entries {
+		destinations, err := ic.skillDestinations(entry.Slug)
+		if err != nil {
+			return err
+		}
+		for _, dest := range destinations {
+			p, err := planPlacement(entry, srcDirs[i], dest.dir)
+			if err != nil {
+				return err
+			}
+			p.target = dest.target
+			p.rule = dest.rule
+			if err := p.checkLocalChanges(ic.force); err != nil {
+				errs = append(errs, err)
+			}
+			placements = append(placements, p)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, p := range placements {
+		if ic.showDiff {
+			p.printDiff()
+		}
+		if ic.dryRun {
+			continue
+		}
+		if err := p.apply(); err != nil {
+			return fmt.Errorf("failed to copy files of skill '%s': %w", p.entry.Slug, err)
+		}
+		log.Info(fmt.Sprintf("Skill '%s' version '%s' installed to %s", p.entry.Slug, p.entry.Version, p.destDir))
+		if p.rule != nil {
+			log.Info(fmt.Sprintf("Rule file of skill '%s' written to %s", p.entry.Slug, p.rule.path))
+		}
+	}
+	return nil
+}
+
+// fetch downloads the skill zip into tmpDir, verifies its checksum, signature and evidence and unpacks it.
+// A signature verified against the trust store attests the skill, so the evidence service is not consulted.
+func (ic *InstallCommand) fetch(tmpDir string) (*fetchedSkill, error) {
 	zipPath, err := ic.downloadZip(tmpDir)
 	if err != nil {
 		if strings.Contains(err.Error(), "403") {
-			return ic.diagnoseDownloadForbidden(err)
+			return nil, ic.diagnoseDownloadForbidden(err)
+		}
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+
+	sha256Hex, err := ic.verifyChecksum(zipPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signedBy := ""
+	if ic.signaturePolicy != common.SignaturePolicyOff {
+		if signedBy, err = ic.checkSignature(ic.verifySignature(tmpDir, sha256Hex)); err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("download failed: %w", err)
 	}
 
-	unzipDir := filepath.Join(tmpDir, "contents")
+	unzipDir := filepath.Join(tmpDir, fmt.Sprintf("%s-%s", ic.slug, ic.version))
 	if err := unzipFile(zipPath, unzipDir); err != nil {
-		return fmt.Errorf("unzip failed: %w", err)
+		return nil, fmt.Errorf("unzip failed: %w", err)
 	}
 
-	if err := ic.verifyEvidence(); err != nil {
-		if ic.quiet || common.IsNonInteractive() {
-			if common.ShouldFailOnMissingEvidence() {
-				return fmt.Errorf("evidence verification failed for skill '%s': %s. Set JFROG_SKILLS_DISABLE_QUIET_FAILURE=true to proceed without evidence", ic.slug, err.Error())
-			}
-			log.Warn(fmt.Sprintf("Evidence verification failed for skill '%s': %s. Proceeding with installation.", ic.slug, err.Error()))
-		} else {
-			log.Warn("Evidence verification failed:", err.Error())
-			if !coreutils.AskYesNo("The skill is unattested. Continue with installation?", false) {
-				return fmt.Errorf("installation aborted by user")
-			}
+	evidenceStatus := common.EvidenceStatusVerified
+	if signedBy == "" {
+		if evidenceStatus, err = ic.checkEvidence(ic.verifyEvidence()); err != nil {
+			return nil, err
 		}
 	}
 
-	destDir := ic.getDestDir()
-	if err := copyDir(unzipDir, destDir); err != nil {
-		return fmt.Errorf("failed to copy skill files: %w", err)
+	skill := &fetchedSkill{dir: unzipDir, sha256: sha256Hex, evidence: evidenceStatus, signedBy: signedBy}
+	if !ic.skipDependencies {
+		if skill.dependencies, err = readDependencies(unzipDir); err != nil {
+			return nil, fmt.Errorf("failed to read dependencies of skill '%s' version '%s': %w", ic.slug, ic.version, err)
+		}
 	}
+	return skill, nil
+}
 
-	log.Info(fmt.Sprintf("Skill '%s' version '%s' installed to %s", ic.slug, ic.version, destDir))
-	return nil
+// checkEvidence returns the evidence status for the result of an evidence verification.
+// When verification failed, non-interactive installs fail unless JFROG_SKILLS_DISABLE_QUIET_FAILURE
+// is set, and interactive installs ask the user whether to continue.
+func (ic *InstallCommand) checkEvidence(verifyErr error) (string, error) {
+	if verifyErr == nil {
+		return common.EvidenceStatusVerified, nil
+	}
+	if ic.quiet || common.IsNonInteractive() {
+		if common.ShouldFailOnMissingEvidence() {
+			return "", fmt.Errorf("evidence verification failed for skill '%s': %s. Set JFROG_SKILLS_DISABLE_QUIET_FAILURE=true to proceed without evidence", ic.slug, verifyErr.Error())
+		}
+		log.Warn(fmt.Sprintf("Evidence verification failed for skill '%s': %s. Proceeding with installation.", ic.slug, verifyErr.Error()))
+	} else {
+		log.Warn("Evidence verification failed:", verifyErr.Error())
+		if !coreutils.AskYesNo(fmt.Sprintf("The skill '%s' is unattested. Continue with installation?", ic.slug), false) {
+			return "", fmt.Errorf("installation aborted by user")
+		}
+	}
+	return common.EvidenceStatusUnverified, nil
 }
 
-func (ic *InstallCommand) resolveVersion() (string, error) {
-	if ic.version == "latest" || ic.version == "" {
-		versions, err := common.ListVersions(ic.serverDetails, ic.repoKey, ic.slug)
+// checkSignature applies the signature policy to the result of a signature verification and
+// returns the name of the trusted key that signed the skill, or "" when it is not verified.
+func (ic *InstallCommand) checkSignature(signedBy string, verifyErr error) (string, error) {
+	if verifyErr == nil {
+		log.Info(fmt.Sprintf("Signature of skill '%s' version '%s' verified with trusted key '%s'", ic.slug, ic.version, signedBy))
+		return signedBy, nil
+	}
+	if ic.signaturePolicy == common.SignaturePolicyRequire {
+		return "", fmt.Errorf("signature verification failed for skill '%s' version '%s': %w", ic.slug, ic.version, verifyErr)
+	}
+	log.Warn(fmt.Sprintf("Signature verification failed for skill '%s' version '%s': %s", ic.slug, ic.version, verifyErr.Error()))
+	return "", nil
+}
+
+// verifySignature downloads the detached signature published next to the skill zip and verifies
+// it against the trust store. It returns the name of the trusted key that verified it.
+func (ic *InstallCommand) verifySignature(tmpDir, sha256Hex string) (string, error) {
+	trustStore, err := ic.loadTrustStore()
+	if err != nil {
+		return "", err
+	}
+	sigName := fmt.Sprintf("%s-%s.zip%s", ic.slug, ic.version, common.SignatureSuffix)
+	sigPath, err := ic.download(tmpDir, sigName)
+	if err != nil {
+		return "", fmt.Errorf("failed to download signature: %w", err)
+	}
+	if sigPath == "" {
+		return "", fmt.Errorf("the skill is not signed (no %s in repository '%s')", sigName, ic.repoKey)
+	}
+	envelope, err := dsse.ReadEnvelope(sigPath)
+	if err != nil {
+		return "", err
+	}
+	return trustStore.VerifyAttestation(envelope, ic.slug, ic.version, sha256Hex)
+}
+
+func (ic *InstallCommand) loadTrustStore() (*common.TrustStore, error) {
+	if ic.trustStore == nil {
+		dir, err := common.TrustStoreDir()
 		if err != nil {
-			if strings.Contains(err.Error(), "404 Not Found") {
-				return "", fmt.Errorf("skill '%s' not found in repository '%s'", ic.slug, ic.repoKey)
-			}
-			if ic.version == "" {
-				return "", fmt.Errorf("failed to list versions (provide --version explicitly): %w", err)
-			}
-			return "", fmt.Errorf("failed to list versions: %w", err)
+			return nil, err
 		}
+		if ic.trustStore, err = common.LoadTrustStore(dir); err != nil {
+			return nil, err
+		}
+	}
+	return ic.trustStore, nil
+}
+
+// verifyChecksum computes the SHA-256 of the downloaded zip and, when a pinned
+// digest is expected, fails if the artifact in Artifactory has drifted from it.
+func (ic *InstallCommand) verifyChecksum(zipPath string) (string, error) {
+	sha256Hex, err := common.ComputeSHA256(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute SHA256: %w", err)
+	}
+	if ic.expectedSHA256 != "" && !strings.EqualFold(ic.expectedSHA256, sha256Hex) {
+		return "", fmt.Errorf("integrity check failed for skill '%s' version '%s': expected SHA256 %s but got %s. The artifact has changed since it was locked", ic.slug, ic.version, ic.expectedSHA256, sha256Hex)
+	}
+	return sha256Hex, nil
+}
+
+// ReportPrunedDependencies logs the dependencies removed from the lockfile by LockFile.RecordClosure.
+func ReportPrunedDependencies(pruned []string) {
+	for _, slug := range pruned {
+		log.Info(fmt.Sprintf("Skill '%s' is no longer required by any installed skill and was removed from the lockfile. Its installed files were left in place", slug))
+	}
+}
 
-		versionStrs := make([]string, len(versions))
-		for i, v := range versions {
-			versionStrs[i] = v.Version
+// recordLockEntries records the installed skills in the lockfile. When their dependencies were
+// resolved too, dependencies that the new versions no longer require are pruned.
+func recordLockEntries(lockFilePath string, entries []common.LockEntry, withDependencies bool) error {
+	lockFile, err := common.LoadLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+	if withDependencies {
+		ReportPrunedDependencies(lockFile.RecordClosure(entries))
+	} else {
+		for _, entry := range entries {
+			lockFile.Record(entry)
 		}
+	}
+	if err := lockFile.Save(lockFilePath); err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Recorded %d skill(s) in %s", len(entries), lockFilePath))
+	return nil
+}
+
+// resolveVersion turns the requested version into a concrete one.
+// Exact versions are used as-is; "latest", an empty version or a range
+// expression (e.g. "^1.2", "~1.4.0", ">=2 <3") are resolved against the repository.
+func (ic *InstallCommand) resolveVersion() (string, error) {
+	if ic.version != "" && ic.version != "latest" && !common.IsVersionRange(ic.version) {
+		return ic.version, nil
+	}
 
-		if ic.version == "latest" {
-			return common.LatestVersion(versionStrs)
+	versions, err := common.ListVersions(ic.serverDetails, ic.repoKey, ic.slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return "", fmt.Errorf("skill '%s' not found in repository '%s'", ic.slug, ic.repoKey)
+		}
+		if ic.version == "" {
+			return "", fmt.Errorf("failed to list versions (provide --version explicitly): %w", err)
 		}
+		return "", fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	versionStrs := make([]string, len(versions))
+	for i, v := range versions {
+		versionStrs[i] = v.Version
+	}
 
+	if ic.version == "" {
 		if ic.quiet || common.IsNonInteractive() {
-			return "", fmt.Errorf("--version is required in non-interactive mode (use semver or \"latest\")")
+			return "", fmt.Errorf("--version is required in non-interactive mode (use semver, a range such as \"^1.2\", or \"latest\")")
 		}
 
 		latest, err := common.LatestVersion(versionStrs)
 		if err != nil {
 			return "", err
 		}
-		log.Info("Available versions:", versionStrs)
+		log.Info("Available versions:", common.SortVersions(versionStrs))
 		log.Info("Using latest version:", latest)
 		return latest, nil
 	}
 
-	return ic.version, nil
+	resolved, err := common.ResolveVersionSpec(versionStrs, ic.version)
+	if err != nil {
+		return "", fmt.Errorf("skill '%s': %w", ic.slug, err)
+	}
+	if ic.version != "latest" {
+		log.Info(fmt.Sprintf("Resolved version range '%s' to %s", ic.version, resolved))
+	}
+	return resolved, nil
 }
 
 func (ic *InstallCommand) downloadZip(tmpDir string) (string, error) {
+	zipPath, err := ic.download(tmpDir, fmt.Sprintf("%s-%s.zip", ic.slug, ic.version))
+	if err != nil {
+		return "", err
+	}
+	if zipPath == "" {
+		return "", fmt.Errorf("skill '%s' version '%s' not found in repository '%s'", ic.slug, ic.version, ic.repoKey)
+	}
+	return zipPath, nil
+}
+
+// download fetches a file of the skill version into tmpDir. It returns "" when the file does not exist.
+func (ic *InstallCommand) download(tmpDir, fileName string) (string, error) {
 	serviceManager, err := utils.CreateDownloadServiceManager(ic.serverDetails, 1, 3, 0, false, nil)
 	if err != nil {
 		return "", err
 	}
 
-	pattern := fmt.Sprintf("%s/%s/%s/%s-%s.zip", ic.repoKey, ic.slug, ic.version, ic.slug, ic.version)
+	pattern := fmt.Sprintf("%s/%s/%s/%s", ic.repoKey, ic.slug, ic.version, fileName)
 
 	downloadParams := services.NewDownloadParams()
 	downloadParams.Pattern = pattern
@@ -182,12 +519,9 @@ func (ic *InstallCommand) downloadZip(tmpDir string) (string, error) {
 		return "", fmt.Errorf("download failed for %s", pattern)
 	}
 	if totalDownloaded == 0 {
-		return "", fmt.Errorf("skill '%s' version '%s' not found in repository '%s'", ic.slug, ic.version, ic.repoKey)
+		return "", nil
 	}
-
-	zipName := fmt.Sprintf("%s-%s.zip", ic.slug, ic.version)
-	zipPath := filepath.Join(tmpDir, zipName)
-	return zipPath, nil
+	return filepath.Join(tmpDir, fileName), nil
 }
 
 // diagnoseDownloadForbidden checks the Xray status API when a download returns 403.
@@ -216,17 +550,55 @@ func (ic *InstallCommand) verifyEvidence() error {
 
 	subjectRepoPath := fmt.Sprintf("%s/%s/%s/%s-%s.zip", ic.repoKey, ic.slug, ic.version, ic.slug, ic.version)
 
-	return common.VerifyEvidence(ic.serverDetails, common.VerifyEvidenceOpts{
+	return evidenceutils.VerifyEvidence(ic.serverDetails, evidenceutils.VerifyEvidenceOpts{
 		SubjectRepoPath: subjectRepoPath,
 	})
 }
 
-func (ic *InstallCommand) getDestDir() string {
+// destination is a directory a skill is installed into, with the install target it belongs to.
+type destination struct {
+	dir    string
+	target string
+	// rule is the rule file written for editors that don't load skills directories, nil otherwise.
+	rule *ruleFile
+}
+
+// skillDestinations returns the directories a skill is installed into: one per install target,
+// or the skill directory in the install path when no target is set.
+func (ic *InstallCommand) skillDestinations(slug string) ([]destination, error) {
+	if len(ic.targets) == 0 {
+		return []destination{{dir: ic.skillDestDir(slug)}}, nil
+	}
+	destinations := make([]destination, 0, len(ic.targets))
+	for _, target := range ic.targets {
+		base, err := target.Dir(ic.installPath)
+		if err != nil {
+			return nil, err
+		}
+		dest := destination{dir: filepath.Join(base, slug), target: target.String()}
+		rulePath, err := target.RuleFilePath(ic.installPath, slug)
+		if err != nil {
+			return nil, err
+		}
+		if rulePath != "" {
+			// Rules of the project refer to the skill relative to the project root, so that they can be committed.
+			skillDir := filepath.ToSlash(filepath.Join(target.Agent.ProjectDir, slug))
+			if target.Global {
+				skillDir = dest.dir
+			}
+			dest.rule = &ruleFile{path: rulePath, format: target.Agent.RuleFile, skillDir: skillDir}
+		}
+		destinations = append(destinations, dest)
+	}
+	return destinations, nil
+}
+
+func (ic *InstallCommand) skillDestDir(slug string) string {
 	base := ic.installPath
 	if base == "" {
 		base = defaultInstallBase
 	}
-	return filepath.Join(base, ic.slug)
+	return filepath.Join(base, slug)
 }
 
 func unzipFile(src, dest string) error {
@@ -348,8 +720,11 @@ func copyFile(src, dst string) error {
 
 // RunInstall is the CLI action for `jf skills install`.
 func RunInstall(c *components.Context) error {
+	if fromFile := c.GetStringFlagValue("from-file"); fromFile != "" {
+		return runInstallFromFile(c, fromFile)
+	}
 	if c.GetNumberOfArgs() < 1 {
-		return fmt.Errorf("usage: jf skills install <slug> [--repo <repo>] [options]")
+		return fmt.Errorf("usage: jf skills install <slug> [--repo <repo>] [options] or jf skills install --from-file <bundle.zip>")
 	}
 
 	slug := c.GetArgumentAt(0)
@@ -359,19 +734,68 @@ func RunInstall(c *components.Context) error {
 		return err
 	}
 
+	signaturePolicy, err := common.ResolveSignaturePolicy(c.GetStringFlagValue("signature-policy"))
+	if err != nil {
+		return err
+	}
+
 	quiet := common.IsQuiet(c)
 	repoKey, err := common.ResolveRepo(serverDetails, c.GetStringFlagValue("repo"), quiet)
 	if err != nil {
 		return err
 	}
 
+	targets, err := common.ParseInstallTargets(c.GetStringFlagValue("target"))
+	if err != nil {
+		return err
+	}
+
+	installPath := c.GetStringFlagValue("path")
+	lockFilePath := c.GetStringFlagValue("lockfile")
+	if lockFilePath == "" {
+		lockFilePath = common.LockFilePath(installPath)
+	}
+
 	cmd := NewInstallCommand().
 		SetServerDetails(serverDetails).
 		SetRepoKey(repoKey).
 		SetSlug(slug).
 		SetVersion(c.GetStringFlagValue("version")).
-		SetInstallPath(c.GetStringFlagValue("path")).
+		SetInstallPath(installPath).
+		SetTargets(targets).
+		SetLockFilePath(lockFilePath).
+		SetSkipDependencies(c.GetBoolFlagValue("no-deps")).
+		SetForce(c.GetBoolFlagValue("force")).
+		SetSignaturePolicy(signaturePolicy).
 		SetQuiet(quiet)
 
 	return cmd.Run()
 }
+
+// runInstallFromFile installs an offline bundle without contacting Artifactory.
+func runInstallFromFile(c *components.Context, fromFile string) error {
+	slug := ""
+	if c.GetNumberOfArgs() > 0 {
+		slug = c.GetArgumentAt(0)
+	}
+	signaturePolicy, err := common.ResolveSignaturePolicy(c.GetStringFlagValue("signature-policy"))
+	if err != nil {
+		return err
+	}
+	targets, err := common.ParseInstallTargets(c.GetStringFlagValue("target"))
+	if err != nil {
+		return err
+	}
+
+	cmd := NewInstallCommand().
+		SetFromFile(fromFile).
+		SetPublicKeyPath(c.GetStringFlagValue("public-key")).
+		SetSignaturePolicy(signaturePolicy).
+		SetSlug(slug).
+		SetInstallPath(c.GetStringFlagValue("path")).
+		SetTargets(targets).
+		SetForce(c.GetBoolFlagValue("force")).
+		SetQuiet(common.IsQuiet(c))
+
+	return cmd.Run()
+}
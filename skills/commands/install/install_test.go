@@ -2,10 +2,13 @@ package install
 
 import (
 	"archive/zip"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,13 +61,63 @@ func TestCopyDir(t *testing.T) {
 	assert.Equal(t, "content2", string(data))
 }
 
-func TestGetDestDir(t *testing.T) {
+func TestSkillDestDir(t *testing.T) {
 	cmd := NewInstallCommand().SetSlug("my-skill")
 
-	assert.Equal(t, filepath.Join(".", "my-skill"), cmd.getDestDir())
+	assert.Equal(t, filepath.Join(".", "my-skill"), cmd.skillDestDir("my-skill"))
 
 	cmd.SetInstallPath("/custom/path")
-	assert.Equal(t, filepath.Join("/custom/path", "my-skill"), cmd.getDestDir())
+	assert.Equal(t, filepath.Join("/custom/path", "my-skill"), cmd.skillDestDir("my-skill"))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "test.zip")
+	require.NoError(t, os.WriteFile(zipPath, []byte("hello world"), 0644))
+	const helloSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+	cmd := NewInstallCommand().SetSlug("my-skill").SetVersion("1.0.0")
+	sha, err := cmd.verifyChecksum(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, helloSHA256, sha)
+
+	cmd.SetExpectedSHA256(strings.ToUpper(helloSHA256))
+	_, err = cmd.verifyChecksum(zipPath)
+	assert.NoError(t, err)
+
+	cmd.SetExpectedSHA256("deadbeef")
+	_, err = cmd.verifyChecksum(zipPath)
+	assert.ErrorContains(t, err, "integrity check failed")
+}
+
+func TestRecordLockEntries(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), common.LockFileName)
+	require.NoError(t, recordLockEntries(lockPath, []common.LockEntry{{Slug: "my-skill", Version: "1.0.0", Repo: "skills", SHA256: "aa"}}, true))
+	require.NoError(t, recordLockEntries(lockPath, []common.LockEntry{
+		{Slug: "my-skill", Version: "1.1.0", Repo: "skills", SHA256: "bb"},
+		{Slug: "helpers", Version: "0.3.0", Repo: "skills", SHA256: "cc", RequiredBy: []string{"my-skill"}},
+	}, true))
+
+	lf, err := common.LoadLockFile(lockPath)
+	require.NoError(t, err)
+	require.Len(t, lf.Skills, 2)
+	assert.Equal(t, "1.1.0", lf.Find("my-skill").Version)
+	assert.Equal(t, []string{"my-skill"}, lf.Find("helpers").RequiredBy)
+}
+
+func TestCheckSignature(t *testing.T) {
+	verifyErr := errors.New("the skill is not signed")
+	ic := NewInstallCommand().SetSlug("my-skill").SetVersion("1.0.0")
+
+	signedBy, err := ic.SetSignaturePolicy(common.SignaturePolicyRequire).checkSignature("release", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "release", signedBy)
+
+	_, err = ic.checkSignature("", verifyErr)
+	assert.ErrorContains(t, err, "signature verification failed for skill 'my-skill' version '1.0.0': the skill is not signed")
+
+	signedBy, err = ic.SetSignaturePolicy(common.SignaturePolicyWarn).checkSignature("", verifyErr)
+	require.NoError(t, err)
+	assert.Empty(t, signedBy)
 }
 
 func createTestZip(t *testing.T, zipPath string, files map[string]string) {
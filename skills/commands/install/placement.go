@@ -0,0 +1,219 @@
+package install
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// maxDiffFileSize is the largest file for which a line diff is printed.
+const maxDiffFileSize = 256 * 1024
+
+// placement describes how a fetched skill replaces the files in its install directory.
+type placement struct {
+	entry   common.LockEntry
+	srcDir  string
+	destDir string
+	// target is the install target destDir belongs to, empty for the install path.
+	target string
+	// rule is the rule file written for the target, nil when the target loads skills directories.
+	rule *ruleFile
+	// previous is the manifest of the skill currently installed in destDir, nil if none.
+	previous *common.InstallManifest
+	// files are the hashes of the files shipped by the new version.
+	files map[string]string
+	// changes lists what installing the new version changes on disk.
+	changes common.FileChanges
+	// localChanges lists the files edited since the previous install that would be overwritten.
+	localChanges []string
+}
+
+// planPlacement compares a fetched skill with what is installed in destDir.
+func planPlacement(entry common.LockEntry, srcDir, destDir string) (*placement, error) {
+	files, err := common.HashSkillFiles(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	p := &placement{entry: entry, srcDir: srcDir, destDir: destDir, files: files}
+
+	previous, err := common.ReadManifest(destDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	p.previous = previous
+
+	current := map[string]string{}
+	if _, err := os.Stat(destDir); err == nil {
+		if current, err = common.HashSkillFiles(destDir); err != nil {
+			return nil, err
+		}
+	}
+	p.changes = common.DiffFiles(current, files)
+	// Only files shipped by the previous version are removed; anything else in the directory is the user's.
+	var removed []string
+	if previous != nil {
+		for _, path := range p.changes.Removed {
+			if _, ok := previous.Files[path]; ok {
+				removed = append(removed, path)
+			}
+		}
+	}
+	p.changes.Removed = removed
+
+	if previous != nil {
+		local := common.DiffFiles(previous.Files, current)
+		// Edited files are either overwritten or removed by the new version.
+		p.localChanges = append(p.localChanges, local.Modified...)
+		// Deleted and added files only matter when the new version ships a file with the same path.
+		for _, path := range append(local.Removed, local.Added...) {
+			if _, ok := files[path]; ok {
+				p.localChanges = append(p.localChanges, path)
+			}
+		}
+		sort.Strings(p.localChanges)
+	}
+	return p, nil
+}
+
+// checkLocalChanges fails when the placement would overwrite files edited since the previous install.
+func (p *placement) checkLocalChanges(force bool) error {
+	if len(p.localChanges) == 0 {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("skill '%s' in %s has local changes that would be overwritten: %s. Use --force to discard them", p.entry.Slug, p.destDir, strings.Join(p.localChanges, ", "))
+	}
+	log.Warn(fmt.Sprintf("Discarding local changes to skill '%s': %s", p.entry.Slug, strings.Join(p.localChanges, ", ")))
+	return nil
+}
+
+// apply copies the new files into the install directory, removes files the new version
+// no longer ships and writes the install manifest and the rule file of the target.
+func (p *placement) apply() error {
+	for _, path := range p.changes.Removed {
+		target := filepath.Join(p.destDir, filepath.FromSlash(path))
+		if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", target, err)
+		}
+		removeEmptyParents(filepath.Dir(target), p.destDir)
+	}
+	if err := copyDir(p.srcDir, p.destDir); err != nil {
+		return err
+	}
+	manifest := common.NewInstallManifest(p.entry, p.files)
+	manifest.Target = p.target
+	if err := manifest.Save(p.destDir); err != nil {
+		return err
+	}
+	if p.rule != nil {
+		return p.rule.write(p.srcDir)
+	}
+	return nil
+}
+
+// ruleFile is the rule file through which an editor loads an installed skill.
+type ruleFile struct {
+	path   string
+	format *common.RuleFile
+	// skillDir is the install directory of the skill, as referenced from the rule.
+	skillDir string
+}
+
+// write generates the rule file from the SKILL.md of the skill in srcDir. The rule file is
+// regenerated on every install, so edits to it are not preserved.
+func (r *ruleFile) write(srcDir string) error {
+	meta, instructions, err := publish.ParseSkillInstructions(srcDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return err
+	}
+	// #nosec G306 -- rule files are loaded by the editor and are not sensitive
+	if err := os.WriteFile(r.path, []byte(r.format.Content(meta.Description, r.skillDir, instructions)), 0644); err != nil {
+		return fmt.Errorf("failed to write rule file %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// removeEmptyParents removes dir and its parents up to (but excluding) root while they are empty.
+func removeEmptyParents(dir, root string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root+string(os.PathSeparator)); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
+// printDiff prints the files changed by the placement, followed by a unified diff of each modified text file.
+func (p *placement) printDiff() {
+	from := "not installed"
+	if p.previous != nil {
+		from = p.previous.Version
+	}
+	if p.changes.IsEmpty() {
+		fmt.Printf("Skill '%s' (%s -> %s): no file changes\n", p.entry.Slug, from, p.entry.Version)
+		return
+	}
+
+	fmt.Printf("Skill '%s' (%s -> %s):\n", p.entry.Slug, from, p.entry.Version)
+	for _, path := range p.changes.Added {
+		fmt.Printf("  A %s\n", path)
+	}
+	for _, path := range p.changes.Modified {
+		fmt.Printf("  M %s\n", path)
+	}
+	for _, path := range p.changes.Removed {
+		fmt.Printf("  D %s\n", path)
+	}
+	for _, path := range p.changes.Modified {
+		diff, err := fileDiff(filepath.Join(p.destDir, filepath.FromSlash(path)), filepath.Join(p.srcDir, filepath.FromSlash(path)), path)
+		if err != nil {
+			log.Debug(fmt.Sprintf("Could not diff %s: %s", path, err.Error()))
+			continue
+		}
+		fmt.Print(diff)
+	}
+}
+
+// fileDiff returns a unified diff between two versions of a file, or a one-line note for binary or large files.
+func fileDiff(oldPath, newPath, name string) (string, error) {
+	// #nosec G304 -- oldPath is a file inside the skill install directory
+	oldData, err := os.ReadFile(oldPath)
+	if err != nil {
+		return "", err
+	}
+	// #nosec G304 -- newPath is a file inside our own unzip temp directory
+	newData, err := os.ReadFile(newPath)
+	if err != nil {
+		return "", err
+	}
+	if !isText(oldData) || !isText(newData) {
+		return fmt.Sprintf("Binary file %s differs\n", name), nil
+	}
+	if len(oldData) > maxDiffFileSize || len(newData) > maxDiffFileSize {
+		return fmt.Sprintf("File %s differs (too large to diff)\n", name), nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(oldData)),
+		B:        difflib.SplitLines(string(newData)),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+}
+
+func isText(data []byte) bool {
+	return utf8.Valid(data) && !bytes.Contains(data, []byte{0})
+}
@@ -0,0 +1,134 @@
+package install
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSkillFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// installVersion places a skill version with the given files into destDir, like Install does.
+func installVersion(t *testing.T, destDir, version string, files map[string]string, force bool) error {
+	srcDir := t.TempDir()
+	writeSkillFiles(t, srcDir, files)
+	p, err := planPlacement(common.LockEntry{Slug: "my-skill", Version: version, Repo: "skills-local"}, srcDir, destDir)
+	require.NoError(t, err)
+	if err := p.checkLocalChanges(force); err != nil {
+		return err
+	}
+	return p.apply()
+}
+
+func TestPlacement_FreshInstallWritesManifest(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, installVersion(t, destDir, "1.0.0", map[string]string{"SKILL.md": "v1", "scripts/run.sh": "echo"}, false))
+
+	m, err := common.ReadManifest(destDir)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", m.Version)
+	assert.Equal(t, "skills-local", m.Repo)
+	assert.Len(t, m.Files, 2)
+}
+
+func TestPlacement_UpdateRemovesDroppedFiles(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, installVersion(t, destDir, "1.0.0", map[string]string{"SKILL.md": "v1", "old/legacy.md": "legacy"}, false))
+	// Files added by the user are not part of the skill and must survive updates.
+	writeSkillFiles(t, destDir, map[string]string{"notes.md": "mine"})
+
+	require.NoError(t, installVersion(t, destDir, "1.1.0", map[string]string{"SKILL.md": "v2", "new.md": "new"}, false))
+
+	assert.NoFileExists(t, filepath.Join(destDir, "old", "legacy.md"))
+	assert.NoDirExists(t, filepath.Join(destDir, "old"))
+	assert.FileExists(t, filepath.Join(destDir, "new.md"))
+	assert.FileExists(t, filepath.Join(destDir, "notes.md"))
+	content, err := os.ReadFile(filepath.Join(destDir, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+
+	m, err := common.ReadManifest(destDir)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", m.Version)
+	assert.NotContains(t, m.Files, "notes.md")
+}
+
+func TestPlacement_RefusesToOverwriteLocalChanges(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, installVersion(t, destDir, "1.0.0", map[string]string{"SKILL.md": "v1", "extra.md": "extra"}, false))
+	writeSkillFiles(t, destDir, map[string]string{"SKILL.md": "edited"})
+
+	err := installVersion(t, destDir, "1.1.0", map[string]string{"SKILL.md": "v2"}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local changes that would be overwritten: SKILL.md")
+	assert.Contains(t, err.Error(), "--force")
+	content, err := os.ReadFile(filepath.Join(destDir, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "edited", string(content))
+	assert.FileExists(t, filepath.Join(destDir, "extra.md"))
+
+	require.NoError(t, installVersion(t, destDir, "1.1.0", map[string]string{"SKILL.md": "v2"}, true))
+	content, err = os.ReadFile(filepath.Join(destDir, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+}
+
+func TestPlacement_LocalFileCollidingWithNewVersion(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, installVersion(t, destDir, "1.0.0", map[string]string{"SKILL.md": "v1"}, false))
+	writeSkillFiles(t, destDir, map[string]string{"guide.md": "mine"})
+
+	err := installVersion(t, destDir, "1.1.0", map[string]string{"SKILL.md": "v2", "guide.md": "theirs"}, false)
+	assert.ErrorContains(t, err, "guide.md")
+}
+
+func TestPlacement_PrintDiff(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, installVersion(t, destDir, "1.0.0", map[string]string{"SKILL.md": "# Skill\nold line\n", "gone.md": "x"}, false))
+
+	srcDir := t.TempDir()
+	writeSkillFiles(t, srcDir, map[string]string{"SKILL.md": "# Skill\nnew line\n", "added.md": "y"})
+	p, err := planPlacement(common.LockEntry{Slug: "my-skill", Version: "1.1.0"}, srcDir, destDir)
+	require.NoError(t, err)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	p.printDiff()
+	_ = w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	output := buf.String()
+
+	assert.Contains(t, output, "Skill 'my-skill' (1.0.0 -> 1.1.0):")
+	assert.Contains(t, output, "  A added.md")
+	assert.Contains(t, output, "  M SKILL.md")
+	assert.Contains(t, output, "  D gone.md")
+	assert.Contains(t, output, "--- a/SKILL.md")
+	assert.Contains(t, output, "-old line")
+	assert.Contains(t, output, "+new line")
+}
+
+func TestFileDiff_Binary(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.bin")
+	newPath := filepath.Join(dir, "new.bin")
+	require.NoError(t, os.WriteFile(oldPath, []byte{0, 1, 2}, 0644))
+	require.NoError(t, os.WriteFile(newPath, []byte{0, 1, 3}, 0644))
+
+	diff, err := fileDiff(oldPath, newPath, "data.bin")
+	require.NoError(t, err)
+	assert.Equal(t, "Binary file data.bin differs\n", diff)
+}
@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type lintRow struct {
+	Severity string `col-name:"Severity"`
+	Line     string `col-name:"Line"`
+	Field    string `col-name:"Field"`
+	Message  string `col-name:"Message"`
+}
+
+// LintCommand validates a skill directory locally, the same way `skills publish` does before uploading.
+type LintCommand struct {
+	skillDir string
+	format   string
+}
+
+func NewLintCommand() *LintCommand {
+	return &LintCommand{}
+}
+
+func (lc *LintCommand) SetSkillDir(dir string) *LintCommand {
+	lc.skillDir = dir
+	return lc
+}
+
+func (lc *LintCommand) SetFormat(format string) *LintCommand {
+	lc.format = format
+	return lc
+}
+
+func (lc *LintCommand) CommandName() string {
+	return "skills_lint"
+}
+
+func (lc *LintCommand) Run() error {
+	issues := publish.LintSkill(lc.skillDir)
+	if err := lc.printIssues(issues); err != nil {
+		return err
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == publish.LintSeverityError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("skill lint failed: %d error(s), %d warning(s) in %s", errorCount, len(issues)-errorCount, lc.skillDir)
+	}
+	if !strings.EqualFold(lc.format, "json") {
+		log.Info(fmt.Sprintf("Skill in %s is valid (%d warning(s)).", lc.skillDir, len(issues)))
+	}
+	return nil
+}
+
+func (lc *LintCommand) printIssues(issues []publish.LintIssue) error {
+	if strings.EqualFold(lc.format, "json") {
+		if issues == nil {
+			issues = []publish.LintIssue{}
+		}
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal lint results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	rows := make([]lintRow, len(issues))
+	for i, issue := range issues {
+		rows[i] = lintRow{Severity: issue.Severity, Field: issue.Field, Message: issue.Message}
+		if issue.Line > 0 {
+			rows[i].Line = strconv.Itoa(issue.Line)
+		}
+	}
+	return coreutils.PrintTable(rows, "Lint results", "No issues found", false)
+}
+
+// RunLint is the CLI action for `jf skills lint`.
+func RunLint(c *components.Context) error {
+	if c.GetBoolFlagValue("schema") {
+		fmt.Println(string(publish.SkillSchema()))
+		return nil
+	}
+
+	skillDir := "."
+	if c.GetNumberOfArgs() > 0 {
+		skillDir = c.GetArgumentAt(0)
+	}
+
+	format := "table"
+	if c.GetStringFlagValue("format") != "" {
+		format = c.GetStringFlagValue("format")
+	}
+
+	return NewLintCommand().
+		SetSkillDir(skillDir).
+		SetFormat(format).
+		Run()
+}
@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	runErr := fn()
+
+	_ = w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), runErr
+}
+
+func TestLintCommand_JSON(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := "---\nname: my-skill\ndescription: x\ntags: [Bad Tag]\nx-owner: team-a\n---\nbody\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	output, err := captureStdout(t, NewLintCommand().SetSkillDir(dir).SetFormat("json").Run)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 error(s), 1 warning(s)")
+
+	var issues []publish.LintIssue
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.Len(t, issues, 2)
+	assert.Equal(t, publish.LintSeverityError, issues[0].Severity)
+	assert.Equal(t, "tags[0]", issues[0].Field)
+	assert.Equal(t, 4, issues[0].Line)
+	assert.Equal(t, publish.LintSeverityWarning, issues[1].Severity)
+	assert.Equal(t, "x-owner", issues[1].Field)
+}
+
+func TestLintCommand_ValidSkill(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := "---\nname: my-skill\ndescription: x\nversion: 1.0.0\n---\n# My Skill\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	output, err := captureStdout(t, NewLintCommand().SetSkillDir(dir).SetFormat("json").Run)
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", output)
+
+	output, err = captureStdout(t, NewLintCommand().SetSkillDir(dir).SetFormat("table").Run)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "SEVERITY")
+}
+
+func TestLintCommand_Table(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := "---\ndescription: x\n---\nbody\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	output, err := captureStdout(t, NewLintCommand().SetSkillDir(dir).SetFormat("table").Run)
+	require.Error(t, err)
+	assert.Contains(t, output, "SEVERITY")
+	assert.Contains(t, output, "name")
+	assert.Contains(t, output, "is required")
+}
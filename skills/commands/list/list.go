@@ -0,0 +1,121 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const (
+	statusUnmodified = "unmodified"
+	statusModified   = "modified"
+)
+
+type installedResult struct {
+	Name       string `json:"name" col-name:"Name"`
+	Version    string `json:"version" col-name:"Version"`
+	Repository string `json:"repository" col-name:"Repository"`
+	Evidence   string `json:"evidence" col-name:"Evidence"`
+	SignedBy   string `json:"signedBy,omitempty" col-name:"Signed By"`
+	Target     string `json:"target,omitempty" col-name:"Target"`
+	Status     string `json:"status" col-name:"Status"`
+	Path       string `json:"path" col-name:"Path"`
+	// Changes lists the files edited since installation; it is only part of the JSON output.
+	Changes *common.FileChanges `json:"changes,omitempty"`
+}
+
+// ListCommand lists the skills installed in an install path, based on their install manifests.
+type ListCommand struct {
+	installPath string
+	format      string
+}
+
+func NewListCommand() *ListCommand {
+	return &ListCommand{}
+}
+
+func (lc *ListCommand) SetInstallPath(path string) *ListCommand {
+	lc.installPath = path
+	return lc
+}
+
+func (lc *ListCommand) SetFormat(format string) *ListCommand {
+	lc.format = format
+	return lc
+}
+
+func (lc *ListCommand) CommandName() string {
+	return "skills_list"
+}
+
+func (lc *ListCommand) Run() error {
+	results, err := lc.collect()
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(lc.format, "json") {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return coreutils.PrintTable(results, "Installed skills", "No installed skills found", false)
+}
+
+func (lc *ListCommand) collect() ([]installedResult, error) {
+	installed, err := common.ListInstalledSkills(lc.installPath)
+	if err != nil {
+		return nil, err
+	}
+	lockFile, err := common.LoadLockFile(common.LockFilePath(lc.installPath))
+	if err != nil {
+		return nil, err
+	}
+	targetSkills, err := common.ListTargetSkills(lc.installPath, lockFile)
+	if err != nil {
+		return nil, err
+	}
+	installed = append(installed, targetSkills...)
+	results := make([]installedResult, 0, len(installed))
+	for _, skill := range installed {
+		changes, err := skill.Manifest.LocalChanges(skill.Dir)
+		if err != nil {
+			return nil, err
+		}
+		result := installedResult{
+			Name:       skill.Manifest.Slug,
+			Version:    skill.Manifest.Version,
+			Repository: skill.Manifest.Repo,
+			Evidence:   skill.Manifest.Evidence,
+			SignedBy:   skill.Manifest.SignedBy,
+			Target:     skill.Target,
+			Status:     statusUnmodified,
+			Path:       skill.Dir,
+		}
+		if !changes.IsEmpty() {
+			result.Status = statusModified
+			result.Changes = &changes
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// RunList is the CLI action for `jf skills list`.
+func RunList(c *components.Context) error {
+	format := "table"
+	if c.GetStringFlagValue("format") != "" {
+		format = c.GetStringFlagValue("format")
+	}
+
+	return NewListCommand().
+		SetInstallPath(c.GetStringFlagValue("path")).
+		SetFormat(format).
+		Run()
+}
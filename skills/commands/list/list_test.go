@@ -0,0 +1,72 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func installSkill(t *testing.T, base, slug, evidence string) string {
+	dir := filepath.Join(base, slug)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(slug), 0644))
+	files, err := common.HashSkillFiles(dir)
+	require.NoError(t, err)
+	entry := common.LockEntry{Slug: slug, Version: "1.0.0", Repo: "skills-local", Evidence: evidence}
+	require.NoError(t, common.NewInstallManifest(entry, files).Save(dir))
+	return dir
+}
+
+func TestCollect(t *testing.T) {
+	base := t.TempDir()
+	installSkill(t, base, "beta", common.EvidenceStatusUnverified)
+	alphaDir := installSkill(t, base, "alpha", common.EvidenceStatusVerified)
+	require.NoError(t, os.WriteFile(filepath.Join(alphaDir, "SKILL.md"), []byte("edited"), 0644))
+
+	results, err := NewListCommand().SetInstallPath(base).collect()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "alpha", results[0].Name)
+	assert.Equal(t, "1.0.0", results[0].Version)
+	assert.Equal(t, "skills-local", results[0].Repository)
+	assert.Equal(t, common.EvidenceStatusVerified, results[0].Evidence)
+	assert.Equal(t, statusModified, results[0].Status)
+	require.NotNil(t, results[0].Changes)
+	assert.Equal(t, []string{"SKILL.md"}, results[0].Changes.Modified)
+
+	assert.Equal(t, "beta", results[1].Name)
+	assert.Equal(t, common.EvidenceStatusUnverified, results[1].Evidence)
+	assert.Equal(t, statusUnmodified, results[1].Status)
+	assert.Nil(t, results[1].Changes)
+}
+
+func TestCollect_Empty(t *testing.T) {
+	results, err := NewListCommand().SetInstallPath(t.TempDir()).collect()
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestCollect_Targets(t *testing.T) {
+	project := t.TempDir()
+	dir := installSkill(t, filepath.Join(project, ".cursor", "skills"), "gamma", common.EvidenceStatusVerified)
+	manifest, err := common.ReadManifest(dir)
+	require.NoError(t, err)
+	manifest.Target = "cursor"
+	require.NoError(t, manifest.Save(dir))
+
+	lockFile := &common.LockFile{}
+	lockFile.Upsert(common.LockEntry{Slug: "gamma", Version: "1.0.0", Targets: []string{"cursor"}})
+	require.NoError(t, lockFile.Save(common.LockFilePath(project)))
+
+	results, err := NewListCommand().SetInstallPath(project).collect()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "gamma", results[0].Name)
+	assert.Equal(t, "cursor", results[0].Target)
+	assert.Equal(t, statusUnmodified, results[0].Status)
+}
@@ -0,0 +1,184 @@
+package pack
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PackCommand builds an offline skill bundle: the same deterministic zip that `skills publish`
+// uploads, plus a sidecar metadata file pinning its SHA-256 and, when a signing key is
+// configured, a signed publish attestation. Bundles are installed with `skills install --from-file`.
+type PackCommand struct {
+	skillDir   string
+	version    string
+	outputDir  string
+	signingKey string
+	keyAlias   string
+}
+
+func NewPackCommand() *PackCommand {
+	return &PackCommand{}
+}
+
+func (pc *PackCommand) SetSkillDir(dir string) *PackCommand {
+	pc.skillDir = dir
+	return pc
+}
+
+func (pc *PackCommand) SetVersion(version string) *PackCommand {
+	pc.version = version
+	return pc
+}
+
+func (pc *PackCommand) SetOutputDir(dir string) *PackCommand {
+	pc.outputDir = dir
+	return pc
+}
+
+func (pc *PackCommand) SetSigningKey(path string) *PackCommand {
+	pc.signingKey = path
+	return pc
+}
+
+func (pc *PackCommand) SetKeyAlias(alias string) *PackCommand {
+	pc.keyAlias = alias
+	return pc
+}
+
+func (pc *PackCommand) CommandName() string {
+	return "skills_pack"
+}
+
+func (pc *PackCommand) Run() error {
+	_, err := pc.Pack()
+	return err
+}
+
+// Pack writes the bundle zip and its sidecar into the output directory and returns the zip path.
+func (pc *PackCommand) Pack() (string, error) {
+	if err := publish.CheckLint(pc.skillDir); err != nil {
+		return "", err
+	}
+	meta, err := publish.ParseSkillMeta(pc.skillDir)
+	if err != nil {
+		return "", err
+	}
+
+	version := pc.version
+	if version == "" {
+		version = meta.Version
+	}
+	if version == "" {
+		return "", fmt.Errorf("no version specified: set 'version' in SKILL.md or use --version")
+	}
+	if err := publish.ValidateVersion(version); err != nil {
+		return "", err
+	}
+	if _, err := common.ParseVersion(version); err != nil && version != meta.Version {
+		log.Warn(fmt.Sprintf("Version '%s' is not a valid semantic version; it will not match version ranges on install.", version))
+	}
+
+	outputDir, err := pc.resolveOutputDir()
+	if err != nil {
+		return "", err
+	}
+
+	if meta.Version != "" && meta.Version != version {
+		if err := publish.UpdateSkillMetaVersion(pc.skillDir, version); err != nil {
+			return "", fmt.Errorf("failed to update SKILL.md version: %w", err)
+		}
+		log.Info(fmt.Sprintf("Updated SKILL.md version from '%s' to '%s'", meta.Version, version))
+	}
+
+	fileName := fmt.Sprintf("%s-%s.zip", meta.Name, version)
+	zipPath := filepath.Join(outputDir, fileName)
+	if err := publish.WriteSkillZip(pc.skillDir, zipPath); err != nil {
+		_ = os.Remove(zipPath)
+		return "", err
+	}
+	sha256Hex, err := common.ComputeSHA256(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute SHA256: %w", err)
+	}
+
+	metadata := &common.BundleMetadata{
+		Slug:        meta.Name,
+		Version:     version,
+		Description: meta.Description,
+		FileName:    fileName,
+		SHA256:      sha256Hex,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	keyPath, alias := publish.ResolveSigningKey(pc.signingKey, pc.keyAlias)
+	if keyPath != "" {
+		if metadata.Attestation, err = publish.SignSkillAttestation(meta.Name, version, fileName, sha256Hex, keyPath, alias); err != nil {
+			return "", fmt.Errorf("failed to sign bundle: %w", err)
+		}
+	} else {
+		log.Info("No signing key configured. Provide --signing-key flag or set EVD_SIGNING_KEY_PATH env var to sign the bundle.")
+	}
+	if err := metadata.Save(zipPath); err != nil {
+		return "", err
+	}
+
+	log.Info(fmt.Sprintf("Packed skill '%s' version '%s' to %s (sha256: %s)", meta.Name, version, zipPath, sha256Hex))
+	return zipPath, nil
+}
+
+// resolveOutputDir returns the absolute output directory, creating it if needed.
+// It must be outside the skill directory, otherwise the next pack would include the bundle itself.
+func (pc *PackCommand) resolveOutputDir() (string, error) {
+	outputDir := pc.outputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	absOutput, err := filepath.Abs(outputDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid output directory: %w", err)
+	}
+	absSkill, err := filepath.Abs(pc.skillDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid skill path: %w", err)
+	}
+	if absOutput == absSkill || strings.HasPrefix(absOutput, absSkill+string(os.PathSeparator)) {
+		return "", fmt.Errorf("output directory %s is inside the skill folder; use --output to write the bundle elsewhere", absOutput)
+	}
+	// #nosec G301 -- bundles are meant to be shared
+	if err := os.MkdirAll(absOutput, 0750); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return absOutput, nil
+}
+
+// RunPack is the CLI action for `jf skills pack`.
+func RunPack(c *components.Context) error {
+	if c.GetNumberOfArgs() < 1 {
+		return fmt.Errorf("usage: jf skills pack <path-to-skill-folder> [--output <dir>] [options]")
+	}
+
+	skillDir := c.GetArgumentAt(0)
+	absDir, err := filepath.Abs(skillDir)
+	if err != nil {
+		return fmt.Errorf("invalid skill path: %w", err)
+	}
+	info, err := os.Stat(absDir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("skill path '%s' is not a valid directory", skillDir)
+	}
+
+	return NewPackCommand().
+		SetSkillDir(absDir).
+		SetVersion(c.GetStringFlagValue("version")).
+		SetOutputDir(c.GetStringFlagValue("output")).
+		SetSigningKey(c.GetStringFlagValue("signing-key")).
+		SetKeyAlias(c.GetStringFlagValue("key-alias")).
+		Run()
+}
@@ -0,0 +1,115 @@
+package pack
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSkill(t *testing.T, version string) string {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "scripts"), 0755))
+	skillMD := "---\nname: my-skill\ndescription: A packed skill\nversion: " + version + "\n---\n\n# My Skill\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("echo hi\n"), 0755))
+	mtime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, f := range []string{"SKILL.md", "scripts/run.sh"} {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, f), mtime, mtime))
+	}
+	return dir
+}
+
+func TestPack_WritesZipAndMetadata(t *testing.T) {
+	t.Setenv("EVD_SIGNING_KEY_PATH", "")
+	t.Setenv("JFROG_CLI_SIGNING_KEY", "")
+	skillDir := createSkill(t, "1.2.0")
+	outputDir := t.TempDir()
+
+	zipPath, err := NewPackCommand().SetSkillDir(skillDir).SetOutputDir(outputDir).Pack()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, "my-skill-1.2.0.zip"), zipPath)
+
+	metadata, err := common.ReadBundleMetadata(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, "my-skill", metadata.Slug)
+	assert.Equal(t, "1.2.0", metadata.Version)
+	assert.Equal(t, "A packed skill", metadata.Description)
+	assert.Equal(t, "my-skill-1.2.0.zip", metadata.FileName)
+	assert.Nil(t, metadata.Attestation)
+
+	sha, err := common.ComputeSHA256(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, sha, metadata.SHA256)
+
+	// Packing the same content again produces a byte-identical bundle.
+	otherOutput := t.TempDir()
+	zipPath2, err := NewPackCommand().SetSkillDir(skillDir).SetOutputDir(otherOutput).Pack()
+	require.NoError(t, err)
+	sha2, err := common.ComputeSHA256(zipPath2)
+	require.NoError(t, err)
+	assert.Equal(t, sha, sha2)
+}
+
+func TestPack_Signed(t *testing.T) {
+	skillDir := createSkill(t, "1.0.0")
+	keyDir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	privPath := filepath.Join(keyDir, "signing.key")
+	pubPath := filepath.Join(keyDir, "signing.pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+
+	zipPath, err := NewPackCommand().SetSkillDir(skillDir).SetOutputDir(t.TempDir()).SetSigningKey(privPath).SetKeyAlias("release").Pack()
+	require.NoError(t, err)
+
+	metadata, err := common.ReadBundleMetadata(zipPath)
+	require.NoError(t, err)
+	require.NotNil(t, metadata.Attestation)
+	assert.Equal(t, "release", metadata.Attestation.Signatures[0].KeyID)
+	assert.NoError(t, metadata.VerifyAttestation(pubPath))
+}
+
+func TestPack_VersionOverrideUpdatesSkillMD(t *testing.T) {
+	skillDir := createSkill(t, "1.0.0")
+
+	zipPath, err := NewPackCommand().SetSkillDir(skillDir).SetVersion("2.0.0").SetOutputDir(t.TempDir()).Pack()
+	require.NoError(t, err)
+	assert.Equal(t, "my-skill-2.0.0.zip", filepath.Base(zipPath))
+
+	content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "version: 2.0.0")
+}
+
+func TestPack_Errors(t *testing.T) {
+	skillDir := createSkill(t, "1.0.0")
+	_, err := NewPackCommand().SetSkillDir(skillDir).SetOutputDir(filepath.Join(skillDir, "dist")).Pack()
+	assert.ErrorContains(t, err, "inside the skill folder")
+
+	noVersion := filepath.Join(t.TempDir(), "no-version")
+	require.NoError(t, os.MkdirAll(noVersion, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(noVersion, "SKILL.md"), []byte("---\nname: no-version\ndescription: x\n---\nbody\n"), 0644))
+	_, err = NewPackCommand().SetSkillDir(noVersion).SetOutputDir(t.TempDir()).Pack()
+	assert.ErrorContains(t, err, "no version specified")
+
+	invalid := filepath.Join(t.TempDir(), "invalid")
+	require.NoError(t, os.MkdirAll(invalid, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(invalid, "SKILL.md"), []byte("---\ndescription: x\n---\nbody\n"), 0644))
+	_, err = NewPackCommand().SetSkillDir(invalid).SetOutputDir(t.TempDir()).Pack()
+	assert.ErrorContains(t, err, "skill validation failed")
+}
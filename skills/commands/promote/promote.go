@@ -0,0 +1,328 @@
+package promote
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/publish"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PromoteCommand copies a published skill version from one skills repository to another,
+// e.g. from a dev repository to a curated prod repository.
+type PromoteCommand struct {
+	serverDetails       *config.ServerDetails
+	slug                string
+	version             string
+	fromRepo            string
+	toRepo              string
+	signingKey          string
+	keyAlias            string
+	deleteSource        bool
+	dryRun              bool
+	quiet               bool
+	skipScan            bool
+	autoDeleteOnFailure bool
+	failOn              []string
+	scanReport          string
+	scanReportFormat    string
+}
+
+func NewPromoteCommand() *PromoteCommand {
+	return &PromoteCommand{}
+}
+
+func (pc *PromoteCommand) SetServerDetails(details *config.ServerDetails) *PromoteCommand {
+	pc.serverDetails = details
+	return pc
+}
+
+func (pc *PromoteCommand) SetSlug(slug string) *PromoteCommand {
+	pc.slug = slug
+	return pc
+}
+
+func (pc *PromoteCommand) SetVersion(version string) *PromoteCommand {
+	pc.version = version
+	return pc
+}
+
+func (pc *PromoteCommand) SetFromRepo(repoKey string) *PromoteCommand {
+	pc.fromRepo = repoKey
+	return pc
+}
+
+func (pc *PromoteCommand) SetToRepo(repoKey string) *PromoteCommand {
+	pc.toRepo = repoKey
+	return pc
+}
+
+func (pc *PromoteCommand) SetSigningKey(path string) *PromoteCommand {
+	pc.signingKey = path
+	return pc
+}
+
+func (pc *PromoteCommand) SetKeyAlias(alias string) *PromoteCommand {
+	pc.keyAlias = alias
+	return pc
+}
+
+func (pc *PromoteCommand) SetDeleteSource(deleteSource bool) *PromoteCommand {
+	pc.deleteSource = deleteSource
+	return pc
+}
+
+func (pc *PromoteCommand) SetDryRun(dryRun bool) *PromoteCommand {
+	pc.dryRun = dryRun
+	return pc
+}
+
+func (pc *PromoteCommand) SetQuiet(quiet bool) *PromoteCommand {
+	pc.quiet = quiet
+	return pc
+}
+
+func (pc *PromoteCommand) SetSkipScan(skip bool) *PromoteCommand {
+	pc.skipScan = skip
+	return pc
+}
+
+func (pc *PromoteCommand) SetAutoDeleteOnFailure(autoDelete bool) *PromoteCommand {
+	pc.autoDeleteOnFailure = autoDelete
+	return pc
+}
+
+func (pc *PromoteCommand) SetFailOn(failOn []string) *PromoteCommand {
+	pc.failOn = failOn
+	return pc
+}
+
+func (pc *PromoteCommand) SetScanReport(path, format string) *PromoteCommand {
+	pc.scanReport = path
+	pc.scanReportFormat = format
+	return pc
+}
+
+func (pc *PromoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+func (pc *PromoteCommand) CommandName() string {
+	return "skills_promote"
+}
+
+func (pc *PromoteCommand) Run() error {
+	if err := pc.validate(); err != nil {
+		return err
+	}
+	if pc.serverDetails != nil {
+		if err := pc.checkVersions(); err != nil {
+			return err
+		}
+	}
+
+	if pc.dryRun {
+		msg := fmt.Sprintf("[DRY RUN] Would promote skill '%s' v%s from '%s' to '%s'", pc.slug, pc.version, pc.fromRepo, pc.toRepo)
+		if pc.deleteSource {
+			msg += fmt.Sprintf(" and delete it from '%s'", pc.fromRepo)
+		}
+		log.Info(msg)
+		return nil
+	}
+
+	sm, err := utils.CreateServiceManager(pc.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	evidenceClients, err := common.NewEvidenceClients(pc.serverDetails, sm)
+	if err != nil {
+		log.Warn("The evidence of the skill will not be copied:", err.Error())
+	}
+	return pc.promote(sm, evidenceClients)
+}
+
+// promote copies the skill version, runs the Xray gate in the target repository and copies the evidence
+// of the source zip to the promoted one. The evidence is not copied when evidenceClients is nil.
+func (pc *PromoteCommand) promote(sm artifactory.ArtifactoryServicesManager, evidenceClients *common.EvidenceClients) error {
+	log.Info(fmt.Sprintf("Promoting skill '%s' v%s from '%s' to '%s'...", pc.slug, pc.version, pc.fromRepo, pc.toRepo))
+	if err := common.CopySkillVersion(sm, pc.fromRepo, pc.toRepo, pc.slug, pc.version); err != nil {
+		return err
+	}
+
+	// The target repository may have its own Xray policy, so the gate runs again there.
+	artifactPath := fmt.Sprintf("%s/%s/%s-%s.zip", pc.slug, pc.version, pc.slug, pc.version)
+	if err := common.CheckXrayGate(common.XrayGateParams{
+		ServerDetails:       pc.serverDetails,
+		ServicesManager:     sm,
+		RepoKey:             pc.toRepo,
+		ArtifactPath:        artifactPath,
+		Slug:                pc.slug,
+		Version:             pc.version,
+		SkipScan:            pc.skipScan,
+		AutoDeleteOnFailure: pc.autoDeleteOnFailure,
+		Quiet:               pc.quiet,
+		FailOn:              pc.failOn,
+		ReportPath:          pc.scanReport,
+		ReportFormat:        pc.scanReportFormat,
+	}); err != nil {
+		return err
+	}
+
+	if evidenceClients != nil {
+		pc.copyEvidence(evidenceClients, artifactPath)
+	}
+	pc.attachEvidence(artifactPath)
+
+	if pc.deleteSource {
+		if err := common.DeleteSkillVersion(pc.serverDetails, pc.fromRepo, pc.slug, pc.version); err != nil {
+			return fmt.Errorf("skill was promoted to '%s' but could not be deleted from '%s': %w", pc.toRepo, pc.fromRepo, err)
+		}
+		log.Info(fmt.Sprintf("Skill '%s' v%s deleted from '%s'.", pc.slug, pc.version, pc.fromRepo))
+	}
+
+	log.Info(fmt.Sprintf("Skill '%s' v%s promoted from '%s' to '%s'.", pc.slug, pc.version, pc.fromRepo, pc.toRepo))
+	return nil
+}
+
+func (pc *PromoteCommand) validate() error {
+	if pc.version == "" {
+		return fmt.Errorf("a version is required for promote")
+	}
+	if pc.fromRepo == "" || pc.toRepo == "" {
+		return fmt.Errorf("both --from and --to repositories are required for promote")
+	}
+	if pc.fromRepo == pc.toRepo {
+		return fmt.Errorf("cannot promote skill '%s' v%s: --from and --to are the same repository '%s'", pc.slug, pc.version, pc.fromRepo)
+	}
+	if err := publish.ValidateSlug(pc.slug); err != nil {
+		return err
+	}
+	return publish.ValidateVersion(pc.version)
+}
+
+// checkVersions makes sure the version exists in the source repository and not yet in the target one.
+func (pc *PromoteCommand) checkVersions() error {
+	exists, err := common.VersionExists(pc.serverDetails, pc.fromRepo, pc.slug, pc.version)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return fmt.Errorf("repository '%s' or skill '%s' not found", pc.fromRepo, pc.slug)
+		}
+		return fmt.Errorf("failed to verify skill existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("skill '%s' v%s not found in repository '%s'", pc.slug, pc.version, pc.fromRepo)
+	}
+
+	exists, err = common.VersionExists(pc.serverDetails, pc.toRepo, pc.slug, pc.version)
+	if err != nil && !strings.Contains(err.Error(), "404 Not Found") {
+		return fmt.Errorf("failed to check repository '%s': %w", pc.toRepo, err)
+	}
+	if exists {
+		return fmt.Errorf("skill '%s' v%s already exists in repository '%s'", pc.slug, pc.version, pc.toRepo)
+	}
+	return nil
+}
+
+// copyEvidence attaches the evidence of the source zip, such as its publish attestation, to the promoted zip,
+// so that installs from the target repository can verify it.
+func (pc *PromoteCommand) copyEvidence(evidenceClients *common.EvidenceClients, artifactPath string) {
+	copied, err := evidenceClients.CopyEvidence(pc.fromRepo+"/"+artifactPath, pc.toRepo+"/"+artifactPath)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Not all evidence of skill '%s' v%s was copied to '%s': %s", pc.slug, pc.version, pc.toRepo, err.Error()))
+	}
+	if copied > 0 {
+		log.Info(fmt.Sprintf("Copied %d evidence of skill '%s' v%s to '%s'.", copied, pc.slug, pc.version, pc.toRepo))
+	}
+}
+
+// attachEvidence attaches a promotion attestation to the promoted zip when a signing key is configured.
+func (pc *PromoteCommand) attachEvidence(artifactPath string) {
+	keyPath, alias := publish.ResolveSigningKey(pc.signingKey, pc.keyAlias)
+	if keyPath == "" {
+		log.Info("No signing key configured. Provide --signing-key flag or set EVD_SIGNING_KEY_PATH env var. Skipping promotion evidence.")
+		return
+	}
+
+	subjectRepoPath := fmt.Sprintf("%s/%s", pc.toRepo, artifactPath)
+	sha256Hex, err := common.GetFileSHA256(pc.serverDetails, subjectRepoPath)
+	if err != nil {
+		log.Warn("Promotion evidence not attached:", err.Error())
+		return
+	}
+
+	tmpDir, err := os.MkdirTemp("", "skill-evidence-*")
+	if err != nil {
+		log.Warn("Failed to create temp dir for evidence:", err.Error())
+		return
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	predicatePath, err := publish.GeneratePromotionPredicateFile(tmpDir, pc.slug, pc.version, pc.fromRepo, pc.toRepo, sha256Hex)
+	if err != nil {
+		log.Warn("Failed to generate predicate:", err.Error())
+		return
+	}
+	markdownPath, err := publish.GeneratePromotionMarkdownFile(tmpDir, pc.slug, pc.version, pc.fromRepo, pc.toRepo)
+	if err != nil {
+		log.Warn("Failed to generate attestation markdown:", err.Error())
+		return
+	}
+
+	publish.AttachEvidence(pc.serverDetails, evidenceutils.CreateEvidenceOpts{
+		SubjectRepoPath: subjectRepoPath,
+		SubjectSHA256:   sha256Hex,
+		PredicatePath:   predicatePath,
+		PredicateType:   publish.PredicateTypePromotionAttestation,
+		MarkdownPath:    markdownPath,
+		KeyPath:         keyPath,
+		KeyAlias:        alias,
+	}, "promotion")
+}
+
+// RunPromote is the CLI action for `jf skills promote`.
+func RunPromote(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return fmt.Errorf("usage: jf skills promote <slug> <version> --from <repo> --to <repo> [options]")
+	}
+
+	failOn, err := common.ParseXrayGateFailOn(c.GetStringFlagValue("fail-on"))
+	if err != nil {
+		return err
+	}
+	scanReportFormat := c.GetStringFlagValue("scan-report-format")
+	if err := common.ValidateXrayReportFormat(scanReportFormat); err != nil {
+		return err
+	}
+
+	serverDetails, err := common.GetServerDetails(c)
+	if err != nil {
+		return err
+	}
+
+	cmd := NewPromoteCommand().
+		SetServerDetails(serverDetails).
+		SetSlug(c.GetArgumentAt(0)).
+		SetVersion(c.GetArgumentAt(1)).
+		SetFromRepo(c.GetStringFlagValue("from")).
+		SetToRepo(c.GetStringFlagValue("to")).
+		SetSigningKey(c.GetStringFlagValue("signing-key")).
+		SetKeyAlias(c.GetStringFlagValue("key-alias")).
+		SetDeleteSource(c.GetBoolFlagValue("delete-source")).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).
+		SetQuiet(common.IsQuiet(c)).
+		SetSkipScan(c.GetBoolFlagValue("skip-scan")).
+		SetAutoDeleteOnFailure(c.GetBoolFlagValue("auto-delete-on-failure")).
+		SetFailOn(failOn).
+		SetScanReport(c.GetStringFlagValue("scan-report"), scanReportFormat)
+
+	return cmd.Run()
+}
@@ -0,0 +1,114 @@
+package promote
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	evidenceservices "github.com/jfrog/jfrog-client-go/evidence/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// promoteServicesManagerMock copies skill versions, answers the Xray gate with xrayStatus and serves the evidence files.
+type promoteServicesManagerMock struct {
+	artifactory.EmptyArtifactoryServicesManager
+	xrayStatus string
+	files      map[string]string
+	copied     []services.MoveCopyParams
+	gateRepo   string
+}
+
+func (m *promoteServicesManagerMock) Copy(params ...services.MoveCopyParams) (int, int, error) {
+	m.copied = append(m.copied, params...)
+	return 2, 0, nil
+}
+
+func (m *promoteServicesManagerMock) GetSkillXrayStatus(repoKey, artifactPath string) (*services.SkillXrayStatusResponse, error) {
+	m.gateRepo = repoKey
+	return &services.SkillXrayStatusResponse{Status: m.xrayStatus, RepoKey: repoKey, Path: artifactPath}, nil
+}
+
+func (m *promoteServicesManagerMock) ReadRemoteFile(readPath string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(m.files[readPath])), nil
+}
+
+type onemodelMock struct {
+	response string
+	query    string
+}
+
+func (m *onemodelMock) GraphqlQuery(query []byte) ([]byte, error) {
+	m.query = string(query)
+	return []byte(m.response), nil
+}
+
+type evidenceUploaderMock struct {
+	uploaded []evidenceservices.EvidenceDetails
+}
+
+func (m *evidenceUploaderMock) UploadEvidence(evidenceDetails evidenceservices.EvidenceDetails) ([]byte, error) {
+	m.uploaded = append(m.uploaded, evidenceDetails)
+	return []byte(`{}`), nil
+}
+
+func TestPromoteCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *PromoteCommand
+		wantErr string
+	}{
+		{"missing version", NewPromoteCommand().SetSlug("test-skill").SetFromRepo("dev").SetToRepo("prod"), "a version is required"},
+		{"missing target", NewPromoteCommand().SetSlug("test-skill").SetVersion("1.0.0").SetFromRepo("dev"), "both --from and --to"},
+		{"same repository", NewPromoteCommand().SetSlug("test-skill").SetVersion("1.0.0").SetFromRepo("dev").SetToRepo("dev"), "are the same repository 'dev'"},
+		{"invalid slug", NewPromoteCommand().SetSlug("../escape").SetVersion("1.0.0").SetFromRepo("dev").SetToRepo("prod"), "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.cmd.SetDryRun(true).Run(), tt.wantErr)
+		})
+	}
+}
+
+func TestPromoteCommand_DryRun(t *testing.T) {
+	cmd := NewPromoteCommand().
+		SetSlug("test-skill").
+		SetVersion("1.0.0").
+		SetFromRepo("skills-dev").
+		SetToRepo("skills-prod").
+		SetDeleteSource(true).
+		SetDryRun(true)
+	// Dry run should succeed without actually calling any server.
+	assert.NoError(t, cmd.Run())
+}
+
+func TestPromoteCommand_Promote(t *testing.T) {
+	t.Setenv("EVD_SIGNING_KEY_PATH", "")
+	const envelope = `{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[{"sig":"c2ln"}]}`
+	sm := &promoteServicesManagerMock{
+		xrayStatus: services.SkillXrayStatusApproved,
+		files:      map[string]string{"evidence-local/skills-dev/publish.json": envelope},
+	}
+	onemodel := &onemodelMock{response: `{"data":{"evidence":{"searchEvidence":{"edges":[{"node":{"downloadPath":"evidence-local/skills-dev/publish.json","predicateType":"https://jfrog.com/evidence/publish-attestation/v1"}}]}}}}`}
+	uploader := &evidenceUploaderMock{}
+	evidenceClients := &common.EvidenceClients{Artifactory: sm, Onemodel: onemodel, Evidence: uploader}
+	cmd := NewPromoteCommand().SetSlug("my-skill").SetVersion("1.0.0").SetFromRepo("skills-dev").SetToRepo("skills-prod").SetQuiet(true)
+
+	require.NoError(t, cmd.promote(sm, evidenceClients))
+	require.Len(t, sm.copied, 1)
+	assert.Equal(t, "skills-dev/my-skill/1.0.0/*", sm.copied[0].Pattern)
+	assert.Equal(t, "skills-prod/my-skill/1.0.0/", sm.copied[0].Target)
+	assert.Equal(t, "skills-prod", sm.gateRepo)
+	// The evidence of the source zip is attached, as signed, to the promoted zip.
+	assert.Contains(t, onemodel.query, `repositoryKey: \"skills-dev\", path: \"my-skill/1.0.0\", name: \"my-skill-1.0.0.zip\"`)
+	assert.Equal(t, []evidenceservices.EvidenceDetails{{SubjectUri: "skills-prod/my-skill/1.0.0/my-skill-1.0.0.zip", DSSEFileRaw: []byte(envelope)}}, uploader.uploaded)
+
+	// A skill blocked in the target repository fails the promotion, and its evidence is not copied.
+	sm.xrayStatus = services.SkillXrayStatusBlocked
+	uploader.uploaded = nil
+	assert.ErrorContains(t, cmd.promote(sm, evidenceClients), "was blocked by Xray security scan")
+	assert.Empty(t, uploader.uploaded)
+}
@@ -6,9 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
 )
 
-const predicateTypePublishAttestation = "https://jfrog.com/evidence/publish-attestation/v1"
+const (
+	predicateTypePublishAttestation = "https://jfrog.com/evidence/publish-attestation/v1"
+	// PredicateTypePromotionAttestation is the predicate type of the evidence attached by 'jf skills promote'.
+	PredicateTypePromotionAttestation = "https://jfrog.com/evidence/skill-promotion/v1"
+)
 
 type predicate struct {
 	Skill       string `json:"skill"`
@@ -16,8 +23,7 @@ type predicate struct {
 	PublishedAt string `json:"publishedAt"`
 }
 
-// GeneratePredicateFile writes the canonical predicate.json to a temp directory.
-func GeneratePredicateFile(dir, slug, version string) (string, error) {
+func marshalPredicate(slug, version string) ([]byte, error) {
 	p := predicate{
 		Skill:       slug,
 		Version:     version,
@@ -26,7 +32,16 @@ func GeneratePredicateFile(dir, slug, version string) (string, error) {
 
 	data, err := json.Marshal(p)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal predicate: %w", err)
+		return nil, fmt.Errorf("failed to marshal predicate: %w", err)
+	}
+	return data, nil
+}
+
+// GeneratePredicateFile writes the canonical predicate.json to a temp directory.
+func GeneratePredicateFile(dir, slug, version string) (string, error) {
+	data, err := marshalPredicate(slug, version)
+	if err != nil {
+		return "", err
 	}
 
 	path := filepath.Join(dir, "predicate.json")
@@ -54,3 +69,70 @@ func GenerateMarkdownFile(dir, slug, version string) (string, error) {
 	}
 	return path, nil
 }
+
+type promotionPredicate struct {
+	Skill      string `json:"skill"`
+	Version    string `json:"version"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	SHA256     string `json:"sha256"`
+	PromotedAt string `json:"promotedAt"`
+}
+
+// GeneratePromotionPredicateFile writes the predicate.json of a promotion to a temp directory.
+// It records the source repository and the digest, so the promoted zip can be traced back to its publish evidence.
+func GeneratePromotionPredicateFile(dir, slug, version, from, to, sha256Hex string) (string, error) {
+	p := promotionPredicate{
+		Skill:      slug,
+		Version:    version,
+		From:       from,
+		To:         to,
+		SHA256:     sha256Hex,
+		PromotedAt: time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal predicate: %w", err)
+	}
+
+	path := filepath.Join(dir, "predicate.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write predicate file: %w", err)
+	}
+	return path, nil
+}
+
+// GeneratePromotionMarkdownFile writes the attestation.md of a promotion to a temp directory.
+func GeneratePromotionMarkdownFile(dir, slug, version, from, to string) (string, error) {
+	promotedAt := time.Now().UTC().Format("2006-01-02T15:04:05Z")
+	md := fmt.Sprintf(`# Promotion Attestation
+
+| Field | Value |
+|-------|-------|
+| Skill | %s |
+| Version | %s |
+| From | %s |
+| To | %s |
+| Promoted at | %s |
+`, slug, version, from, to, promotedAt)
+
+	path := filepath.Join(dir, "attestation.md")
+	if err := os.WriteFile(path, []byte(md), 0600); err != nil {
+		return "", fmt.Errorf("failed to write attestation markdown: %w", err)
+	}
+	return path, nil
+}
+
+// SignSkillAttestation signs the publish attestation of a skill zip locally, producing the same
+// predicate that is attached as evidence. It is used for offline bundles and detached signatures.
+func SignSkillAttestation(slug, version, fileName, sha256Hex, keyPath, keyAlias string) (*dsse.Envelope, error) {
+	predicate, err := marshalPredicate(slug, version)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := common.NewInTotoStatement(fileName, sha256Hex, predicateTypePublishAttestation, predicate)
+	if err != nil {
+		return nil, err
+	}
+	return dsse.SignEnvelope(dsse.InTotoPayloadType, statement, keyPath, keyAlias)
+}
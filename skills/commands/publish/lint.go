@@ -0,0 +1,123 @@
+package publish
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+)
+
+// LintIssue is a problem found while validating a skill directory.
+type LintIssue struct {
+	Severity string `json:"severity"`
+	// Line is the SKILL.md line the issue refers to, or 0 when it applies to the whole file.
+	Line    int    `json:"line,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	location := "SKILL.md"
+	if i.Line > 0 {
+		location = fmt.Sprintf("SKILL.md:%d", i.Line)
+	}
+	if i.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", location, i.Field, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, i.Message)
+}
+
+// LintSkill validates a skill directory locally, without contacting Artifactory:
+// the SKILL.md frontmatter must be valid YAML matching skill.schema.json, and
+// declared dependencies must use valid version ranges.
+func LintSkill(skillDir string) []LintIssue {
+	doc, err := readSkillDocument(skillDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []LintIssue{{Severity: LintSeverityError, Message: "SKILL.md not found in " + skillDir}}
+		}
+		return []LintIssue{{Severity: LintSeverityError, Message: err.Error()}}
+	}
+
+	var issues []LintIssue
+	for _, v := range doc.validateFrontmatter() {
+		issues = append(issues, LintIssue{Severity: LintSeverityError, Line: v.line, Field: v.field, Message: v.message})
+	}
+	if doc.root != nil {
+		for i := 0; i+1 < len(doc.root.Content); i += 2 {
+			key := doc.root.Content[i]
+			if !knownField(key.Value) {
+				issues = append(issues, LintIssue{
+					Severity: LintSeverityWarning,
+					Line:     doc.line(key),
+					Field:    key.Value,
+					Message:  "unknown field; it is kept in SKILL.md but ignored by the skills tooling",
+				})
+			}
+		}
+	}
+	if HasLintErrors(issues) {
+		return issues
+	}
+
+	meta, err := doc.meta()
+	if err != nil {
+		return append(issues, LintIssue{Severity: LintSeverityError, Message: err.Error()})
+	}
+	issues = append(issues, lintMeta(doc, meta)...)
+
+	if strings.TrimSpace(doc.body()) == "" {
+		issues = append(issues, LintIssue{Severity: LintSeverityWarning, Message: "SKILL.md has no instructions after the frontmatter"})
+	}
+	return issues
+}
+
+func lintMeta(doc *skillDocument, meta *SkillMeta) []LintIssue {
+	var issues []LintIssue
+	issue := func(severity, field, message string) {
+		line := 0
+		if key, _ := doc.field(field); key != nil {
+			line = doc.line(key)
+		}
+		issues = append(issues, LintIssue{Severity: severity, Line: line, Field: field, Message: message})
+	}
+
+	if meta.Version != "" {
+		if err := ValidateVersion(meta.Version); err != nil {
+			issue(LintSeverityError, "version", err.Error())
+		} else if _, err := common.ParseVersion(meta.Version); err != nil {
+			issue(LintSeverityWarning, "version", fmt.Sprintf("'%s' is not a valid semantic version; it will not match version ranges on install", meta.Version))
+		}
+	}
+	if meta.Description == "" {
+		issue(LintSeverityWarning, "description", "is recommended; it is shown in search results and helps agents decide when to use the skill")
+	}
+	if meta.Compatibility.JFrogCLI != "" {
+		if _, err := common.ParseConstraint(meta.Compatibility.JFrogCLI); err != nil {
+			issue(LintSeverityError, "compatibility", err.Error())
+		}
+	}
+	for _, dep := range meta.Dependencies {
+		if dep.Slug == meta.Name {
+			issue(LintSeverityError, "dependencies", fmt.Sprintf("skill '%s' cannot depend on itself", meta.Name))
+		}
+	}
+	return issues
+}
+
+// HasLintErrors reports whether any issue has error severity.
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
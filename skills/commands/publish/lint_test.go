@@ -0,0 +1,167 @@
+package publish
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSkillMD(t *testing.T, content string) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644))
+	return dir
+}
+
+func TestLintSkill_Valid(t *testing.T) {
+	dir := writeSkillMD(t, `---
+name: my-skill
+version: 1.2.0
+description: Does useful things
+tags: [ci, security]
+license: Apache-2.0
+authors:
+  - Jane Doe <jane@example.com>
+compatibility:
+  agents: [cursor, claude-code]
+  jfrog-cli: ">=2.60.0"
+dependencies:
+  shared-helpers: ^1.0.0
+---
+
+# My Skill
+`)
+	assert.Empty(t, LintSkill(dir))
+}
+
+func TestLintSkill_Issues(t *testing.T) {
+	tests := []struct {
+		name     string
+		skillMD  string
+		severity string
+		line     int
+		field    string
+		message  string
+	}{
+		{
+			name:     "missing SKILL.md",
+			severity: LintSeverityError,
+			message:  "SKILL.md not found",
+		},
+		{
+			name:     "invalid YAML",
+			skillMD:  "---\nname: my-skill\ndescription: [unclosed\n---\nbody\n",
+			severity: LintSeverityError,
+			message:  "invalid YAML frontmatter",
+		},
+		{
+			name:     "missing name",
+			skillMD:  "---\ndescription: x\n---\nbody\n",
+			severity: LintSeverityError,
+			line:     2,
+			field:    "name",
+			message:  "is required",
+		},
+		{
+			name:     "invalid tag",
+			skillMD:  "---\nname: my-skill\ndescription: x\ntags:\n  - ok\n  - Not Valid\n---\nbody\n",
+			severity: LintSeverityError,
+			line:     6,
+			field:    "tags[1]",
+			message:  "'Not Valid' must match pattern",
+		},
+		{
+			name:     "wrong type",
+			skillMD:  "---\nname: my-skill\ndescription: x\nauthors: Jane\n---\nbody\n",
+			severity: LintSeverityError,
+			line:     4,
+			field:    "authors",
+			message:  "must be a list",
+		},
+		{
+			name:     "unsupported compatibility field",
+			skillMD:  "---\nname: my-skill\ndescription: x\ncompatibility:\n  editors: [vim]\n---\nbody\n",
+			severity: LintSeverityError,
+			line:     5,
+			field:    "compatibility.editors",
+			message:  "is not a supported field",
+		},
+		{
+			name:     "invalid dependency range",
+			skillMD:  "---\nname: my-skill\ndescription: x\ndependencies:\n  helpers: \">=\"\n---\nbody\n",
+			severity: LintSeverityError,
+			message:  "invalid version range",
+		},
+		{
+			name:     "self dependency",
+			skillMD:  "---\nname: my-skill\ndescription: x\ndependencies:\n  my-skill: ^1.0.0\n---\nbody\n",
+			severity: LintSeverityError,
+			line:     4,
+			field:    "dependencies",
+			message:  "cannot depend on itself",
+		},
+		{
+			name:     "unknown field",
+			skillMD:  "---\nname: my-skill\ndescription: x\nx-owner: team-a\n---\nbody\n",
+			severity: LintSeverityWarning,
+			line:     4,
+			field:    "x-owner",
+			message:  "unknown field",
+		},
+		{
+			name:     "non-semver version",
+			skillMD:  "---\nname: my-skill\ndescription: x\nversion: latest-build\n---\nbody\n",
+			severity: LintSeverityWarning,
+			line:     4,
+			field:    "version",
+			message:  "not a valid semantic version",
+		},
+		{
+			name:     "missing description",
+			skillMD:  "---\nname: my-skill\n---\nbody\n",
+			severity: LintSeverityWarning,
+			field:    "description",
+			message:  "is recommended",
+		},
+		{
+			name:     "empty body",
+			skillMD:  "---\nname: my-skill\ndescription: x\n---\n",
+			severity: LintSeverityWarning,
+			message:  "no instructions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.skillMD != "" {
+				dir = writeSkillMD(t, tt.skillMD)
+			}
+			issues := LintSkill(dir)
+			require.Len(t, issues, 1, "%v", issues)
+			assert.Equal(t, tt.severity, issues[0].Severity)
+			assert.Equal(t, tt.line, issues[0].Line)
+			assert.Equal(t, tt.field, issues[0].Field)
+			assert.Contains(t, issues[0].Message, tt.message)
+			assert.Equal(t, tt.severity == LintSeverityError, HasLintErrors(issues))
+		})
+	}
+}
+
+func TestLintIssue_String(t *testing.T) {
+	assert.Equal(t, "SKILL.md:3: tags[0]: must be a string", LintIssue{Line: 3, Field: "tags[0]", Message: "must be a string"}.String())
+	assert.Equal(t, "SKILL.md: no instructions", LintIssue{Message: "no instructions"}.String())
+}
+
+func TestSkillSchema(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(SkillSchema(), &schema))
+	assert.Equal(t, []interface{}{"name"}, schema["required"])
+
+	for _, field := range []string{"name", "version", "description", "tags", "license", "authors", "compatibility", "dependencies"} {
+		assert.True(t, knownField(field), field)
+	}
+	assert.False(t, knownField("x-owner"))
+}
@@ -3,8 +3,7 @@ package publish
 import (
 	"archive/zip"
 	"bufio"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
 	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
@@ -45,6 +45,10 @@ type PublishCommand struct {
 	quiet               bool
 	skipScan            bool
 	autoDeleteOnFailure bool
+	detachedSignature   bool
+	scanReport          string
+	scanReportFormat    string
+	failOn              []string
 }
 
 func NewPublishCommand() *PublishCommand {
@@ -96,6 +100,24 @@ func (pc *PublishCommand) SetAutoDeleteOnFailure(autoDelete bool) *PublishComman
 	return pc
 }
 
+func (pc *PublishCommand) SetDetachedSignature(detached bool) *PublishCommand {
+	pc.detachedSignature = detached
+	return pc
+}
+
+// SetScanReport sets the file the Xray scan gate result is written to, as "json" or "sarif".
+func (pc *PublishCommand) SetScanReport(path, format string) *PublishCommand {
+	pc.scanReport = path
+	pc.scanReportFormat = format
+	return pc
+}
+
+// SetFailOn sets the scan gate outcomes, besides blocked, that fail the publish.
+func (pc *PublishCommand) SetFailOn(failOn []string) *PublishCommand {
+	pc.failOn = failOn
+	return pc
+}
+
 func (pc *PublishCommand) ServerDetails() (*config.ServerDetails, error) {
 	return pc.serverDetails, nil
 }
@@ -105,6 +127,10 @@ func (pc *PublishCommand) CommandName() string {
 }
 
 func (pc *PublishCommand) Run() error {
+	if err := pc.lint(); err != nil {
+		return err
+	}
+
 	meta, err := ParseSkillMeta(pc.skillDir)
 	if err != nil {
 		return err
@@ -114,6 +140,9 @@ func (pc *PublishCommand) Run() error {
 	if err := ValidateSlug(slug); err != nil {
 		return err
 	}
+	if err := pc.validateDependencies(meta.Dependencies); err != nil {
+		return err
+	}
 
 	version := pc.version
 	if version == "" {
@@ -129,6 +158,14 @@ func (pc *PublishCommand) Run() error {
 	if err := ValidateVersion(version); err != nil {
 		return err
 	}
+	if pc.detachedSignature {
+		if keyPath, _ := ResolveSigningKey(pc.signingKey, pc.keyAlias); keyPath == "" {
+			return fmt.Errorf("--detached-signature requires a signing key. Provide --signing-key flag or set EVD_SIGNING_KEY_PATH env var")
+		}
+	}
+	if _, err := common.ParseVersion(version); err != nil {
+		log.Warn(fmt.Sprintf("Version '%s' is not a valid semantic version; it will not match version ranges on install.", version))
+	}
 
 	version, err = pc.resolveVersionCollision(slug, version)
 	if err != nil {
@@ -154,7 +191,7 @@ func (pc *PublishCommand) Run() error {
 		}
 	}()
 
-	sha256Hex, err := computeSHA256(zipPath)
+	sha256Hex, err := common.ComputeSHA256(zipPath)
 	if err != nil {
 		return fmt.Errorf("failed to compute SHA256: %w", err)
 	}
@@ -163,6 +200,11 @@ func (pc *PublishCommand) Run() error {
 	if err := pc.upload(zipPath, target); err != nil {
 		return fmt.Errorf("upload failed: %w", err)
 	}
+	if pc.detachedSignature {
+		if err := pc.uploadSignature(slug, version, fmt.Sprintf("%s-%s.zip", slug, version), sha256Hex, target); err != nil {
+			return fmt.Errorf("signature upload failed: %w", err)
+		}
+	}
 
 	log.Info("Upload complete. Attaching evidence...")
 	pc.attachEvidence(slug, version, sha256Hex)
@@ -178,6 +220,9 @@ func (pc *PublishCommand) Run() error {
 		SkipScan:            pc.skipScan,
 		AutoDeleteOnFailure: pc.autoDeleteOnFailure,
 		Quiet:               pc.quiet,
+		FailOn:              pc.failOn,
+		ReportPath:          pc.scanReport,
+		ReportFormat:        pc.scanReportFormat,
 	}); err != nil {
 		return err
 	}
@@ -186,6 +231,59 @@ func (pc *PublishCommand) Run() error {
 	return nil
 }
 
+// lint validates the skill directory locally, so that nothing is uploaded for an invalid skill.
+func (pc *PublishCommand) lint() error {
+	return CheckLint(pc.skillDir)
+}
+
+// CheckLint runs LintSkill, logs warnings and fails on any error.
+func CheckLint(skillDir string) error {
+	issues := LintSkill(skillDir)
+	var errs []string
+	for _, issue := range issues {
+		if issue.Severity == LintSeverityError {
+			errs = append(errs, issue.String())
+		} else {
+			log.Warn(issue.String())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("skill validation failed (see 'jf skills lint'):\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// validateDependencies checks that every dependency declared in SKILL.md exists in the
+// target repository with at least one version satisfying its range.
+func (pc *PublishCommand) validateDependencies(deps []Dependency) error {
+	var errs []error
+	for _, dep := range deps {
+		versions, err := common.ListVersions(pc.serverDetails, pc.repoKey, dep.Slug)
+		if err != nil {
+			if strings.Contains(err.Error(), "404 Not Found") {
+				errs = append(errs, fmt.Errorf("dependency '%s' not found in repository '%s'", dep.Slug, pc.repoKey))
+			} else {
+				errs = append(errs, fmt.Errorf("failed to list versions of dependency '%s': %w", dep.Slug, err))
+			}
+			continue
+		}
+		versionStrs := make([]string, len(versions))
+		for i, v := range versions {
+			versionStrs[i] = v.Version
+		}
+		resolved, err := common.ResolveVersionSpec(versionStrs, dep.Version)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("dependency '%s@%s': %w", dep.Slug, dep.Version, err))
+			continue
+		}
+		log.Debug(fmt.Sprintf("Dependency '%s@%s' resolves to %s", dep.Slug, dep.Version, resolved))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("SKILL.md declares unresolvable dependencies:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
 // resolveMissingVersion handles the case where neither --version nor SKILL.md frontmatter
 // provides a version. It fetches existing versions from Artifactory, then:
 //   - Interactive: shows them and asks the user to enter a version
@@ -248,14 +346,23 @@ func (pc *PublishCommand) resolveVersionCollision(slug, version string) (string,
 		return version, nil
 	}
 
+	suggestion := pc.suggestNextVersion(slug, version)
+
 	if pc.quiet {
+		if suggestion != "" {
+			return "", fmt.Errorf("version %s of skill '%s' already exists. Use a different version (next available: %s) or remove the existing one", version, slug, suggestion)
+		}
 		return "", fmt.Errorf("version %s of skill '%s' already exists. Use a different version or remove the existing one", version, slug)
 	}
 
 	log.Warn(fmt.Sprintf("Version %s of skill '%s' already exists in repository '%s'.", version, slug, pc.repoKey))
 	fmt.Println("Choose an action:")
 	fmt.Println("  [o] Overwrite the existing version")
-	fmt.Println("  [n] Enter a new version")
+	if suggestion != "" {
+		fmt.Printf("  [n] Enter a new version (next available: %s)\n", suggestion)
+	} else {
+		fmt.Println("  [n] Enter a new version")
+	}
 	fmt.Println("  [a] Abort")
 	fmt.Print("Your choice (o/n/a): ")
 
@@ -268,9 +375,16 @@ func (pc *PublishCommand) resolveVersionCollision(slug, version string) (string,
 		log.Info(fmt.Sprintf("Overwriting version %s...", version))
 		return version, nil
 	case "n":
-		fmt.Print("Enter new version: ")
+		if suggestion != "" {
+			fmt.Printf("Enter new version [%s]: ", suggestion)
+		} else {
+			fmt.Print("Enter new version: ")
+		}
 		newInput, _ := reader.ReadString('\n')
 		newVersion := strings.TrimSpace(newInput)
+		if newVersion == "" {
+			newVersion = suggestion
+		}
 		if newVersion == "" {
 			return "", fmt.Errorf("no version provided, aborting")
 		}
@@ -286,6 +400,24 @@ func (pc *PublishCommand) resolveVersionCollision(slug, version string) (string,
 	}
 }
 
+// suggestNextVersion returns the next free patch version above the colliding one,
+// or an empty string when it cannot be determined (e.g. the version is not semver).
+func (pc *PublishCommand) suggestNextVersion(slug, version string) string {
+	versions, err := common.ListVersions(pc.serverDetails, pc.repoKey, slug)
+	if err != nil {
+		log.Debug("Could not fetch existing versions:", err.Error())
+	}
+	taken := make([]string, len(versions))
+	for i, v := range versions {
+		taken[i] = v.Version
+	}
+	next, err := common.NextAvailablePatchVersion(version, append(taken, version))
+	if err != nil {
+		return ""
+	}
+	return next
+}
+
 func (pc *PublishCommand) resolveZip(slug, version string) (string, error) {
 	if strings.Contains(version, "..") || strings.ContainsAny(version, "/\\") {
 		return "", fmt.Errorf("invalid version '%s': contains path traversal characters", version)
@@ -392,20 +524,35 @@ func normalizeFileMode(mode os.FileMode) os.FileMode {
 	return mode
 }
 
-func zipSkillFolder(skillDir, slug, version string) (zipPath string, err error) {
+func zipSkillFolder(skillDir, slug, version string) (string, error) {
 	if strings.Contains(version, "..") || strings.ContainsAny(version, "/\\") {
 		return "", fmt.Errorf("invalid version '%s': contains path traversal characters", version)
 	}
 
+	tmpDir, err := os.MkdirTemp("", "skill-publish-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	zipPath := filepath.Clean(filepath.Join(tmpDir, fmt.Sprintf("%s-%s.zip", slug, version)))
+	if err := WriteSkillZip(skillDir, zipPath); err != nil {
+		return "", err
+	}
+	return zipPath, nil
+}
+
+// WriteSkillZip writes the deterministic zip of a skill directory to zipPath:
+// the same content and mtimes always produce a byte-identical zip.
+func WriteSkillZip(skillDir, zipPath string) (err error) {
 	// Collect and sort file paths for deterministic zip output.
 	// The max mtime is used as a uniform timestamp for all zip entries so that
 	// the zip is byte-identical when rebuilt with the same content and mtimes.
 	files, maxMtime, err := collectFiles(skillDir)
 	if err != nil {
-		return "", fmt.Errorf("failed to collect skill files: %w", err)
+		return fmt.Errorf("failed to collect skill files: %w", err)
 	}
 	if len(files) == 0 {
-		return "", fmt.Errorf("no files found in skill directory %s (all files may have been excluded)", skillDir)
+		return fmt.Errorf("no files found in skill directory %s (all files may have been excluded)", skillDir)
 	}
 	// Guard against zero mtime (e.g. files with epoch timestamps) which produces
 	// invalid MS-DOS dates before the ZIP format's 1980-01-01 minimum.
@@ -413,15 +560,10 @@ func zipSkillFolder(skillDir, slug, version string) (zipPath string, err error)
 		maxMtime = zipEpoch
 	}
 
-	tmpDir, err := os.MkdirTemp("", "skill-publish-*")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp dir: %w", err)
-	}
-
-	zipPath = filepath.Clean(filepath.Join(tmpDir, fmt.Sprintf("%s-%s.zip", slug, version)))
-	zipFile, err := os.Create(zipPath)
+	// #nosec G304 -- zipPath is a temp file or the user-provided pack output location
+	zipFile, err := os.Create(filepath.Clean(zipPath))
 	if err != nil {
-		return "", fmt.Errorf("failed to create zip file: %w", err)
+		return fmt.Errorf("failed to create zip file: %w", err)
 	}
 	defer func() {
 		_ = zipFile.Close()
@@ -436,7 +578,7 @@ func zipSkillFolder(skillDir, slug, version string) (zipPath string, err error)
 
 	for _, sf := range files {
 		if err = addFileToZip(w, skillDir, sf, maxMtime); err != nil {
-			return "", fmt.Errorf("failed to add %s to zip: %w", sf.relPath, err)
+			return fmt.Errorf("failed to add %s to zip: %w", sf.relPath, err)
 		}
 	}
 
@@ -458,26 +600,6 @@ func shouldExclude(relPath string, info os.FileInfo) bool {
 	return false
 }
 
-func computeSHA256(path string) (string, error) {
-	if strings.Contains(path, "..") {
-		return "", fmt.Errorf("invalid path: contains traversal sequence")
-	}
-	cleanPath := filepath.Clean(path)
-	f, err := os.Open(cleanPath)
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		_ = f.Close()
-	}()
-
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return "", err
-	}
-	return hex.EncodeToString(h.Sum(nil)), nil
-}
-
 func (pc *PublishCommand) upload(zipPath, target string) error {
 	serviceManager, err := utils.CreateUploadServiceManager(pc.serverDetails, 1, 3, 0, false, nil)
 	if err != nil {
@@ -494,20 +616,7 @@ func (pc *PublishCommand) upload(zipPath, target string) error {
 }
 
 func (pc *PublishCommand) attachEvidence(slug, version, sha256Hex string) {
-	// Flags take precedence over environment variables
-	keyPath := pc.signingKey
-	if keyPath == "" {
-		keyPath = os.Getenv("EVD_SIGNING_KEY_PATH")
-	}
-	if keyPath == "" {
-		keyPath = os.Getenv("JFROG_CLI_SIGNING_KEY")
-	}
-
-	alias := pc.keyAlias
-	if alias == "" {
-		alias = os.Getenv("EVD_KEY_ALIAS")
-	}
-
+	keyPath, alias := ResolveSigningKey(pc.signingKey, pc.keyAlias)
 	if keyPath == "" {
 		log.Info("No signing key configured. Provide --signing-key flag or set EVD_SIGNING_KEY_PATH env var. Skipping evidence creation.")
 		return
@@ -536,7 +645,7 @@ func (pc *PublishCommand) attachEvidence(slug, version, sha256Hex string) {
 
 	subjectRepoPath := fmt.Sprintf("%s/%s/%s/%s-%s.zip", pc.repoKey, slug, version, slug, version)
 
-	opts := common.CreateEvidenceOpts{
+	opts := evidenceutils.CreateEvidenceOpts{
 		SubjectRepoPath: subjectRepoPath,
 		SubjectSHA256:   sha256Hex,
 		PredicatePath:   predicatePath,
@@ -546,16 +655,22 @@ func (pc *PublishCommand) attachEvidence(slug, version, sha256Hex string) {
 		KeyAlias:        alias,
 	}
 
+	AttachEvidence(pc.serverDetails, opts, "skill upload")
+}
+
+// AttachEvidence creates evidence on a skill zip. Evidence is best effort: failures are logged,
+// mentioning that the operation itself (e.g. "skill upload") succeeded, and never returned.
+func AttachEvidence(serverDetails *config.ServerDetails, opts evidenceutils.CreateEvidenceOpts, operation string) {
 	// Suppress the evidence library's internal error/warn logs during this call.
 	// On 403 (license issue), they are noise — we handle the error ourselves below.
-	err = withSuppressedLogs(func() error {
-		return common.CreateEvidence(pc.serverDetails, opts)
+	err := withSuppressedLogs(func() error {
+		return evidenceutils.CreateEvidence(serverDetails, opts)
 	})
 	if err != nil {
 		if isEvidenceLicenseError(err) {
-			log.Info("Evidence not attached: evidence requires an Enterprise+ license. Skill upload succeeded.")
+			log.Info(fmt.Sprintf("Evidence not attached: evidence requires an Enterprise+ license. The %s succeeded.", operation))
 		} else {
-			log.Warn("Evidence creation failed (skill upload succeeded):", err.Error())
+			log.Warn(fmt.Sprintf("Evidence creation failed (%s succeeded):", operation), err.Error())
 		}
 		return
 	}
@@ -563,6 +678,48 @@ func (pc *PublishCommand) attachEvidence(slug, version, sha256Hex string) {
 	log.Info("Evidence successfully attached.")
 }
 
+// uploadSignature signs the skill zip locally and uploads the signature next to it, so that
+// installs can verify it against their trust store without the evidence service.
+func (pc *PublishCommand) uploadSignature(slug, version, zipName, sha256Hex, target string) error {
+	keyPath, alias := ResolveSigningKey(pc.signingKey, pc.keyAlias)
+	envelope, err := SignSkillAttestation(slug, version, zipName, sha256Hex, keyPath, alias)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "skill-signature-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	sigPath := filepath.Join(tmpDir, zipName+common.SignatureSuffix)
+	if err := envelope.Save(sigPath); err != nil {
+		return err
+	}
+	if err := pc.upload(sigPath, target); err != nil {
+		return err
+	}
+	log.Info("Detached signature uploaded.")
+	return nil
+}
+
+// ResolveSigningKey returns the evidence signing key path and alias.
+// Flags take precedence over environment variables.
+func ResolveSigningKey(keyPath, alias string) (string, string) {
+	if keyPath == "" {
+		keyPath = os.Getenv("EVD_SIGNING_KEY_PATH")
+	}
+	if keyPath == "" {
+		keyPath = os.Getenv("JFROG_CLI_SIGNING_KEY")
+	}
+	if alias == "" {
+		alias = os.Getenv("EVD_KEY_ALIAS")
+	}
+	return keyPath, alias
+}
+
 // withSuppressedLogs temporarily mutes all log output while fn executes,
 // then restores the previous log level. Used to suppress noisy internal
 // library logs when we handle errors ourselves.
@@ -609,6 +766,15 @@ func RunPublish(c *components.Context) error {
 		return err
 	}
 
+	failOn, err := common.ParseXrayGateFailOn(c.GetStringFlagValue("fail-on"))
+	if err != nil {
+		return err
+	}
+	scanReportFormat := c.GetStringFlagValue("scan-report-format")
+	if err := common.ValidateXrayReportFormat(scanReportFormat); err != nil {
+		return err
+	}
+
 	cmd := NewPublishCommand().
 		SetServerDetails(serverDetails).
 		SetRepoKey(repoKey).
@@ -618,7 +784,10 @@ func RunPublish(c *components.Context) error {
 		SetKeyAlias(c.GetStringFlagValue("key-alias")).
 		SetQuiet(quiet).
 		SetSkipScan(c.GetBoolFlagValue("skip-scan")).
-		SetAutoDeleteOnFailure(c.GetBoolFlagValue("auto-delete-on-failure"))
+		SetAutoDeleteOnFailure(c.GetBoolFlagValue("auto-delete-on-failure")).
+		SetDetachedSignature(c.GetBoolFlagValue("detached-signature")).
+		SetScanReport(c.GetStringFlagValue("scan-report"), scanReportFormat).
+		SetFailOn(failOn)
 
 	return cmd.Run()
 }
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,16 +66,6 @@ func TestParseSkillMeta_FileNotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestStripQuotes(t *testing.T) {
-	assert.Equal(t, "1.0.0", stripQuotes(`"1.0.0"`))
-	assert.Equal(t, "1.0.0", stripQuotes(`'1.0.0'`))
-	assert.Equal(t, "no-quotes", stripQuotes("no-quotes"))
-	assert.Equal(t, "", stripQuotes(`""`))
-	assert.Equal(t, "", stripQuotes(`''`))
-	assert.Equal(t, "a", stripQuotes("a"))
-	assert.Equal(t, `"mismatched'`, stripQuotes(`"mismatched'`))
-}
-
 func TestParseSkillMeta_QuotedVersion(t *testing.T) {
 	dir := t.TempDir()
 	skillMD := `---
@@ -296,6 +287,27 @@ func TestGenerateMarkdownFile(t *testing.T) {
 	assert.Contains(t, content, "| Published at |")
 }
 
+func TestGeneratePromotionFiles(t *testing.T) {
+	dir := t.TempDir()
+	path, err := GeneratePromotionPredicateFile(dir, "test-skill", "1.0.0", "skills-dev", "skills-prod", "abc123")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var p promotionPredicate
+	require.NoError(t, json.Unmarshal(data, &p))
+	assert.Equal(t, promotionPredicate{Skill: "test-skill", Version: "1.0.0", From: "skills-dev", To: "skills-prod", SHA256: "abc123", PromotedAt: p.PromotedAt}, p)
+	assert.True(t, strings.HasSuffix(p.PromotedAt, "Z"))
+
+	path, err = GeneratePromotionMarkdownFile(dir, "test-skill", "1.0.0", "skills-dev", "skills-prod")
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Promotion Attestation")
+	assert.Contains(t, string(data), "| From | skills-dev |")
+	assert.Contains(t, string(data), "| To | skills-prod |")
+}
+
 func TestZipSkillFolder(t *testing.T) {
 	dir := t.TempDir()
 	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: test\n---"), 0644))
@@ -317,18 +329,6 @@ func TestZipSkillFolder(t *testing.T) {
 	assert.True(t, info.Size() > 0)
 }
 
-func TestComputeSHA256(t *testing.T) {
-	dir := t.TempDir()
-	testFile := filepath.Join(dir, "test.txt")
-	require.NoError(t, os.WriteFile(testFile, []byte("hello world"), 0644))
-
-	hash, err := computeSHA256(testFile)
-	require.NoError(t, err)
-	assert.Len(t, hash, 64)
-	// SHA256 of "hello world"
-	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", hash)
-}
-
 func TestShouldExclude(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -434,7 +434,7 @@ func zipAndHash(t *testing.T, dir string) string {
 	require.NoError(t, err)
 	// Clean up both the zip file and its parent temp directory.
 	defer func() { _ = os.RemoveAll(filepath.Dir(zipPath)) }()
-	hash, err := computeSHA256(zipPath)
+	hash, err := common.ComputeSHA256(zipPath)
 	require.NoError(t, err)
 	return hash
 }
@@ -616,3 +616,130 @@ func TestCollectFiles_Sorted(t *testing.T) {
 			"files should be sorted: %s should come before %s", files[i-1].relPath, files[i].relPath)
 	}
 }
+
+func TestParseSkillMeta_Dependencies(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := `---
+name: my-skill
+version: 1.0.0
+dependencies:
+  shared-helpers: ^1.2
+  lint-rules: ">=2 <3"  # keep in sync with CI
+  formatter: latest
+description: After the block
+---
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	meta, err := ParseSkillMeta(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "After the block", meta.Description)
+	assert.Equal(t, []Dependency{
+		{Slug: "shared-helpers", Version: "^1.2"},
+		{Slug: "lint-rules", Version: ">=2 <3"},
+		{Slug: "formatter", Version: "latest"},
+	}, meta.Dependencies)
+}
+
+func TestParseSkillMeta_InvalidDependencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		block       string
+		errContains string
+	}{
+		{name: "invalid slug", block: "dependencies:\n  Bad_Slug: ^1.0\n", errContains: "invalid dependency"},
+		{name: "missing range", block: "dependencies:\n  helper:\n", errContains: "must declare a version range"},
+		{name: "invalid range", block: "dependencies:\n  helper: ^abc\n", errContains: "invalid version range"},
+		{name: "duplicate", block: "dependencies:\n  helper: ^1.0\n  helper: ^2.0\n", errContains: "more than once"},
+		{name: "self dependency", block: "dependencies:\n  my-skill: ^1.0\n", errContains: "cannot depend on itself"},
+		{name: "inline value", block: "dependencies: helper\n", errContains: "must be a map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			skillMD := "---\nname: my-skill\n" + tt.block + "---\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+			_, err := ParseSkillMeta(dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestParseSkillMeta_FullFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := `---
+# Skill metadata
+name: full-skill
+version: 1.0
+description: |
+  Reviews pull requests.
+  Use when: a PR needs a second opinion --- fast.
+tags: [review, git]
+license: Apache-2.0
+authors:
+  - Jane Doe <jane@example.com>
+compatibility:
+  agents: [claude-code, cursor]
+  jfrog-cli: ">=2.60"
+x-internal:
+  owner: platform-team
+---
+
+# Full Skill
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	meta, err := ParseSkillMeta(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "full-skill", meta.Name)
+	assert.Equal(t, "1.0", meta.Version, "unquoted numbers are kept as written")
+	assert.Equal(t, "Reviews pull requests.\nUse when: a PR needs a second opinion --- fast.\n", meta.Description)
+	assert.Equal(t, []string{"review", "git"}, meta.Tags)
+	assert.Equal(t, "Apache-2.0", meta.License)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>"}, meta.Authors)
+	assert.Equal(t, Compatibility{Agents: []string{"claude-code", "cursor"}, JFrogCLI: ">=2.60"}, meta.Compatibility)
+}
+
+func TestParseSkillMeta_InvalidYAML(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := "---\nname: bad-skill\ndescription: Use when: it breaks\n---\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	_, err := ParseSkillMeta(dir)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidFrontmatter)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestParseSkillMeta_WrongFieldType(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := "---\nname: typed-skill\ntags: review\n---\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	_, err := ParseSkillMeta(dir)
+	assert.ErrorContains(t, err, "'tags' must be a list of strings")
+}
+
+func TestUpdateSkillMetaVersion_PreservesCommentsAndUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	skillMD := `---
+# Owned by the platform team
+name: commented-skill
+
+version: "1.0.0" # bumped by CI
+x-internal:
+  version: 9.9.9
+---
+body
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0644))
+
+	require.NoError(t, UpdateSkillMetaVersion(dir, "1.1.0"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(skillMD, `"1.0.0"`, `"1.1.0"`, 1), string(data))
+}
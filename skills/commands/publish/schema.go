@@ -0,0 +1,218 @@
+package publish
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// skillSchemaJSON is the published JSON schema of the SKILL.md frontmatter.
+//
+//go:embed skill.schema.json
+var skillSchemaJSON []byte
+
+var skillSchema = mustParseSchema(skillSchemaJSON)
+
+// SkillSchema returns the JSON schema describing the allowed SKILL.md frontmatter fields.
+func SkillSchema() []byte {
+	return skillSchemaJSON
+}
+
+// jsonSchema is the subset of JSON Schema used by skill.schema.json.
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Pattern              string                 `json:"pattern"`
+	MinLength            int                    `json:"minLength"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	PropertyNames        *jsonSchema            `json:"propertyNames"`
+	Items                *jsonSchema            `json:"items"`
+	UniqueItems          bool                   `json:"uniqueItems"`
+
+	pattern *regexp.Regexp
+	// additional validates properties not listed in Properties; nil means they are rejected
+	// unless allowAdditional is set.
+	additional      *jsonSchema
+	allowAdditional bool
+}
+
+// schemaViolation is a frontmatter value that does not match the schema.
+type schemaViolation struct {
+	field   string
+	line    int
+	message string
+}
+
+func mustParseSchema(data []byte) *jsonSchema {
+	schema := &jsonSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		panic(fmt.Sprintf("invalid embedded skill schema: %s", err))
+	}
+	if err := schema.compile(); err != nil {
+		panic(fmt.Sprintf("invalid embedded skill schema: %s", err))
+	}
+	return schema
+}
+
+func (s *jsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = re
+	}
+
+	s.allowAdditional = true
+	if len(s.AdditionalProperties) > 0 {
+		var allow bool
+		if err := json.Unmarshal(s.AdditionalProperties, &allow); err == nil {
+			s.allowAdditional = allow
+		} else {
+			s.additional = &jsonSchema{}
+			if err := json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+				return err
+			}
+		}
+	}
+
+	children := []*jsonSchema{s.PropertyNames, s.Items, s.additional}
+	for _, prop := range s.Properties {
+		children = append(children, prop)
+	}
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaValidator collects the violations found while walking the frontmatter node tree.
+type schemaValidator struct {
+	lineOffset int
+	violations []schemaViolation
+}
+
+// validateFrontmatter checks the frontmatter against the skill schema.
+func (d *skillDocument) validateFrontmatter() []schemaViolation {
+	root := d.root
+	if root == nil {
+		// Empty frontmatter: validate an empty mapping so that required fields are reported.
+		root = &yaml.Node{Kind: yaml.MappingNode, Line: 1}
+	}
+	v := &schemaValidator{lineOffset: d.lineOffset}
+	v.validate(skillSchema, root, "")
+	return v.violations
+}
+
+func (v *schemaValidator) report(n *yaml.Node, field, format string, args ...interface{}) {
+	v.violations = append(v.violations, schemaViolation{field: field, line: n.Line + v.lineOffset, message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(s *jsonSchema, n *yaml.Node, field string) {
+	switch s.Type {
+	case "object":
+		if n.Kind != yaml.MappingNode {
+			v.report(n, field, "must be a map")
+			return
+		}
+		v.validateObject(s, n, field)
+	case "array":
+		if n.Kind != yaml.SequenceNode {
+			v.report(n, field, "must be a list")
+			return
+		}
+		seen := map[string]bool{}
+		for i, item := range n.Content {
+			itemField := fmt.Sprintf("%s[%d]", field, i)
+			if s.Items != nil {
+				v.validate(s.Items, item, itemField)
+			}
+			if s.UniqueItems && item.Kind == yaml.ScalarNode {
+				if seen[item.Value] {
+					v.report(item, itemField, "duplicate value '%s'", item.Value)
+				}
+				seen[item.Value] = true
+			}
+		}
+	case "string":
+		if n.Kind != yaml.ScalarNode || isNull(n) {
+			v.report(n, field, "must be a string")
+			return
+		}
+		v.validateString(s, n, n.Value, field)
+	}
+}
+
+func (v *schemaValidator) validateObject(s *jsonSchema, n *yaml.Node, field string) {
+	present := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		keyField := joinField(field, key.Value)
+		if present[key.Value] {
+			v.report(key, keyField, "is defined more than once")
+			continue
+		}
+		present[key.Value] = true
+
+		if s.PropertyNames != nil {
+			v.validateString(s.PropertyNames, key, key.Value, keyField)
+		}
+		switch prop, ok := s.Properties[key.Value]; {
+		case ok:
+			v.validate(prop, value, keyField)
+		case s.additional != nil:
+			v.validate(s.additional, value, keyField)
+		case !s.allowAdditional:
+			v.report(key, keyField, "is not a supported field")
+		}
+	}
+
+	missing := make([]string, 0, len(s.Required))
+	for _, name := range s.Required {
+		if !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		v.report(n, joinField(field, name), "is required")
+	}
+}
+
+func (v *schemaValidator) validateString(s *jsonSchema, n *yaml.Node, value, field string) {
+	if utf8.RuneCountInString(value) < s.MinLength {
+		if s.MinLength == 1 {
+			v.report(n, field, "must not be empty")
+		} else {
+			v.report(n, field, "must be at least %d characters long", s.MinLength)
+		}
+		return
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		v.report(n, field, "'%s' must match pattern %s", value, s.Pattern)
+	}
+}
+
+// knownField reports whether name is a top-level field defined by the skill schema.
+func knownField(name string) bool {
+	_, ok := skillSchema.Properties[name]
+	return ok
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
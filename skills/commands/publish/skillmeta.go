@@ -1,11 +1,17 @@
 package publish
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"gopkg.in/yaml.v3"
 )
 
 var slugRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
@@ -14,22 +20,64 @@ var slugRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
 // It rejects path separators, "..", null bytes, and other characters that could cause path traversal.
 var versionSafeRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.\-+]*$`)
 
+// yamlErrorLineRegex matches the line references in yaml.v3 error messages.
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// ErrInvalidFrontmatter is returned when the SKILL.md frontmatter is not valid YAML.
+var ErrInvalidFrontmatter = errors.New("invalid YAML frontmatter")
+
+// SkillMeta is the typed view of the SKILL.md frontmatter.
+// See skill.schema.json for the documented fields.
 type SkillMeta struct {
-	Name        string
-	Description string
-	Version     string
+	Name          string
+	Description   string
+	Version       string
+	Tags          []string
+	License       string
+	Authors       []string
+	Compatibility Compatibility
+	Dependencies  []Dependency
+}
+
+// Compatibility declares which agents and CLI versions a skill supports.
+type Compatibility struct {
+	Agents   []string
+	JFrogCLI string
+}
+
+// Dependency is a skill required by another skill, declared in the SKILL.md frontmatter:
+//
+//	dependencies:
+//	  shared-helpers: ^1.2
+//	  lint-rules: ">=2 <3"
+type Dependency struct {
+	Slug string
+	// Version is a version range, an exact version or "latest".
+	Version string
+}
+
+// skillDocument is a parsed SKILL.md: the YAML frontmatter node tree plus the raw content,
+// so that edits can be spliced into the original text without reformatting it.
+type skillDocument struct {
+	content string
+	// yamlStart and yamlEnd delimit the frontmatter YAML within content.
+	yamlStart int
+	yamlEnd   int
+	// lineOffset is the number of lines preceding the frontmatter YAML, used to
+	// report node positions as SKILL.md line numbers.
+	lineOffset int
+	// root is the top-level mapping, or nil when the frontmatter is empty.
+	root *yaml.Node
 }
 
 // ParseSkillMeta reads a SKILL.md file and extracts YAML frontmatter metadata.
 func ParseSkillMeta(skillDir string) (*SkillMeta, error) {
-	skillMDPath := filepath.Join(skillDir, "SKILL.md")
-	// #nosec G304 -- path is constructed from user-provided skill directory argument
-	data, err := os.ReadFile(skillMDPath)
+	doc, err := readSkillDocument(skillDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read SKILL.md at %s: %w", skillMDPath, err)
+		return nil, err
 	}
 
-	meta, err := parseFrontmatter(string(data))
+	meta, err := doc.meta()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
 	}
@@ -37,103 +85,377 @@ func ParseSkillMeta(skillDir string) (*SkillMeta, error) {
 	if meta.Name == "" {
 		return nil, fmt.Errorf("SKILL.md missing required 'name' field in frontmatter")
 	}
+	for _, dep := range meta.Dependencies {
+		if dep.Slug == meta.Name {
+			return nil, fmt.Errorf("skill '%s' cannot depend on itself", meta.Name)
+		}
+	}
 
 	return meta, nil
 }
 
-func parseFrontmatter(content string) (*SkillMeta, error) {
-	if !strings.HasPrefix(strings.TrimSpace(content), "---") {
+// ParseSkillInstructions reads a SKILL.md file and returns its metadata and the instructions following the frontmatter.
+func ParseSkillInstructions(skillDir string) (*SkillMeta, string, error) {
+	doc, err := readSkillDocument(skillDir)
+	if err != nil {
+		return nil, "", err
+	}
+	meta, err := doc.meta()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
+	}
+	return meta, doc.body(), nil
+}
+
+func readSkillDocument(skillDir string) (*skillDocument, error) {
+	skillMDPath := filepath.Join(skillDir, "SKILL.md")
+	// #nosec G304 -- path is constructed from user-provided skill directory argument
+	data, err := os.ReadFile(skillMDPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md at %s: %w", skillMDPath, err)
+	}
+
+	doc, err := parseSkillDocument(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
+	}
+	return doc, nil
+}
+
+func parseSkillDocument(content string) (*skillDocument, error) {
+	start := len(content) - len(strings.TrimLeft(content, "\ufeff \t\r\n"))
+	if !strings.HasPrefix(content[start:], "---") {
 		return nil, fmt.Errorf("SKILL.md does not start with YAML frontmatter delimiter '---'")
 	}
 
-	trimmed := strings.TrimSpace(content)
-	// Find second --- delimiter
-	rest := trimmed[3:]
-	endIdx := strings.Index(rest, "---")
-	if endIdx < 0 {
+	doc := &skillDocument{content: content, yamlEnd: -1}
+	openingEnd := strings.IndexByte(content[start:], '\n')
+	if openingEnd < 0 {
+		return nil, fmt.Errorf("SKILL.md missing closing YAML frontmatter delimiter '---'")
+	}
+	doc.yamlStart = start + openingEnd + 1
+
+	for pos := doc.yamlStart; pos < len(content); {
+		lineEnd := strings.IndexByte(content[pos:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(content)
+		} else {
+			lineEnd += pos
+		}
+		if strings.TrimRight(content[pos:lineEnd], " \t\r") == "---" {
+			doc.yamlEnd = pos
+			break
+		}
+		pos = lineEnd + 1
+	}
+	if doc.yamlEnd < 0 {
 		return nil, fmt.Errorf("SKILL.md missing closing YAML frontmatter delimiter '---'")
 	}
+	doc.lineOffset = strings.Count(content[:doc.yamlStart], "\n")
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(doc.frontmatter()), &node); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFrontmatter, doc.adjustErrorLines(err.Error()))
+	}
+	if len(node.Content) == 0 {
+		return doc, nil
+	}
+	if node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: frontmatter must be a mapping of 'key: value' fields", ErrInvalidFrontmatter)
+	}
+	doc.root = node.Content[0]
+	return doc, nil
+}
 
-	frontmatter := rest[:endIdx]
+func (d *skillDocument) frontmatter() string {
+	return d.content[d.yamlStart:d.yamlEnd]
+}
+
+// body returns the markdown following the frontmatter.
+func (d *skillDocument) body() string {
+	rest := d.content[d.yamlEnd:]
+	if idx := strings.IndexByte(rest, '\n'); idx >= 0 {
+		return rest[idx+1:]
+	}
+	return ""
+}
+
+// line converts a node position into a SKILL.md line number.
+func (d *skillDocument) line(n *yaml.Node) int {
+	return n.Line + d.lineOffset
+}
+
+// adjustErrorLines rewrites yaml.v3 line references to SKILL.md line numbers.
+func (d *skillDocument) adjustErrorLines(msg string) string {
+	return yamlErrorLineRegex.ReplaceAllStringFunc(msg, func(match string) string {
+		n, err := strconv.Atoi(strings.TrimPrefix(match, "line "))
+		if err != nil {
+			return match
+		}
+		return fmt.Sprintf("line %d", n+d.lineOffset)
+	})
+}
+
+// field returns the key and value nodes of a top-level frontmatter field.
+func (d *skillDocument) field(name string) (key, value *yaml.Node) {
+	if d.root == nil {
+		return nil, nil
+	}
+	return mappingField(d.root, name)
+}
+
+func mappingField(mapping *yaml.Node, name string) (key, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func (d *skillDocument) meta() (*SkillMeta, error) {
 	meta := &SkillMeta{}
+	var err error
+	if meta.Name, err = d.stringField("name"); err != nil {
+		return nil, err
+	}
+	if meta.Description, err = d.stringField("description"); err != nil {
+		return nil, err
+	}
+	if meta.Version, err = d.stringField("version"); err != nil {
+		return nil, err
+	}
+	if meta.License, err = d.stringField("license"); err != nil {
+		return nil, err
+	}
+	if meta.Tags, err = d.stringListField("tags"); err != nil {
+		return nil, err
+	}
+	if meta.Authors, err = d.stringListField("authors"); err != nil {
+		return nil, err
+	}
+	if meta.Compatibility, err = d.compatibility(); err != nil {
+		return nil, err
+	}
+	if meta.Dependencies, err = d.dependencies(); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
 
-	for _, line := range strings.Split(frontmatter, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+func (d *skillDocument) stringField(name string) (string, error) {
+	_, value := d.field(name)
+	return scalarString(value, name)
+}
+
+func (d *skillDocument) stringListField(name string) ([]string, error) {
+	_, value := d.field(name)
+	return stringList(value, name)
+}
+
+func (d *skillDocument) compatibility() (Compatibility, error) {
+	var compat Compatibility
+	_, value := d.field("compatibility")
+	if isNull(value) {
+		return compat, nil
+	}
+	if value.Kind != yaml.MappingNode {
+		return compat, fmt.Errorf("'compatibility' must be a map")
+	}
+	_, agents := mappingField(value, "agents")
+	var err error
+	if compat.Agents, err = stringList(agents, "compatibility.agents"); err != nil {
+		return compat, err
+	}
+	_, cli := mappingField(value, "jfrog-cli")
+	if compat.JFrogCLI, err = scalarString(cli, "compatibility.jfrog-cli"); err != nil {
+		return compat, err
+	}
+	return compat, nil
+}
+
+func (d *skillDocument) dependencies() ([]Dependency, error) {
+	_, value := d.field("dependencies")
+	if isNull(value) {
+		return nil, nil
+	}
+	if value.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("'dependencies' must be a map of 'slug: version-range' entries (line %d)", d.line(value))
+	}
+
+	var deps []Dependency
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		keyNode, specNode := value.Content[i], value.Content[i+1]
+		spec, err := scalarString(specNode, "dependencies."+keyNode.Value)
+		if err != nil {
+			return nil, err
 		}
-		colonIdx := strings.Index(line, ":")
-		if colonIdx < 0 {
-			continue
+		dep := Dependency{Slug: keyNode.Value, Version: spec}
+		if err := validateDependency(dep); err != nil {
+			return nil, err
 		}
-		key := strings.TrimSpace(line[:colonIdx])
-		value := stripQuotes(strings.TrimSpace(line[colonIdx+1:]))
-
-		switch key {
-		case "name":
-			meta.Name = value
-		case "description":
-			meta.Description = value
-		case "version":
-			meta.Version = value
+		if seen[dep.Slug] {
+			return nil, fmt.Errorf("dependency '%s' is declared more than once", dep.Slug)
 		}
+		seen[dep.Slug] = true
+		deps = append(deps, dep)
 	}
+	return deps, nil
+}
 
-	return meta, nil
+func validateDependency(dep Dependency) error {
+	if err := ValidateSlug(dep.Slug); err != nil {
+		return fmt.Errorf("invalid dependency: %w", err)
+	}
+	if dep.Version == "" {
+		return fmt.Errorf("dependency '%s' must declare a version range (e.g. ^1.0.0 or latest)", dep.Slug)
+	}
+	if dep.Version != "latest" {
+		if _, err := common.ParseConstraint(dep.Version); err != nil {
+			return fmt.Errorf("invalid version range '%s' for dependency '%s': %w", dep.Version, dep.Slug, err)
+		}
+	}
+	return nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n == nil || (n.Kind == yaml.ScalarNode && n.Tag == "!!null")
+}
+
+// scalarString returns the literal text of a scalar node. Unquoted numbers such as
+// "version: 1.0" are kept exactly as written rather than reinterpreted.
+func scalarString(n *yaml.Node, name string) (string, error) {
+	if isNull(n) {
+		return "", nil
+	}
+	if n.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("'%s' must be a string", name)
+	}
+	return n.Value, nil
 }
 
-func stripQuotes(s string) string {
-	if len(s) >= 2 {
-		if (s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'') {
-			return s[1 : len(s)-1]
+func stringList(n *yaml.Node, name string) ([]string, error) {
+	if isNull(n) {
+		return nil, nil
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("'%s' must be a list of strings", name)
+	}
+	values := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		if item.Kind != yaml.ScalarNode || isNull(item) {
+			return nil, fmt.Errorf("'%s' must be a list of strings", name)
 		}
+		values = append(values, item.Value)
 	}
-	return s
+	return values, nil
 }
 
-// versionLineRegex matches a YAML version field (with optional quoting) inside front matter.
-var versionLineRegex = regexp.MustCompile(`(?m)^(version:\s*).*$`)
+// setScalar replaces the value of a top-level scalar field in place. Only the value's own
+// characters are rewritten, so comments, unknown fields, quoting style and layout are preserved.
+// It reports false when the field does not exist.
+func (d *skillDocument) setScalar(name, newValue string) (bool, error) {
+	_, value := d.field(name)
+	if value == nil {
+		return false, nil
+	}
+	if value.Kind != yaml.ScalarNode || value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return false, fmt.Errorf("'%s' must be a single-line value to be updated", name)
+	}
 
-// UpdateSkillMetaVersion replaces the version value in the SKILL.md YAML front matter.
-// It only acts when a version: line already exists; it never inserts a new one.
-func UpdateSkillMetaVersion(skillDir, newVersion string) error {
-	skillMDPath := filepath.Join(skillDir, "SKILL.md")
-	// #nosec G304 -- path is constructed from user-provided skill directory argument
-	data, err := os.ReadFile(skillMDPath)
+	start, err := d.offset(value)
 	if err != nil {
-		return fmt.Errorf("failed to read SKILL.md at %s: %w", skillMDPath, err)
+		return false, err
 	}
+	end := scalarEnd(d.content, start, value.Style)
 
-	content := string(data)
-	trimmed := strings.TrimSpace(content)
-	if !strings.HasPrefix(trimmed, "---") {
-		return fmt.Errorf("SKILL.md does not start with YAML frontmatter delimiter '---'")
+	replacement := newValue
+	switch {
+	case value.Style&yaml.DoubleQuotedStyle != 0:
+		replacement = `"` + newValue + `"`
+	case value.Style&yaml.SingleQuotedStyle != 0:
+		replacement = `'` + newValue + `'`
 	}
+	d.content = d.content[:start] + replacement + d.content[end:]
+	return true, nil
+}
 
-	rest := trimmed[3:]
-	endIdx := strings.Index(rest, "---")
-	if endIdx < 0 {
-		return fmt.Errorf("SKILL.md missing closing YAML frontmatter delimiter '---'")
+// offset converts a node's line/column position into a byte offset within content.
+func (d *skillDocument) offset(n *yaml.Node) (int, error) {
+	pos := d.yamlStart
+	for line := 1; line < n.Line; line++ {
+		idx := strings.IndexByte(d.content[pos:d.yamlEnd], '\n')
+		if idx < 0 {
+			return 0, fmt.Errorf("frontmatter position %d:%d is out of range", n.Line, n.Column)
+		}
+		pos += idx + 1
 	}
+	// Columns count characters, not bytes.
+	for col := 1; col < n.Column; col++ {
+		_, size := utf8.DecodeRuneInString(d.content[pos:])
+		pos += size
+	}
+	return pos, nil
+}
 
-	// Locate the front matter boundaries within the original (untrimmed) content
-	fmStart := strings.Index(content, "---")
-	fmEnd := strings.Index(content[fmStart+3:], "---")
-	if fmEnd < 0 {
-		return fmt.Errorf("SKILL.md missing closing YAML frontmatter delimiter '---'")
+// scalarEnd returns the end offset of the single-line scalar token starting at start.
+func scalarEnd(content string, start int, style yaml.Style) int {
+	lineEnd := strings.IndexByte(content[start:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += start
 	}
-	fmEnd += fmStart + 3
+	line := content[start:lineEnd]
 
-	frontmatter := content[fmStart+3 : fmEnd]
-	if !versionLineRegex.MatchString(frontmatter) {
-		return nil
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		for i := 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				return start + i + 1
+			}
+		}
+	case style&yaml.SingleQuotedStyle != 0:
+		for i := 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return start + i + 1
+			}
+		}
+	default:
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		return start + len(strings.TrimRight(line, " \t\r"))
 	}
+	return lineEnd
+}
 
-	updatedFM := versionLineRegex.ReplaceAllString(frontmatter, "${1}"+newVersion)
-	updated := content[:fmStart+3] + updatedFM + content[fmEnd:]
+// UpdateSkillMetaVersion replaces the version value in the SKILL.md YAML front matter.
+// It only acts when a version field already exists; it never inserts a new one.
+func UpdateSkillMetaVersion(skillDir, newVersion string) error {
+	doc, err := readSkillDocument(skillDir)
+	if err != nil {
+		return err
+	}
 
+	updated, err := doc.setScalar("version", newVersion)
+	if err != nil {
+		return fmt.Errorf("failed to update SKILL.md version: %w", err)
+	}
+	if !updated {
+		return nil
+	}
+
+	skillMDPath := filepath.Join(skillDir, "SKILL.md")
 	// #nosec G306 G703 -- SKILL.md is a user-owned source file; path constructed from user-provided skill directory
-	if err := os.WriteFile(skillMDPath, []byte(updated), 0644); err != nil {
+	if err := os.WriteFile(skillMDPath, []byte(doc.content), 0644); err != nil {
 		return fmt.Errorf("failed to write updated SKILL.md: %w", err)
 	}
 	return nil
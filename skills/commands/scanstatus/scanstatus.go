@@ -0,0 +1,172 @@
+package scanstatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+type statusResult struct {
+	Name    string `col-name:"Name"`
+	Version string `col-name:"Version"`
+	Status  string `col-name:"Xray Status"`
+	Outcome string `col-name:"Outcome"`
+	Failed  string `col-name:"Failed"`
+	Reasons string `col-name:"Reasons"`
+}
+
+// ScanStatusCommand runs the Xray scan gate against an already-published skill version.
+type ScanStatusCommand struct {
+	serverDetails    *config.ServerDetails
+	repoKey          string
+	slug             string
+	version          string
+	scanReport       string
+	scanReportFormat string
+	failOn           []string
+	format           string
+	quiet            bool
+}
+
+func NewScanStatusCommand() *ScanStatusCommand {
+	return &ScanStatusCommand{}
+}
+
+func (sc *ScanStatusCommand) SetServerDetails(details *config.ServerDetails) *ScanStatusCommand {
+	sc.serverDetails = details
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetRepoKey(repoKey string) *ScanStatusCommand {
+	sc.repoKey = repoKey
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetSlug(slug string) *ScanStatusCommand {
+	sc.slug = slug
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetVersion(version string) *ScanStatusCommand {
+	sc.version = version
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetScanReport(path, format string) *ScanStatusCommand {
+	sc.scanReport = path
+	sc.scanReportFormat = format
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetFailOn(failOn []string) *ScanStatusCommand {
+	sc.failOn = failOn
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetFormat(format string) *ScanStatusCommand {
+	sc.format = format
+	return sc
+}
+
+func (sc *ScanStatusCommand) SetQuiet(quiet bool) *ScanStatusCommand {
+	sc.quiet = quiet
+	return sc
+}
+
+func (sc *ScanStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *ScanStatusCommand) CommandName() string {
+	return "skills_scan_status"
+}
+
+// Run queries the gate and prints its result. Unlike publish, a blocked version is never deleted.
+func (sc *ScanStatusCommand) Run() error {
+	result, gateErr := common.RunXrayGate(common.XrayGateParams{
+		ServerDetails: sc.serverDetails,
+		RepoKey:       sc.repoKey,
+		ArtifactPath:  fmt.Sprintf("%s/%s/%s-%s.zip", sc.slug, sc.version, sc.slug, sc.version),
+		Slug:          sc.slug,
+		Version:       sc.version,
+		Quiet:         sc.quiet,
+		FailOn:        sc.failOn,
+		ReportPath:    sc.scanReport,
+		ReportFormat:  sc.scanReportFormat,
+	})
+	if err := sc.print(result); err != nil {
+		return err
+	}
+	return gateErr
+}
+
+func (sc *ScanStatusCommand) print(result *common.XrayGateResult) error {
+	if strings.EqualFold(sc.format, "json") {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return coreutils.PrintTable([]statusResult{toStatusResult(result)}, "Xray scan status", "", false)
+}
+
+func toStatusResult(result *common.XrayGateResult) statusResult {
+	return statusResult{
+		Name:    result.Slug,
+		Version: result.Version,
+		Status:  result.Status,
+		Outcome: result.Outcome,
+		Failed:  fmt.Sprintf("%t", result.Failed),
+		Reasons: strings.Join(result.Reasons, "; "),
+	}
+}
+
+// RunScanStatus is the CLI action for `jf skills scan-status`.
+func RunScanStatus(c *components.Context) error {
+	if c.GetNumberOfArgs() != 2 {
+		return fmt.Errorf("usage: jf skills scan-status <slug> <version> [--repo <repo>] [options]")
+	}
+
+	failOn, err := common.ParseXrayGateFailOn(c.GetStringFlagValue("fail-on"))
+	if err != nil {
+		return err
+	}
+	scanReportFormat := c.GetStringFlagValue("scan-report-format")
+	if err := common.ValidateXrayReportFormat(scanReportFormat); err != nil {
+		return err
+	}
+
+	serverDetails, err := common.GetServerDetails(c)
+	if err != nil {
+		return err
+	}
+
+	quiet := common.IsQuiet(c)
+	repoKey, err := common.ResolveRepo(serverDetails, c.GetStringFlagValue("repo"), quiet)
+	if err != nil {
+		return err
+	}
+
+	format := "table"
+	if c.GetStringFlagValue("format") != "" {
+		format = c.GetStringFlagValue("format")
+	}
+
+	return NewScanStatusCommand().
+		SetServerDetails(serverDetails).
+		SetRepoKey(repoKey).
+		SetSlug(c.GetArgumentAt(0)).
+		SetVersion(c.GetArgumentAt(1)).
+		SetScanReport(c.GetStringFlagValue("scan-report"), scanReportFormat).
+		SetFailOn(failOn).
+		SetFormat(format).
+		SetQuiet(quiet).
+		Run()
+}
@@ -0,0 +1,29 @@
+package scanstatus
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStatusResult(t *testing.T) {
+	result := &common.XrayGateResult{
+		Slug:    "my-skill",
+		Version: "1.0.0",
+		Status:  "XRAY_DISABLED_FOR_REPO",
+		Outcome: common.XrayGateOutcomeUnscanned,
+		Reasons: []string{"Xray scanning is disabled for repository 'skills-local'"},
+		Failed:  true,
+	}
+
+	row := toStatusResult(result)
+	assert.Equal(t, statusResult{
+		Name:    "my-skill",
+		Version: "1.0.0",
+		Status:  "XRAY_DISABLED_FOR_REPO",
+		Outcome: "unscanned",
+		Failed:  "true",
+		Reasons: "Xray scanning is disabled for repository 'skills-local'",
+	}, row)
+}
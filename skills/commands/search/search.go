@@ -3,6 +3,7 @@ package search
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jfrog/jfrog-cli-artifactory/cliutils/flagkit"
@@ -129,12 +130,33 @@ func (sc *SearchCommand) printResults(results []searchResult) error {
 		return nil
 	}
 
+	sortResults(results)
 	if strings.EqualFold(sc.format, "json") {
 		return printJSON(results)
 	}
 	return printTable(results)
 }
 
+// sortResults orders results by name, then by semver precedence with the newest version first.
+// Versions that are not valid semver sort after valid ones, in lexical order.
+func sortResults(results []searchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		cmp, err := common.CompareVersions(results[i].Version, results[j].Version)
+		if err != nil {
+			_, iErr := common.ParseVersion(results[i].Version)
+			_, jErr := common.ParseVersion(results[j].Version)
+			if (iErr == nil) != (jErr == nil) {
+				return iErr == nil
+			}
+			return results[i].Version > results[j].Version
+		}
+		return cmp > 0
+	})
+}
+
 func printJSON(results []searchResult) error {
 	data, err := json.MarshalIndent(results, "", "  ")
 	if err != nil {
@@ -83,3 +83,21 @@ func TestPrintTableEmptyResults(t *testing.T) {
 
 	assert.Contains(t, output, "No skills found")
 }
+
+func TestSortResults(t *testing.T) {
+	results := []searchResult{
+		{Name: "b-skill", Version: "1.0.0"},
+		{Name: "a-skill", Version: "1.10.0"},
+		{Name: "a-skill", Version: "custom"},
+		{Name: "a-skill", Version: "1.2.0"},
+		{Name: "a-skill", Version: "1.10.0-rc.1"},
+	}
+
+	sortResults(results)
+
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Name+"@"+r.Version)
+	}
+	assert.Equal(t, []string{"a-skill@1.10.0", "a-skill@1.10.0-rc.1", "a-skill@1.2.0", "a-skill@custom", "b-skill@1.0.0"}, got)
+}
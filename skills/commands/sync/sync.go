@@ -0,0 +1,216 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/install"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// SyncCommand installs every skill pinned in a skills.lock file.
+// In update mode it re-resolves each skill to the newest version allowed by its
+// recorded constraint (or the latest version) and rewrites the lockfile.
+type SyncCommand struct {
+	serverDetails   *config.ServerDetails
+	installPath     string
+	lockFilePath    string
+	update          bool
+	force           bool
+	signaturePolicy common.SignaturePolicy
+	quiet           bool
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{signaturePolicy: common.SignaturePolicyOff}
+}
+
+func (sc *SyncCommand) SetServerDetails(details *config.ServerDetails) *SyncCommand {
+	sc.serverDetails = details
+	return sc
+}
+
+func (sc *SyncCommand) SetInstallPath(path string) *SyncCommand {
+	sc.installPath = path
+	return sc
+}
+
+func (sc *SyncCommand) SetLockFilePath(path string) *SyncCommand {
+	sc.lockFilePath = path
+	return sc
+}
+
+func (sc *SyncCommand) SetUpdate(update bool) *SyncCommand {
+	sc.update = update
+	return sc
+}
+
+func (sc *SyncCommand) SetForce(force bool) *SyncCommand {
+	sc.force = force
+	return sc
+}
+
+func (sc *SyncCommand) SetSignaturePolicy(policy common.SignaturePolicy) *SyncCommand {
+	sc.signaturePolicy = policy
+	return sc
+}
+
+func (sc *SyncCommand) SetQuiet(quiet bool) *SyncCommand {
+	sc.quiet = quiet
+	return sc
+}
+
+func (sc *SyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "skills_sync"
+}
+
+func (sc *SyncCommand) Run() error {
+	lockFilePath := sc.lockFilePath
+	if lockFilePath == "" {
+		lockFilePath = common.LockFilePath(sc.installPath)
+	}
+
+	lockFile, err := common.LoadLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+	if len(lockFile.Skills) == 0 {
+		return fmt.Errorf("no skills found in %s. Run 'jf skills install <slug>' first to create it", lockFilePath)
+	}
+
+	var errs []error
+	installed := 0
+	// Update mode may prune entries from the lockfile while iterating, so iterate over a copy.
+	locked := append([]common.LockEntry{}, lockFile.Skills...)
+	for _, entry := range locked {
+		// Update mode re-resolves dependencies from the skills that were installed directly.
+		if sc.update && entry.IsDependency() {
+			continue
+		}
+		newEntries, err := sc.syncEntry(entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Slug, err))
+			continue
+		}
+		if sc.update {
+			install.ReportPrunedDependencies(lockFile.RecordClosure(newEntries))
+		} else {
+			for _, newEntry := range newEntries {
+				lockFile.Upsert(newEntry)
+			}
+		}
+		installed += len(newEntries)
+	}
+
+	if sc.update && installed > 0 {
+		if err := lockFile.Save(lockFilePath); err != nil {
+			errs = append(errs, err)
+		} else {
+			log.Info(fmt.Sprintf("Updated %s", lockFilePath))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("skills sync failed for %d of %d skill(s):\n%w", len(errs), len(locked), errors.Join(errs...))
+	}
+	log.Info(fmt.Sprintf("%d skill(s) synced from %s.", installed, lockFilePath))
+	return nil
+}
+
+// syncEntry installs a locked skill. Pinned installs are verified against the recorded
+// SHA-256 and skip dependency resolution, since the lockfile already holds the whole closure.
+// Update mode resolves the newest allowed version, accepts its hash and re-resolves its dependencies.
+func (sc *SyncCommand) syncEntry(entry common.LockEntry) ([]common.LockEntry, error) {
+	if entry.Slug == "" || entry.Version == "" || entry.Repo == "" {
+		return nil, fmt.Errorf("invalid lockfile entry: slug, version and repo are required")
+	}
+
+	version := entry.Version
+	expectedSHA256 := entry.SHA256
+	if sc.update {
+		resolved, err := sc.resolveUpdate(entry)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != entry.Version {
+			log.Info(fmt.Sprintf("Updating skill '%s' from %s to %s", entry.Slug, entry.Version, resolved))
+			version = resolved
+			expectedSHA256 = ""
+		}
+	} else if expectedSHA256 == "" {
+		return nil, fmt.Errorf("lockfile entry for version %s has no sha256; run 'jf skills sync --update' to regenerate it", entry.Version)
+	}
+
+	targets, err := common.ParseTargetNames(entry.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	newEntries, err := install.NewInstallCommand().
+		SetServerDetails(sc.serverDetails).
+		SetRepoKey(entry.Repo).
+		SetSlug(entry.Slug).
+		SetVersion(version).
+		SetInstallPath(sc.installPath).
+		SetTargets(targets).
+		SetExpectedSHA256(expectedSHA256).
+		SetSkipDependencies(!sc.update).
+		SetForce(sc.force).
+		SetSignaturePolicy(sc.signaturePolicy).
+		SetQuiet(sc.quiet).
+		Install()
+	if err != nil {
+		return nil, err
+	}
+	newEntries[0].Constraint = entry.Constraint
+	newEntries[0].RequiredBy = entry.RequiredBy
+	return newEntries, nil
+}
+
+// resolveUpdate returns the newest version allowed by the entry's recorded constraint,
+// or the latest version when the skill was installed without a range.
+func (sc *SyncCommand) resolveUpdate(entry common.LockEntry) (string, error) {
+	versions, err := common.ListVersions(sc.serverDetails, entry.Repo, entry.Slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return "", fmt.Errorf("skill not found in repository '%s'", entry.Repo)
+		}
+		return "", fmt.Errorf("failed to list versions: %w", err)
+	}
+	versionStrs := make([]string, len(versions))
+	for i, v := range versions {
+		versionStrs[i] = v.Version
+	}
+	return common.ResolveVersionSpec(versionStrs, entry.Constraint)
+}
+
+// RunSync is the CLI action for `jf skills sync`.
+func RunSync(c *components.Context) error {
+	serverDetails, err := common.GetServerDetails(c)
+	if err != nil {
+		return err
+	}
+	signaturePolicy, err := common.ResolveSignaturePolicy(c.GetStringFlagValue("signature-policy"))
+	if err != nil {
+		return err
+	}
+
+	cmd := NewSyncCommand().
+		SetServerDetails(serverDetails).
+		SetInstallPath(c.GetStringFlagValue("path")).
+		SetLockFilePath(c.GetStringFlagValue("lockfile")).
+		SetUpdate(c.GetBoolFlagValue("update")).
+		SetForce(c.GetBoolFlagValue("force")).
+		SetSignaturePolicy(signaturePolicy).
+		SetQuiet(common.IsQuiet(c))
+
+	return cmd.Run()
+}
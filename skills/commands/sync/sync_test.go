@@ -0,0 +1,36 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCommand_EmptyLockFile(t *testing.T) {
+	err := NewSyncCommand().SetInstallPath(t.TempDir()).Run()
+	assert.ErrorContains(t, err, "no skills found")
+}
+
+func TestSyncCommand_EntryWithoutChecksum(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, common.LockFileName)
+	lf := &common.LockFile{}
+	lf.Upsert(common.LockEntry{Slug: "my-skill", Version: "1.0.0", Repo: "skills"})
+	require.NoError(t, lf.Save(lockPath))
+
+	err := NewSyncCommand().SetInstallPath(dir).SetLockFilePath(lockPath).Run()
+	assert.ErrorContains(t, err, "has no sha256")
+}
+
+func TestSyncCommand_InvalidEntry(t *testing.T) {
+	dir := t.TempDir()
+	lf := &common.LockFile{}
+	lf.Upsert(common.LockEntry{Slug: "my-skill", Version: "1.0.0"})
+	require.NoError(t, lf.Save(common.LockFilePath(dir)))
+
+	err := NewSyncCommand().SetInstallPath(dir).Run()
+	assert.ErrorContains(t, err, "invalid lockfile entry")
+}
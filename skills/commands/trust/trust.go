@@ -0,0 +1,151 @@
+package trust
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	actionAdd    = "add"
+	actionList   = "list"
+	actionRemove = "remove"
+)
+
+type keyResult struct {
+	Name        string `json:"name" col-name:"Name"`
+	Fingerprint string `json:"fingerprint" col-name:"SHA-256 Fingerprint"`
+	Path        string `json:"path" col-name:"Path"`
+}
+
+// TrustCommand manages the local trust store of public keys that skill signatures are verified against.
+type TrustCommand struct {
+	trustStoreDir string
+	action        string
+	// arg is the public key path for add and the key name for remove.
+	arg    string
+	name   string
+	format string
+}
+
+func NewTrustCommand() *TrustCommand {
+	return &TrustCommand{}
+}
+
+func (tc *TrustCommand) SetTrustStoreDir(dir string) *TrustCommand {
+	tc.trustStoreDir = dir
+	return tc
+}
+
+func (tc *TrustCommand) SetAction(action string) *TrustCommand {
+	tc.action = action
+	return tc
+}
+
+func (tc *TrustCommand) SetArg(arg string) *TrustCommand {
+	tc.arg = arg
+	return tc
+}
+
+func (tc *TrustCommand) SetName(name string) *TrustCommand {
+	tc.name = name
+	return tc
+}
+
+func (tc *TrustCommand) SetFormat(format string) *TrustCommand {
+	tc.format = format
+	return tc
+}
+
+func (tc *TrustCommand) CommandName() string {
+	return "skills_trust"
+}
+
+func (tc *TrustCommand) Run() error {
+	trustStore, err := common.LoadTrustStore(tc.trustStoreDir)
+	if err != nil {
+		return err
+	}
+
+	switch tc.action {
+	case actionAdd:
+		name := tc.name
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(tc.arg), filepath.Ext(tc.arg))
+		}
+		key, err := trustStore.Add(tc.arg, name)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Added key '%s' (SHA-256 fingerprint %s) to the trust store %s", key.Name, key.Fingerprint(), trustStore.Dir))
+		return nil
+	case actionRemove:
+		if err := trustStore.Remove(tc.arg); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Removed key '%s' from the trust store %s", tc.arg, trustStore.Dir))
+		return nil
+	case actionList:
+		return tc.list(trustStore)
+	default:
+		return fmt.Errorf("unknown action '%s': expected add, list or remove", tc.action)
+	}
+}
+
+func (tc *TrustCommand) list(trustStore *common.TrustStore) error {
+	results := make([]keyResult, 0, len(trustStore.Keys))
+	for i := range trustStore.Keys {
+		key := &trustStore.Keys[i]
+		results = append(results, keyResult{Name: key.Name, Fingerprint: key.Fingerprint(), Path: key.Path})
+	}
+	if strings.EqualFold(tc.format, "json") {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return coreutils.PrintTable(results, "Trusted keys", fmt.Sprintf("No trusted keys in %s", trustStore.Dir), false)
+}
+
+// RunTrust is the CLI action for `jf skills trust`.
+func RunTrust(c *components.Context) error {
+	usage := fmt.Errorf("usage: jf skills trust add <public-key> [--name <name>] | jf skills trust list | jf skills trust remove <name>")
+	if c.GetNumberOfArgs() < 1 {
+		return usage
+	}
+	action := c.GetArgumentAt(0)
+	arg := ""
+	switch {
+	case action == actionList && c.GetNumberOfArgs() == 1:
+	case (action == actionAdd || action == actionRemove) && c.GetNumberOfArgs() == 2:
+		arg = c.GetArgumentAt(1)
+	default:
+		return usage
+	}
+
+	dir, err := common.TrustStoreDir()
+	if err != nil {
+		return err
+	}
+
+	format := "table"
+	if c.GetStringFlagValue("format") != "" {
+		format = c.GetStringFlagValue("format")
+	}
+
+	return NewTrustCommand().
+		SetTrustStoreDir(dir).
+		SetAction(action).
+		SetArg(arg).
+		SetName(c.GetStringFlagValue("name")).
+		SetFormat(format).
+		Run()
+}
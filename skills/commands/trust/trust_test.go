@@ -0,0 +1,50 @@
+package trust
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePublicKey(t *testing.T, dir string) string {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "release-2026.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	return path
+}
+
+func TestTrustCommand(t *testing.T) {
+	storeDir := filepath.Join(t.TempDir(), "trusted-keys")
+	keyPath := writePublicKey(t, t.TempDir())
+
+	// The key name defaults to the file name without its extension.
+	require.NoError(t, NewTrustCommand().SetTrustStoreDir(storeDir).SetAction("add").SetArg(keyPath).Run())
+	require.NoError(t, NewTrustCommand().SetTrustStoreDir(storeDir).SetAction("add").SetArg(keyPath).SetName("ci").Run())
+
+	trustStore, err := common.LoadTrustStore(storeDir)
+	require.NoError(t, err)
+	require.Len(t, trustStore.Keys, 2)
+	assert.Equal(t, "ci", trustStore.Keys[0].Name)
+	assert.Equal(t, "release-2026", trustStore.Keys[1].Name)
+
+	require.NoError(t, NewTrustCommand().SetTrustStoreDir(storeDir).SetAction("list").SetFormat("json").Run())
+
+	require.NoError(t, NewTrustCommand().SetTrustStoreDir(storeDir).SetAction("remove").SetArg("ci").Run())
+	trustStore, err = common.LoadTrustStore(storeDir)
+	require.NoError(t, err)
+	require.Len(t, trustStore.Keys, 1)
+
+	err = NewTrustCommand().SetTrustStoreDir(storeDir).SetAction("rotate").Run()
+	assert.ErrorContains(t, err, "unknown action 'rotate'")
+}
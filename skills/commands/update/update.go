@@ -0,0 +1,284 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/commands/install"
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// UpdateCommand upgrades installed skills in place to the newest version available in
+// the repository they were installed from, within the range recorded in skills.lock.
+// It prints the file changes of every upgrade and refuses to overwrite local edits unless forced.
+type UpdateCommand struct {
+	serverDetails *config.ServerDetails
+	installPath   string
+	lockFilePath  string
+	// slug limits the update to a single installed skill; empty updates every directly installed skill.
+	slug            string
+	force           bool
+	dryRun          bool
+	signaturePolicy common.SignaturePolicy
+	quiet           bool
+}
+
+func NewUpdateCommand() *UpdateCommand {
+	return &UpdateCommand{signaturePolicy: common.SignaturePolicyOff}
+}
+
+func (uc *UpdateCommand) SetServerDetails(details *config.ServerDetails) *UpdateCommand {
+	uc.serverDetails = details
+	return uc
+}
+
+func (uc *UpdateCommand) SetInstallPath(path string) *UpdateCommand {
+	uc.installPath = path
+	return uc
+}
+
+func (uc *UpdateCommand) SetLockFilePath(path string) *UpdateCommand {
+	uc.lockFilePath = path
+	return uc
+}
+
+func (uc *UpdateCommand) SetSlug(slug string) *UpdateCommand {
+	uc.slug = slug
+	return uc
+}
+
+func (uc *UpdateCommand) SetForce(force bool) *UpdateCommand {
+	uc.force = force
+	return uc
+}
+
+func (uc *UpdateCommand) SetDryRun(dryRun bool) *UpdateCommand {
+	uc.dryRun = dryRun
+	return uc
+}
+
+func (uc *UpdateCommand) SetSignaturePolicy(policy common.SignaturePolicy) *UpdateCommand {
+	uc.signaturePolicy = policy
+	return uc
+}
+
+func (uc *UpdateCommand) SetQuiet(quiet bool) *UpdateCommand {
+	uc.quiet = quiet
+	return uc
+}
+
+func (uc *UpdateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return uc.serverDetails, nil
+}
+
+func (uc *UpdateCommand) CommandName() string {
+	return "skills_update"
+}
+
+func (uc *UpdateCommand) Run() error {
+	lockFilePath := uc.lockFilePath
+	if lockFilePath == "" {
+		lockFilePath = common.LockFilePath(uc.installPath)
+	}
+	lockFile, err := common.LoadLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+
+	installed, err := common.ListInstalledSkills(uc.installPath)
+	if err != nil {
+		return err
+	}
+	targetSkills, err := common.ListTargetSkills(uc.installPath, lockFile)
+	if err != nil {
+		return err
+	}
+	installed = append(installed, targetSkills...)
+	skills, err := selectSkills(installed, lockFile, uc.slug)
+	if err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		installBase := uc.installPath
+		if installBase == "" {
+			installBase = "."
+		}
+		return fmt.Errorf("no installed skills found in %s. Run 'jf skills install <slug>' first", installBase)
+	}
+
+	var errs []error
+	updated := 0
+	for _, skill := range skills {
+		entries, err := uc.updateSkill(skill.Manifest, lockFile.Find(skill.Manifest.Slug))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", skill.Manifest.Slug, err))
+			continue
+		}
+		install.ReportPrunedDependencies(lockFile.RecordClosure(entries))
+		if len(entries) > 0 {
+			updated++
+		}
+	}
+
+	if updated > 0 && !uc.dryRun {
+		if err := lockFile.Save(lockFilePath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("skills update failed for %d of %d skill(s):\n%w", len(errs), len(skills), errors.Join(errs...))
+	}
+
+	switch {
+	case updated == 0:
+		log.Info("All skills are up to date.")
+	case uc.dryRun:
+		log.Info(fmt.Sprintf("%d skill(s) can be updated. Run without --dry-run to apply.", updated))
+	default:
+		log.Info(fmt.Sprintf("%d skill(s) updated.", updated))
+	}
+	return nil
+}
+
+// selectSkills returns the installed skills to update. Without a slug, skills that were only
+// installed as dependencies are skipped: they are re-resolved from the skills that need them.
+// Skills installed from offline bundles are skipped too, since they have no source repository.
+// A skill installed into several targets is selected once: updating it updates every target.
+func selectSkills(installed []common.InstalledSkill, lockFile *common.LockFile, slug string) ([]common.InstalledSkill, error) {
+	if slug != "" {
+		for _, skill := range installed {
+			if skill.Manifest.Slug == slug {
+				return []common.InstalledSkill{skill}, nil
+			}
+		}
+		return nil, fmt.Errorf("skill '%s' is not installed (no %s found)", slug, common.ManifestFileName)
+	}
+
+	var selected []common.InstalledSkill
+	seen := map[string]bool{}
+	for _, skill := range installed {
+		if seen[skill.Manifest.Slug] {
+			continue
+		}
+		seen[skill.Manifest.Slug] = true
+		if entry := lockFile.Find(skill.Manifest.Slug); entry != nil && entry.IsDependency() {
+			continue
+		}
+		if skill.Manifest.Repo == "" {
+			log.Debug(fmt.Sprintf("Skipping skill '%s': installed from an offline bundle", skill.Manifest.Slug))
+			continue
+		}
+		selected = append(selected, skill)
+	}
+	return selected, nil
+}
+
+// updateSkill upgrades a single installed skill and returns the resulting lockfile entries,
+// or nothing when the skill is already up to date.
+func (uc *UpdateCommand) updateSkill(manifest *common.InstallManifest, lockEntry *common.LockEntry) ([]common.LockEntry, error) {
+	if manifest.Repo == "" {
+		return nil, fmt.Errorf("the skill was installed from an offline bundle; install a newer bundle with 'jf skills install --from-file'")
+	}
+
+	constraint := ""
+	if lockEntry != nil {
+		constraint = lockEntry.Constraint
+	}
+	latest, err := uc.resolveUpdate(manifest, constraint)
+	if err != nil {
+		return nil, err
+	}
+	if !isNewer(latest, manifest.Version) {
+		log.Info(fmt.Sprintf("Skill '%s' is up to date (%s)", manifest.Slug, manifest.Version))
+		return nil, nil
+	}
+	log.Info(fmt.Sprintf("Updating skill '%s' from %s to %s", manifest.Slug, manifest.Version, latest))
+
+	var targets []common.InstallTarget
+	if lockEntry != nil {
+		if targets, err = common.ParseTargetNames(lockEntry.Targets); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := install.NewInstallCommand().
+		SetServerDetails(uc.serverDetails).
+		SetRepoKey(manifest.Repo).
+		SetSlug(manifest.Slug).
+		SetVersion(latest).
+		SetInstallPath(uc.installPath).
+		SetTargets(targets).
+		SetForce(uc.force).
+		SetSignaturePolicy(uc.signaturePolicy).
+		SetShowDiff(true).
+		SetDryRun(uc.dryRun).
+		SetQuiet(uc.quiet).
+		Install()
+	if err != nil {
+		return nil, err
+	}
+	entries[0].Constraint = constraint
+	if lockEntry != nil {
+		entries[0].RequiredBy = lockEntry.RequiredBy
+	}
+	return entries, nil
+}
+
+// resolveUpdate returns the newest version allowed by the constraint, or the latest version when there is none.
+func (uc *UpdateCommand) resolveUpdate(manifest *common.InstallManifest, constraint string) (string, error) {
+	versions, err := common.ListVersions(uc.serverDetails, manifest.Repo, manifest.Slug)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return "", fmt.Errorf("skill not found in repository '%s'", manifest.Repo)
+		}
+		return "", fmt.Errorf("failed to list versions: %w", err)
+	}
+	versionStrs := make([]string, len(versions))
+	for i, v := range versions {
+		versionStrs[i] = v.Version
+	}
+	return common.ResolveVersionSpec(versionStrs, constraint)
+}
+
+// isNewer reports whether candidate should replace the installed version. Versions that are
+// not valid semver cannot be ordered, so any different version counts as newer.
+func isNewer(candidate, installed string) bool {
+	cmp, err := common.CompareVersions(candidate, installed)
+	if err != nil {
+		return candidate != installed
+	}
+	return cmp > 0
+}
+
+// RunUpdate is the CLI action for `jf skills update`.
+func RunUpdate(c *components.Context) error {
+	slug := ""
+	if c.GetNumberOfArgs() > 0 {
+		slug = c.GetArgumentAt(0)
+	}
+
+	serverDetails, err := common.GetServerDetails(c)
+	if err != nil {
+		return err
+	}
+	signaturePolicy, err := common.ResolveSignaturePolicy(c.GetStringFlagValue("signature-policy"))
+	if err != nil {
+		return err
+	}
+
+	cmd := NewUpdateCommand().
+		SetServerDetails(serverDetails).
+		SetInstallPath(c.GetStringFlagValue("path")).
+		SetLockFilePath(c.GetStringFlagValue("lockfile")).
+		SetSlug(slug).
+		SetForce(c.GetBoolFlagValue("force")).
+		SetSignaturePolicy(signaturePolicy).
+		SetDryRun(c.GetBoolFlagValue("dry-run")).
+		SetQuiet(common.IsQuiet(c))
+
+	return cmd.Run()
+}
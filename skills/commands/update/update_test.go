@@ -0,0 +1,54 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/skills/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func installed(slugs ...string) []common.InstalledSkill {
+	skills := make([]common.InstalledSkill, len(slugs))
+	for i, slug := range slugs {
+		skills[i] = common.InstalledSkill{Dir: slug, Manifest: &common.InstallManifest{Slug: slug, Version: "1.0.0", Repo: "skills-local"}}
+	}
+	return skills
+}
+
+func TestSelectSkills(t *testing.T) {
+	lockFile := &common.LockFile{}
+	lockFile.Upsert(common.LockEntry{Slug: "app", Version: "1.0.0"})
+	lockFile.Upsert(common.LockEntry{Slug: "helpers", Version: "1.0.0", RequiredBy: []string{"app"}})
+
+	// Dependencies are updated through the skills that need them, and bundles have no repository to update from.
+	bundled := installed("offline")
+	bundled[0].Manifest.Repo = ""
+	skills, err := selectSkills(append(installed("app", "helpers", "manual"), bundled...), lockFile, "")
+	require.NoError(t, err)
+	require.Len(t, skills, 2)
+	assert.Equal(t, "app", skills[0].Manifest.Slug)
+	assert.Equal(t, "manual", skills[1].Manifest.Slug)
+
+	// A skill installed into several targets is updated once.
+	skills, err = selectSkills(installed("app", "app"), lockFile, "")
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+
+	skills, err = selectSkills(installed("app", "helpers"), lockFile, "helpers")
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+	assert.Equal(t, "helpers", skills[0].Manifest.Slug)
+
+	_, err = selectSkills(installed("app"), lockFile, "missing")
+	assert.ErrorContains(t, err, "skill 'missing' is not installed")
+}
+
+func TestIsNewer(t *testing.T) {
+	assert.True(t, isNewer("1.1.0", "1.0.0"))
+	assert.True(t, isNewer("1.0.0", "1.0.0-beta.1"))
+	assert.False(t, isNewer("1.0.0", "1.0.0"))
+	assert.False(t, isNewer("1.0.0", "1.2.0"))
+	assert.True(t, isNewer("nightly-2", "nightly-1"))
+	assert.False(t, isNewer("nightly-1", "nightly-1"))
+}
@@ -0,0 +1,156 @@
+package common
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+)
+
+const (
+	// BundleMetadataSuffix is appended to a bundle zip path to name its sidecar metadata file.
+	BundleMetadataSuffix = ".metadata.json"
+	// bundleFormatVersion is bumped whenever the sidecar layout changes incompatibly.
+	bundleFormatVersion = 1
+)
+
+// BundleMetadata is the sidecar written next to an offline skill bundle by `skills pack`.
+// It pins the bundle zip's digest and optionally carries a signed attestation of it.
+type BundleMetadata struct {
+	BundleVersion int    `json:"bundleVersion"`
+	Slug          string `json:"slug"`
+	Version       string `json:"version"`
+	Description   string `json:"description,omitempty"`
+	FileName      string `json:"fileName"`
+	SHA256        string `json:"sha256"`
+	CreatedAt     string `json:"createdAt"`
+	// Attestation is a DSSE envelope over an in-toto statement whose subject is the bundle zip.
+	Attestation *dsse.Envelope `json:"attestation,omitempty"`
+}
+
+// InTotoStatement is the attestation signed into a bundle.
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// attestedSkill is the part of the attestation predicate that names the attested skill.
+type attestedSkill struct {
+	Skill   string `json:"skill"`
+	Version string `json:"version"`
+}
+
+// BundleMetadataPath returns the sidecar path of the bundle zip at zipPath.
+func BundleMetadataPath(zipPath string) string {
+	return zipPath + BundleMetadataSuffix
+}
+
+// ReadBundleMetadata reads the sidecar of the bundle zip at zipPath.
+func ReadBundleMetadata(zipPath string) (*BundleMetadata, error) {
+	path := BundleMetadataPath(zipPath)
+	// #nosec G304 -- path is derived from the bundle path provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle metadata: %w", err)
+	}
+	m := &BundleMetadata{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle metadata %s: %w", path, err)
+	}
+	if m.BundleVersion > bundleFormatVersion {
+		return nil, fmt.Errorf("bundle metadata %s has unsupported version %d (max supported: %d)", path, m.BundleVersion, bundleFormatVersion)
+	}
+	if m.Slug == "" || m.Version == "" || m.SHA256 == "" {
+		return nil, fmt.Errorf("bundle metadata %s is incomplete: slug, version and sha256 are required", path)
+	}
+	return m, nil
+}
+
+// Save writes the sidecar of the bundle zip at zipPath.
+func (m *BundleMetadata) Save(zipPath string) error {
+	m.BundleVersion = bundleFormatVersion
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle metadata: %w", err)
+	}
+	path := BundleMetadataPath(zipPath)
+	// #nosec G306 -- bundle metadata is meant to be shared alongside the bundle
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write bundle metadata %s: %w", path, err)
+	}
+	return nil
+}
+
+// NewInTotoStatement returns the statement attesting the bundle zip with the given predicate.
+func NewInTotoStatement(fileName, sha256Hex, predicateType string, predicate []byte) ([]byte, error) {
+	statement := InTotoStatement{
+		Type:          dsse.InTotoStatementType,
+		Subject:       []InTotoSubject{{Name: fileName, Digest: map[string]string{"sha256": sha256Hex}}},
+		PredicateType: predicateType,
+		Predicate:     predicate,
+	}
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attestation statement: %w", err)
+	}
+	return data, nil
+}
+
+// VerifyAttestation checks the bundle's signed attestation with the public key at publicKeyPath
+// and that it attests the bundle's skill, version and digest.
+func (m *BundleMetadata) VerifyAttestation(publicKeyPath string) error {
+	if m.Attestation == nil {
+		return fmt.Errorf("bundle of skill '%s' version '%s' is not signed", m.Slug, m.Version)
+	}
+	key, err := dsse.LoadPublicKey(publicKeyPath)
+	if err != nil {
+		return err
+	}
+	return verifyAttestation(m.Attestation, key, m.Slug, m.Version, m.SHA256)
+}
+
+// verifyAttestation checks that envelope is an in-toto statement signed by key whose subject has the
+// given digest, and whose predicate names the given skill and version.
+func verifyAttestation(envelope *dsse.Envelope, key crypto.PublicKey, slug, version, sha256Hex string) error {
+	if envelope.PayloadType != dsse.InTotoPayloadType {
+		return fmt.Errorf("unsupported attestation payload type '%s'", envelope.PayloadType)
+	}
+	payload, err := envelope.VerifyWithKey(key)
+	if err != nil {
+		return fmt.Errorf("attestation verification failed: %w", err)
+	}
+
+	statement := InTotoStatement{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return fmt.Errorf("invalid attestation statement: %w", err)
+	}
+	covered := false
+	for _, subject := range statement.Subject {
+		if strings.EqualFold(subject.Digest["sha256"], sha256Hex) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return fmt.Errorf("attestation does not cover the digest %s", sha256Hex)
+	}
+
+	attested := attestedSkill{}
+	if err := json.Unmarshal(statement.Predicate, &attested); err != nil {
+		return fmt.Errorf("invalid attestation predicate: %w", err)
+	}
+	if attested.Skill != slug || attested.Version != version {
+		return fmt.Errorf("attestation is for skill '%s' version '%s', not '%s' version '%s'", attested.Skill, attested.Version, slug, version)
+	}
+	return nil
+}
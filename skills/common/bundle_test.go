@@ -0,0 +1,86 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a PEM private key and its PKIX public key into dir and returns their paths.
+func writeKeyPair(t *testing.T, dir, name string, private crypto.Signer) (string, string) {
+	privDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+
+	privPath := filepath.Join(dir, name+".key")
+	pubPath := filepath.Join(dir, name+".pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644))
+	return privPath, pubPath
+}
+
+func TestBundleMetadata_SaveAndRead(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "my-skill-1.0.0.zip")
+	m := &BundleMetadata{Slug: "my-skill", Version: "1.0.0", FileName: "my-skill-1.0.0.zip", SHA256: "abc", CreatedAt: "2026-01-01T00:00:00Z"}
+	require.NoError(t, m.Save(zipPath))
+	assert.FileExists(t, zipPath+BundleMetadataSuffix)
+
+	loaded, err := ReadBundleMetadata(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, bundleFormatVersion, loaded.BundleVersion)
+	assert.Equal(t, "my-skill", loaded.Slug)
+	assert.Equal(t, "abc", loaded.SHA256)
+	assert.Nil(t, loaded.Attestation)
+}
+
+func TestReadBundleMetadata_Invalid(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "bundle.zip")
+	_, err := ReadBundleMetadata(zipPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(BundleMetadataPath(zipPath), []byte(`{"bundleVersion": 1, "slug": "x"}`), 0644))
+	_, err = ReadBundleMetadata(zipPath)
+	assert.ErrorContains(t, err, "is incomplete")
+
+	require.NoError(t, os.WriteFile(BundleMetadataPath(zipPath), []byte(`{"bundleVersion": 99}`), 0644))
+	_, err = ReadBundleMetadata(zipPath)
+	assert.ErrorContains(t, err, "unsupported version")
+}
+
+func TestBundleMetadata_VerifyAttestation(t *testing.T) {
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPath, pubPath := writeKeyPair(t, dir, "signer", key)
+
+	statement, err := NewInTotoStatement("my-skill-1.0.0.zip", "abc", "https://example.com/predicate", []byte(`{"skill":"my-skill","version":"1.0.0"}`))
+	require.NoError(t, err)
+	env, err := dsse.SignEnvelope(dsse.InTotoPayloadType, statement, privPath, "")
+	require.NoError(t, err)
+
+	m := &BundleMetadata{Slug: "my-skill", Version: "1.0.0", SHA256: "abc", Attestation: env}
+	require.NoError(t, m.VerifyAttestation(pubPath))
+
+	// A valid signature over another skill or version must not vouch for this bundle.
+	m.Version = "2.0.0"
+	assert.ErrorContains(t, m.VerifyAttestation(pubPath), "attestation is for skill 'my-skill' version '1.0.0'")
+	m.Version = "1.0.0"
+
+	// A valid signature over a different digest must not vouch for this bundle.
+	m.SHA256 = "def"
+	assert.ErrorContains(t, m.VerifyAttestation(pubPath), "does not cover the digest")
+
+	m.Attestation = nil
+	assert.ErrorContains(t, m.VerifyAttestation(pubPath), "is not signed")
+}
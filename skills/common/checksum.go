@@ -0,0 +1,32 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ComputeSHA256 returns the hex-encoded SHA-256 digest of the file at path.
+func ComputeSHA256(path string) (string, error) {
+	if strings.Contains(path, "..") {
+		return "", fmt.Errorf("invalid path: contains traversal sequence")
+	}
+	cleanPath := filepath.Clean(path)
+	f, err := os.Open(cleanPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,88 +1,121 @@
 package common
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
 	"strings"
 
-	"github.com/jfrog/jfrog-cli-evidence/evidence/create"
-	"github.com/jfrog/jfrog-cli-evidence/evidence/verify"
-
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/evidenceutils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
-	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	evidenceservices "github.com/jfrog/jfrog-client-go/evidence/services"
+	"github.com/jfrog/jfrog-client-go/onemodel"
 )
 
-type CreateEvidenceOpts struct {
-	SubjectRepoPath string
-	SubjectSHA256   string
-	PredicatePath   string
-	PredicateType   string
-	MarkdownPath    string
-	KeyPath         string
-	KeyAlias        string
+// searchEvidenceQueryTemplate finds the evidence attached to an artifact, given its repository, path and name as GraphQL strings.
+const searchEvidenceQueryTemplate = `{ evidence { searchEvidence( where: { hasSubjectWith: { repositoryKey: %s, path: %s, name: %s }} ) { edges { node { downloadPath predicateType } } } } }`
+
+// EvidenceUploader attaches a signed evidence file to a subject.
+type EvidenceUploader interface {
+	UploadEvidence(evidenceDetails evidenceservices.EvidenceDetails) ([]byte, error)
 }
 
-type VerifyEvidenceOpts struct {
-	SubjectRepoPath string
+// EvidenceClients are the services used to read the evidence attached to an artifact and attach it to another one.
+type EvidenceClients struct {
+	Artifactory artifactory.ArtifactoryServicesManager
+	Onemodel    onemodel.Manager
+	Evidence    EvidenceUploader
 }
 
-// CreateEvidence attaches a signed publish-attestation to an artifact using jfrog-cli-evidence programmatically.
-func CreateEvidence(serverDetails *config.ServerDetails, opts CreateEvidenceOpts) error {
-	ensureServiceUrls(serverDetails)
-	cmd := create.NewCreateEvidenceCustom(
-		serverDetails,
-		opts.PredicatePath,
-		opts.PredicateType,
-		opts.MarkdownPath,
-		opts.KeyPath,
-		opts.KeyAlias,
-		opts.SubjectRepoPath,
-		opts.SubjectSHA256,
-		"", "", "",
-		"", "", "",
-	)
-	return cmd.Run()
+// NewEvidenceClients creates the evidence clients of the server, reusing the given Artifactory services manager.
+func NewEvidenceClients(serverDetails *config.ServerDetails, sm artifactory.ArtifactoryServicesManager) (*EvidenceClients, error) {
+	evidenceutils.EnsureServiceUrls(serverDetails)
+	onemodelManager, err := utils.CreateOnemodelServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	evidenceManager, err := utils.CreateEvidenceServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	return &EvidenceClients{Artifactory: sm, Onemodel: onemodelManager, Evidence: evidenceManager}, nil
 }
 
-// VerifyEvidence verifies publish-attestation evidence on an artifact using Artifactory keys.
-func VerifyEvidence(serverDetails *config.ServerDetails, opts VerifyEvidenceOpts) error {
-	ensureServiceUrls(serverDetails)
-	cmd := verify.NewVerifyEvidenceCustom(
-		serverDetails,
-		opts.SubjectRepoPath,
-		"plaintext",
-		nil,
-		true,
-	)
-	return cmd.Run()
+type searchEvidenceResponse struct {
+	Data struct {
+		Evidence struct {
+			SearchEvidence struct {
+				Edges []struct {
+					Node struct {
+						DownloadPath  string `json:"downloadPath"`
+						PredicateType string `json:"predicateType"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"searchEvidence"`
+		} `json:"evidence"`
+	} `json:"data"`
 }
 
-// ensureServiceUrls derives the platform URL from ArtifactoryUrl and populates
-// service-specific URLs that the evidence library requires.
-func ensureServiceUrls(sd *config.ServerDetails) {
-	if sd.Url != "" {
-		platformBase := clientutils.AddTrailingSlashIfNeeded(sd.Url)
-		if sd.OnemodelUrl == "" {
-			sd.OnemodelUrl = platformBase + "onemodel/"
-		}
-		if sd.EvidenceUrl == "" {
-			sd.EvidenceUrl = platformBase + "evidence/"
-		}
-		return
+// CopyEvidence attaches the evidence of the artifact at fromRepoPath to the artifact at toRepoPath,
+// which must have the same content. The signed files are attached as they are, so the evidence keeps
+// its original signer and predicate. It returns the number of evidence attached.
+func (ec *EvidenceClients) CopyEvidence(fromRepoPath, toRepoPath string) (int, error) {
+	repoKey, itemPath, _ := strings.Cut(fromRepoPath, "/")
+	query, err := searchEvidenceQuery(repoKey, path.Dir(itemPath), path.Base(itemPath))
+	if err != nil {
+		return 0, err
+	}
+	body, err := ec.Onemodel.GraphqlQuery(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search the evidence of %s: %w", fromRepoPath, err)
+	}
+	response := searchEvidenceResponse{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("failed to parse the evidence of %s: %w", fromRepoPath, err)
 	}
 
-	if sd.ArtifactoryUrl == "" {
-		return
+	copied := 0
+	var errs []error
+	for _, edge := range response.Data.Evidence.SearchEvidence.Edges {
+		if err = ec.attachEvidenceFile(edge.Node.DownloadPath, toRepoPath); err != nil {
+			errs = append(errs, fmt.Errorf("%s evidence: %w", edge.Node.PredicateType, err))
+			continue
+		}
+		copied++
 	}
+	return copied, errors.Join(errs...)
+}
 
-	platformBase := sd.ArtifactoryUrl
-	platformBase = strings.TrimRight(platformBase, "/")
-	platformBase = strings.TrimSuffix(platformBase, "/artifactory")
-	platformBase = clientutils.AddTrailingSlashIfNeeded(platformBase)
+// searchEvidenceQuery returns the request that searches the evidence of an artifact. A JSON string is a valid GraphQL
+// string, so the values are JSON-encoded into the query, which is itself JSON-encoded into the request.
+func searchEvidenceQuery(repoKey, itemPath, name string) ([]byte, error) {
+	values := make([]any, 3)
+	for i, value := range []string{repoKey, itemPath, name} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = string(encoded)
+	}
+	return json.Marshal(map[string]string{"query": fmt.Sprintf(searchEvidenceQueryTemplate, values...)})
+}
 
-	sd.Url = platformBase
-	if sd.OnemodelUrl == "" {
-		sd.OnemodelUrl = platformBase + "onemodel/"
+func (ec *EvidenceClients) attachEvidenceFile(downloadPath, subjectRepoPath string) error {
+	reader, err := ec.Artifactory.ReadRemoteFile(downloadPath)
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(reader)
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
 	}
-	if sd.EvidenceUrl == "" {
-		sd.EvidenceUrl = platformBase + "evidence/"
+	if err != nil {
+		return err
 	}
+	_, err = ec.Evidence.UploadEvidence(evidenceservices.EvidenceDetails{SubjectUri: subjectRepoPath, DSSEFileRaw: content})
+	return err
 }
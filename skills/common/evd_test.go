@@ -0,0 +1,17 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchEvidenceQuery(t *testing.T) {
+	query, err := searchEvidenceQuery("skills-dev", `my-skill/1.0.0"} , name: "other`, `a\b.zip`)
+	require.NoError(t, err)
+	var request map[string]string
+	require.NoError(t, json.Unmarshal(query, &request))
+	assert.Equal(t, `{ evidence { searchEvidence( where: { hasSubjectWith: { repositoryKey: "skills-dev", path: "my-skill/1.0.0\"} , name: \"other", name: "a\\b.zip" }} ) { edges { node { downloadPath predicateType } } } } }`, request["query"])
+}
@@ -0,0 +1,217 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// LockFileName is the default name of the skills lockfile, written next to the installed skills.
+	LockFileName = "skills.lock"
+	// lockFileFormatVersion is bumped whenever the lockfile layout changes incompatibly.
+	lockFileFormatVersion = 1
+
+	EvidenceStatusVerified   = "verified"
+	EvidenceStatusUnverified = "unverified"
+)
+
+// LockFile pins the exact skill versions installed into a project so that every
+// developer and CI run installs byte-identical skills.
+type LockFile struct {
+	LockfileVersion int         `json:"lockfileVersion"`
+	Skills          []LockEntry `json:"skills"`
+}
+
+// LockEntry records a single installed skill.
+type LockEntry struct {
+	Slug    string `json:"slug"`
+	Version string `json:"version"`
+	// Constraint is the version range originally requested (e.g. "^1.2"), if any.
+	// `skills sync --update` re-resolves within it instead of jumping to the latest version.
+	Constraint string `json:"constraint,omitempty"`
+	Repo       string `json:"repo"`
+	SHA256     string `json:"sha256"`
+	Evidence   string `json:"evidence"`
+	// SignedBy names the trust store key that verified the skill's signature on install, if any.
+	SignedBy string `json:"signedBy,omitempty"`
+	// Targets lists the install targets (e.g. "claude", "cursor:global") the skill was installed into.
+	// It is empty for skills installed into the install path itself.
+	Targets []string `json:"targets,omitempty"`
+	// RequiredBy lists the skills that pulled this one in as a dependency.
+	// It is empty for skills that were installed directly.
+	RequiredBy []string `json:"requiredBy,omitempty"`
+}
+
+// IsDependency reports whether the skill was only installed as a dependency of other skills.
+func (e *LockEntry) IsDependency() bool {
+	return len(e.RequiredBy) > 0
+}
+
+// LockFilePath returns the lockfile location for the given install base directory.
+func LockFilePath(installBase string) string {
+	if installBase == "" {
+		installBase = "."
+	}
+	return filepath.Join(installBase, LockFileName)
+}
+
+// LoadLockFile reads a lockfile from disk. A missing file yields an empty lockfile.
+func LoadLockFile(path string) (*LockFile, error) {
+	// #nosec G304 -- path is the user-provided or default lockfile location
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &LockFile{LockfileVersion: lockFileFormatVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read lockfile %s: %w", path, err)
+	}
+
+	lf := &LockFile{}
+	if err := json.Unmarshal(data, lf); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+	if lf.LockfileVersion > lockFileFormatVersion {
+		return nil, fmt.Errorf("lockfile %s has unsupported version %d (max supported: %d)", path, lf.LockfileVersion, lockFileFormatVersion)
+	}
+	return lf, nil
+}
+
+// Find returns the entry for the given slug, or nil if the skill is not locked.
+func (lf *LockFile) Find(slug string) *LockEntry {
+	for i := range lf.Skills {
+		if lf.Skills[i].Slug == slug {
+			return &lf.Skills[i]
+		}
+	}
+	return nil
+}
+
+// Upsert adds the entry, replacing any existing entry for the same slug.
+func (lf *LockFile) Upsert(entry LockEntry) {
+	if existing := lf.Find(entry.Slug); existing != nil {
+		*existing = entry
+		return
+	}
+	lf.Skills = append(lf.Skills, entry)
+}
+
+// Record adds an installed skill like Upsert, but keeps track of why it is installed:
+// a skill installed directly stays direct when it is later pulled in as a dependency,
+// and the dependents of a dependency accumulate across installs.
+// Installing into further targets adds them to the targets already recorded, and installing
+// without targets keeps them.
+func (lf *LockFile) Record(entry LockEntry) {
+	existing := lf.Find(entry.Slug)
+	if existing != nil {
+		entry.Targets = mergeSorted(existing.Targets, entry.Targets)
+	}
+	if existing != nil && entry.IsDependency() {
+		if !existing.IsDependency() {
+			entry.RequiredBy = nil
+			entry.Constraint = existing.Constraint
+		} else {
+			entry.RequiredBy = mergeSorted(existing.RequiredBy, entry.RequiredBy)
+		}
+	}
+	lf.Upsert(entry)
+}
+
+// RecordClosure records a skill installed together with its resolved dependencies, requested skill first.
+// The dependents recorded for the closure replace those recorded by earlier installs of the same skills,
+// and dependencies no longer required by any skill are removed. It returns the slugs of the removed skills.
+func (lf *LockFile) RecordClosure(entries []LockEntry) []string {
+	closure := map[string]bool{}
+	for _, entry := range entries {
+		closure[entry.Slug] = true
+	}
+	orphans := lf.dropDependents(closure)
+	for _, entry := range entries {
+		lf.Record(entry)
+	}
+
+	var removed []string
+	for len(orphans) > 0 {
+		slug := orphans[0]
+		orphans = orphans[1:]
+		if closure[slug] {
+			continue
+		}
+		if entry := lf.Find(slug); entry == nil || entry.IsDependency() {
+			continue
+		}
+		lf.remove(slug)
+		removed = append(removed, slug)
+		orphans = append(orphans, lf.dropDependents(map[string]bool{slug: true})...)
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// dropDependents removes the given skills from the dependents of every entry,
+// and returns the dependencies left without any dependent.
+func (lf *LockFile) dropDependents(dependents map[string]bool) []string {
+	var orphans []string
+	for i := range lf.Skills {
+		entry := &lf.Skills[i]
+		if !entry.IsDependency() {
+			continue
+		}
+		var kept []string
+		for _, slug := range entry.RequiredBy {
+			if !dependents[slug] {
+				kept = append(kept, slug)
+			}
+		}
+		entry.RequiredBy = kept
+		if len(kept) == 0 {
+			orphans = append(orphans, entry.Slug)
+		}
+	}
+	return orphans
+}
+
+func (lf *LockFile) remove(slug string) {
+	for i := range lf.Skills {
+		if lf.Skills[i].Slug == slug {
+			lf.Skills = append(lf.Skills[:i], lf.Skills[i+1:]...)
+			return
+		}
+	}
+}
+
+func mergeSorted(a, b []string) []string {
+	seen := map[string]bool{}
+	var merged []string
+	for _, s := range append(append([]string{}, a...), b...) {
+		if !seen[s] {
+			seen[s] = true
+			merged = append(merged, s)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// Save writes the lockfile with entries sorted by slug, so that diffs stay minimal.
+func (lf *LockFile) Save(path string) error {
+	lf.LockfileVersion = lockFileFormatVersion
+	sort.Slice(lf.Skills, func(i, j int) bool { return lf.Skills[i].Slug < lf.Skills[j].Slug })
+
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+	// #nosec G301 -- lockfile directory is the user's project/install directory
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create lockfile directory: %w", err)
+	}
+	// #nosec G306 -- lockfile is meant to be committed and shared
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLockFile_Missing(t *testing.T) {
+	lf, err := LoadLockFile(filepath.Join(t.TempDir(), LockFileName))
+	require.NoError(t, err)
+	assert.Empty(t, lf.Skills)
+}
+
+func TestLockFile_UpsertAndSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", LockFileName)
+
+	lf := &LockFile{}
+	lf.Upsert(LockEntry{Slug: "zeta", Version: "1.0.0", Repo: "skills-local", SHA256: "aa", Evidence: EvidenceStatusVerified})
+	lf.Upsert(LockEntry{Slug: "alpha", Version: "0.1.0", Repo: "skills-local", SHA256: "bb", Evidence: EvidenceStatusUnverified})
+	lf.Upsert(LockEntry{Slug: "zeta", Version: "1.1.0", Repo: "skills-local", SHA256: "cc", Evidence: EvidenceStatusVerified})
+	require.NoError(t, lf.Save(path))
+
+	loaded, err := LoadLockFile(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Skills, 2)
+	assert.Equal(t, lockFileFormatVersion, loaded.LockfileVersion)
+	assert.Equal(t, "alpha", loaded.Skills[0].Slug)
+	assert.Equal(t, "zeta", loaded.Skills[1].Slug)
+	assert.Equal(t, "1.1.0", loaded.Skills[1].Version)
+	assert.Equal(t, "cc", loaded.Skills[1].SHA256)
+	assert.Nil(t, loaded.Find("missing"))
+}
+
+func TestLoadLockFile_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"lockfileVersion": 99, "skills": []}`), 0644))
+
+	_, err := LoadLockFile(path)
+	assert.ErrorContains(t, err, "unsupported version")
+}
+
+func TestLockFilePath(t *testing.T) {
+	assert.Equal(t, filepath.Join(".", LockFileName), LockFilePath(""))
+	assert.Equal(t, filepath.Join("/custom", LockFileName), LockFilePath("/custom"))
+}
+
+func TestLockFile_Record(t *testing.T) {
+	lf := &LockFile{}
+	lf.Record(LockEntry{Slug: "helpers", Version: "1.0.0", RequiredBy: []string{"alpha"}})
+	lf.Record(LockEntry{Slug: "helpers", Version: "1.1.0", RequiredBy: []string{"beta"}})
+	assert.Equal(t, "1.1.0", lf.Find("helpers").Version)
+	assert.Equal(t, []string{"alpha", "beta"}, lf.Find("helpers").RequiredBy)
+
+	// A direct install stays direct when the skill is later pulled in as a dependency.
+	lf.Record(LockEntry{Slug: "direct", Version: "2.0.0", Constraint: "^2"})
+	lf.Record(LockEntry{Slug: "direct", Version: "2.1.0", RequiredBy: []string{"alpha"}})
+	assert.False(t, lf.Find("direct").IsDependency())
+	assert.Equal(t, "^2", lf.Find("direct").Constraint)
+	assert.Equal(t, "2.1.0", lf.Find("direct").Version)
+
+	// Installing a dependency directly makes it direct.
+	lf.Record(LockEntry{Slug: "helpers", Version: "1.2.0"})
+	assert.False(t, lf.Find("helpers").IsDependency())
+
+	// Installing into further targets keeps the targets recorded before.
+	lf.Record(LockEntry{Slug: "direct", Version: "2.1.0", Targets: []string{"cursor"}})
+	lf.Record(LockEntry{Slug: "direct", Version: "2.1.0", Targets: []string{"claude:global", "cursor"}})
+	assert.Equal(t, []string{"claude:global", "cursor"}, lf.Find("direct").Targets)
+	// Installing without targets doesn't forget them.
+	lf.Record(LockEntry{Slug: "direct", Version: "2.2.0"})
+	assert.Equal(t, []string{"claude:global", "cursor"}, lf.Find("direct").Targets)
+}
+
+func TestLockFile_RecordClosure(t *testing.T) {
+	lf := &LockFile{}
+	lf.RecordClosure([]LockEntry{
+		{Slug: "app", Version: "1.0.0"},
+		{Slug: "core", Version: "3.0.0", RequiredBy: []string{"helpers"}},
+		{Slug: "helpers", Version: "1.0.0", RequiredBy: []string{"app"}},
+		{Slug: "shared", Version: "1.0.0", RequiredBy: []string{"app"}},
+	})
+	lf.RecordClosure([]LockEntry{
+		{Slug: "tool", Version: "1.0.0"},
+		{Slug: "shared", Version: "1.0.0", RequiredBy: []string{"tool"}},
+	})
+	assert.Equal(t, []string{"app", "tool"}, lf.Find("shared").RequiredBy)
+
+	// app@2.0.0 no longer depends on helpers, so helpers and its own dependency are pruned,
+	// while shared is still required by tool.
+	pruned := lf.RecordClosure([]LockEntry{{Slug: "app", Version: "2.0.0"}})
+	assert.Equal(t, []string{"core", "helpers"}, pruned)
+	assert.Nil(t, lf.Find("helpers"))
+	assert.Nil(t, lf.Find("core"))
+	assert.Equal(t, []string{"tool"}, lf.Find("shared").RequiredBy)
+	assert.Equal(t, "2.0.0", lf.Find("app").Version)
+	assert.False(t, lf.Find("app").IsDependency())
+}
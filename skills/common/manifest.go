@@ -0,0 +1,222 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// ManifestFileName is the install manifest written into every installed skill directory.
+	ManifestFileName = ".jfrog-skill.json"
+	// manifestFormatVersion is bumped whenever the manifest layout changes incompatibly.
+	manifestFormatVersion = 1
+)
+
+// InstallManifest records where an installed skill came from and the files it was installed with,
+// so that later commands can tell which version is installed and whether it was edited locally.
+type InstallManifest struct {
+	ManifestVersion int    `json:"manifestVersion"`
+	Slug            string `json:"slug"`
+	Version         string `json:"version"`
+	Repo            string `json:"repo"`
+	SHA256          string `json:"sha256"`
+	Evidence        string `json:"evidence"`
+	SignedBy        string `json:"signedBy,omitempty"`
+	// Target is the install target (e.g. "claude" or "cursor") the skill was installed for, if any.
+	Target      string `json:"target,omitempty"`
+	InstalledAt string `json:"installedAt"`
+	// Files maps each installed file, relative to the skill directory and using forward slashes, to its SHA-256.
+	Files map[string]string `json:"files"`
+}
+
+// InstalledSkill is a skill directory that contains an install manifest.
+type InstalledSkill struct {
+	Dir string
+	// Target is the install target the directory belongs to; empty for the install path.
+	Target   string
+	Manifest *InstallManifest
+}
+
+// FileChanges lists the files that differ between two file sets.
+type FileChanges struct {
+	Added    []string `json:"added,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether there are no changes.
+func (c FileChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// NewInstallManifest creates the manifest of an installed skill from its lockfile entry
+// and the file hashes returned by HashSkillFiles.
+func NewInstallManifest(entry LockEntry, files map[string]string) *InstallManifest {
+	return &InstallManifest{
+		ManifestVersion: manifestFormatVersion,
+		Slug:            entry.Slug,
+		Version:         entry.Version,
+		Repo:            entry.Repo,
+		SHA256:          entry.SHA256,
+		Evidence:        entry.Evidence,
+		SignedBy:        entry.SignedBy,
+		InstalledAt:     time.Now().UTC().Format(time.RFC3339),
+		Files:           files,
+	}
+}
+
+// ReadManifest reads the install manifest of the skill installed in dir.
+// The returned error wraps os.ErrNotExist when the directory has no manifest.
+func ReadManifest(dir string) (*InstallManifest, error) {
+	path := filepath.Join(dir, ManifestFileName)
+	// #nosec G304 -- path is the manifest inside the user's install directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read install manifest: %w", err)
+	}
+	m := &InstallManifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse install manifest %s: %w", path, err)
+	}
+	if m.ManifestVersion > manifestFormatVersion {
+		return nil, fmt.Errorf("install manifest %s has unsupported version %d (max supported: %d)", path, m.ManifestVersion, manifestFormatVersion)
+	}
+	return m, nil
+}
+
+// Save writes the manifest into the skill directory.
+func (m *InstallManifest) Save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal install manifest: %w", err)
+	}
+	path := filepath.Join(dir, ManifestFileName)
+	// #nosec G306 -- the manifest describes the installed skill and is not sensitive
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write install manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// LocalChanges compares the files currently in dir with the files the skill was installed with.
+// Added lists files created after installation; they are never removed by the skills tooling.
+func (m *InstallManifest) LocalChanges(dir string) (FileChanges, error) {
+	current, err := HashSkillFiles(dir)
+	if err != nil {
+		return FileChanges{}, err
+	}
+	return DiffFiles(m.Files, current), nil
+}
+
+// ListInstalledSkills returns the skills installed directly under installBase, sorted by slug.
+// Directories without an install manifest (e.g. skills copied by hand) are skipped.
+func ListInstalledSkills(installBase string) ([]InstalledSkill, error) {
+	if installBase == "" {
+		installBase = "."
+	}
+	entries, err := os.ReadDir(installBase)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read install directory %s: %w", installBase, err)
+	}
+
+	var skills []InstalledSkill
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(installBase, entry.Name())
+		m, err := ReadManifest(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		skills = append(skills, InstalledSkill{Dir: dir, Manifest: m})
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Manifest.Slug < skills[j].Manifest.Slug })
+	return skills, nil
+}
+
+// HashSkillFiles returns the SHA-256 of every regular file under dir, keyed by its
+// slash-separated path relative to dir. The install manifest itself is excluded.
+func HashSkillFiles(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ManifestFileName {
+			return nil
+		}
+		sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash files in %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// DiffFiles compares two file hash sets, as returned by HashSkillFiles. Each list is sorted.
+func DiffFiles(from, to map[string]string) FileChanges {
+	var changes FileChanges
+	for path, sum := range to {
+		fromSum, ok := from[path]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, path)
+		case fromSum != sum:
+			changes.Modified = append(changes.Modified, path)
+		}
+	}
+	for path := range from {
+		if _, ok := to[path]; !ok {
+			changes.Removed = append(changes.Removed, path)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Modified)
+	sort.Strings(changes.Removed)
+	return changes
+}
+
+func hashFile(path string) (string, error) {
+	// #nosec G304 -- path is a file found while walking the skill directory
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestInstallManifest_SaveAndRead(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"SKILL.md": "skill", "scripts/run.sh": "echo"})
+
+	files, err := HashSkillFiles(dir)
+	require.NoError(t, err)
+	entry := LockEntry{Slug: "my-skill", Version: "1.0.0", Repo: "skills-local", SHA256: "aa", Evidence: EvidenceStatusVerified}
+	require.NoError(t, NewInstallManifest(entry, files).Save(dir))
+
+	m, err := ReadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, manifestFormatVersion, m.ManifestVersion)
+	assert.Equal(t, "my-skill", m.Slug)
+	assert.Equal(t, "1.0.0", m.Version)
+	assert.Equal(t, "skills-local", m.Repo)
+	assert.Equal(t, EvidenceStatusVerified, m.Evidence)
+	assert.NotEmpty(t, m.InstalledAt)
+	assert.Len(t, m.Files, 2)
+	assert.Contains(t, m.Files, "scripts/run.sh")
+
+	// The manifest does not list itself, so saving it does not count as a local change.
+	changes, err := m.LocalChanges(dir)
+	require.NoError(t, err)
+	assert.True(t, changes.IsEmpty())
+}
+
+func TestReadManifest_Missing(t *testing.T) {
+	_, err := ReadManifest(t.TempDir())
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestReadManifest_UnsupportedVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{ManifestFileName: `{"manifestVersion": 99}`})
+
+	_, err := ReadManifest(dir)
+	assert.ErrorContains(t, err, "unsupported version")
+}
+
+func TestInstallManifest_LocalChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"SKILL.md": "skill", "a.md": "a", "b.md": "b"})
+	files, err := HashSkillFiles(dir)
+	require.NoError(t, err)
+	m := NewInstallManifest(LockEntry{Slug: "my-skill", Version: "1.0.0"}, files)
+
+	writeFiles(t, dir, map[string]string{"a.md": "edited", "notes/new.md": "mine"})
+	require.NoError(t, os.Remove(filepath.Join(dir, "b.md")))
+
+	changes, err := m.LocalChanges(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notes/new.md"}, changes.Added)
+	assert.Equal(t, []string{"a.md"}, changes.Modified)
+	assert.Equal(t, []string{"b.md"}, changes.Removed)
+}
+
+func TestDiffFiles(t *testing.T) {
+	changes := DiffFiles(
+		map[string]string{"same": "1", "changed": "1", "gone": "1"},
+		map[string]string{"same": "1", "changed": "2", "new-b": "1", "new-a": "1"},
+	)
+	assert.Equal(t, []string{"new-a", "new-b"}, changes.Added)
+	assert.Equal(t, []string{"changed"}, changes.Modified)
+	assert.Equal(t, []string{"gone"}, changes.Removed)
+	assert.True(t, DiffFiles(map[string]string{"a": "1"}, map[string]string{"a": "1"}).IsEmpty())
+}
+
+func TestListInstalledSkills(t *testing.T) {
+	base := t.TempDir()
+	for _, slug := range []string{"zeta", "alpha"} {
+		dir := filepath.Join(base, slug)
+		writeFiles(t, dir, map[string]string{"SKILL.md": slug})
+		require.NoError(t, NewInstallManifest(LockEntry{Slug: slug, Version: "1.0.0"}, nil).Save(dir))
+	}
+	// Skills copied by hand have no manifest and are not listed.
+	writeFiles(t, filepath.Join(base, "manual"), map[string]string{"SKILL.md": "manual"})
+	writeFiles(t, base, map[string]string{LockFileName: "{}"})
+
+	skills, err := ListInstalledSkills(base)
+	require.NoError(t, err)
+	require.Len(t, skills, 2)
+	assert.Equal(t, "alpha", skills[0].Manifest.Slug)
+	assert.Equal(t, filepath.Join(base, "alpha"), skills[0].Dir)
+	assert.Equal(t, "zeta", skills[1].Manifest.Slug)
+
+	skills, err = ListInstalledSkills(filepath.Join(base, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, skills)
+}
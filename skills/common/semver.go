@@ -2,82 +2,578 @@ package common
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-type semverParts struct {
-	Major int
-	Minor int
-	Patch int
-	Raw   string
+// Version is a parsed Semantic Versioning 2.0.0 version.
+// See https://semver.org for the precedence rules implemented by Compare.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      string
+	Raw        string
 }
 
-// LatestVersion returns the greatest semver from a list of version strings.
-func LatestVersion(versions []string) (string, error) {
-	if len(versions) == 0 {
-		return "", fmt.Errorf("no versions available")
+// semverRegex is the official semver.org regular expression, with an optional leading "v".
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// ParseVersion parses a full semver string (MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]).
+// A leading "v" is accepted and ignored.
+func ParseVersion(version string) (*Version, error) {
+	m := semverRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if m == nil {
+		return nil, fmt.Errorf("invalid semver: %s", version)
+	}
+	v := &Version{Raw: version, Build: m[5]}
+	var err error
+	if v.Major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid major version in %s: %w", version, err)
+	}
+	if v.Minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid minor version in %s: %w", version, err)
+	}
+	if v.Patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid patch version in %s: %w", version, err)
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+	}
+	return v, nil
+}
+
+// IsPrerelease reports whether the version carries a pre-release tag.
+func (v *Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// String returns the canonical form of the version (without a "v" prefix).
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.IsPrerelease() {
+		s += "-" + strings.Join(v.Prerelease, ".")
 	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
 
-	parsed := make([]semverParts, 0, len(versions))
+// Compare returns -1, 0 or 1 according to semver precedence.
+// Build metadata is ignored, as required by the specification.
+func (v *Version) Compare(o *Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+func (v *Version) sameCore(o *Version) bool {
+	return v.Major == o.Major && v.Minor == o.Minor && v.Patch == o.Patch
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease implements semver rule 11: a version without pre-release has
+// higher precedence; identifiers are compared numerically when both are numeric,
+// numeric identifiers sort before alphanumeric ones, and a longer set wins a tie.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aErr := strconv.ParseUint(a[i], 10, 64)
+		bn, bErr := strconv.ParseUint(b[i], 10, 64)
+		aNum, bNum := aErr == nil, bErr == nil
+		switch {
+		case aNum && bNum:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+// CompareVersions compares two semver strings by precedence.
+func CompareVersions(a, b string) (int, error) {
+	va, err := ParseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// SortVersions returns the valid semver strings from versions in ascending precedence.
+// Invalid versions are dropped.
+func SortVersions(versions []string) []string {
+	parsed := parseValidVersions(versions)
+	sorted := make([]string, len(parsed))
+	for i, v := range parsed {
+		sorted[i] = v.Raw
+	}
+	return sorted
+}
+
+func parseValidVersions(versions []string) []*Version {
+	parsed := make([]*Version, 0, len(versions))
 	for _, v := range versions {
-		sv, err := parseSemver(v)
+		sv, err := ParseVersion(v)
 		if err != nil {
 			continue
 		}
 		parsed = append(parsed, sv)
 	}
+	sort.SliceStable(parsed, func(i, j int) bool { return parsed[i].Compare(parsed[j]) < 0 })
+	return parsed
+}
 
+// LatestVersion returns the greatest semver from a list of version strings.
+func LatestVersion(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions available")
+	}
+
+	parsed := parseValidVersions(versions)
 	if len(parsed) == 0 {
 		return "", fmt.Errorf("no valid semver versions found")
 	}
-
-	sort.Slice(parsed, func(i, j int) bool {
-		if parsed[i].Major != parsed[j].Major {
-			return parsed[i].Major < parsed[j].Major
-		}
-		if parsed[i].Minor != parsed[j].Minor {
-			return parsed[i].Minor < parsed[j].Minor
-		}
-		return parsed[i].Patch < parsed[j].Patch
-	})
-
 	return parsed[len(parsed)-1].Raw, nil
 }
 
 // NextMinorVersion takes a semver string and returns the next minor version
 // with patch reset to 0 (e.g. "1.2.3" -> "1.3.0").
 func NextMinorVersion(version string) (string, error) {
-	sv, err := parseSemver(version)
+	sv, err := ParseVersion(version)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("%d.%d.0", sv.Major, sv.Minor+1), nil
 }
 
-func parseSemver(version string) (semverParts, error) {
-	v := strings.TrimPrefix(version, "v")
-	parts := strings.SplitN(v, ".", 3)
-	if len(parts) != 3 {
-		return semverParts{}, fmt.Errorf("invalid semver: %s", version)
+// NextAvailablePatchVersion returns the lowest patch release above version that
+// is not already taken (e.g. "1.2.3" with 1.2.4 taken -> "1.2.5").
+func NextAvailablePatchVersion(version string, taken []string) (string, error) {
+	sv, err := ParseVersion(version)
+	if err != nil {
+		return "", err
+	}
+	existing := make(map[string]bool, len(taken))
+	for _, t := range taken {
+		if tv, err := ParseVersion(t); err == nil {
+			existing[tv.String()] = true
+		}
+	}
+	for patch := sv.Patch + 1; ; patch++ {
+		candidate := fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, patch)
+		if !existing[candidate] {
+			return candidate, nil
+		}
+	}
+}
+
+// Constraint is an npm-style version range: a union ("||") of comparator sets,
+// where every comparator in a set must match.
+// Supported forms: exact ("1.2.3", "=1.2.3"), comparisons (">=2 <3"), x-ranges ("1.x", "1.2.*", "*"),
+// tilde ("~1.4.0"), caret ("^1.2") and hyphen ranges ("1.2 - 2.3.4").
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version *Version
+}
+
+// String returns the constraint as it was written.
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether the version satisfies the constraint.
+// As in npm, a pre-release version only matches when a comparator in the same set
+// has a pre-release on the same MAJOR.MINOR.PATCH.
+func (c *Constraint) Check(v *Version) bool {
+	for _, set := range c.sets {
+		if setMatches(set, v) {
+			return true
+		}
 	}
+	return false
+}
+
+func setMatches(set []comparator, v *Version) bool {
+	for _, cmp := range set {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, cmp := range set {
+		if cmp.version.IsPrerelease() && cmp.version.sameCore(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmp comparator) matches(v *Version) bool {
+	c := v.Compare(cmp.version)
+	switch cmp.op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	default:
+		return c == 0
+	}
+}
+
+var (
+	hyphenRangeRegex  = regexp.MustCompile(`^\s*(\S+)\s+-\s+(\S+)\s*$`)
+	operatorGapRegex  = regexp.MustCompile(`(>=|<=|>|<|=|\^|~)\s+`)
+	partialRegex      = regexp.MustCompile(`^v?(\*|x|X|0|[1-9]\d*)(?:\.(\*|x|X|0|[1-9]\d*))?(?:\.(\*|x|X|0|[1-9]\d*))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	comparatorOpRegex = regexp.MustCompile(`^(>=|<=|>|<|=|\^|~>?)?(.*)$`)
+)
+
+// ParseConstraint parses an npm-style range expression.
+func ParseConstraint(expr string) (*Constraint, error) {
+	c := &Constraint{raw: expr}
+	for _, part := range strings.Split(expr, "||") {
+		set, err := parseComparatorSet(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range '%s': %w", expr, err)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
 
-	major, err := strconv.Atoi(parts[0])
+func parseComparatorSet(part string) ([]comparator, error) {
+	if m := hyphenRangeRegex.FindStringSubmatch(part); m != nil {
+		return parseHyphenRange(m[1], m[2])
+	}
+
+	normalized := operatorGapRegex.ReplaceAllString(strings.TrimSpace(part), "$1")
+	tokens := strings.FieldsFunc(normalized, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	if len(tokens) == 0 {
+		// An empty set matches any release version.
+		return []comparator{anyComparator()}, nil
+	}
+
+	var set []comparator
+	for _, token := range tokens {
+		cmps, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cmps...)
+	}
+	return set, nil
+}
+
+func parseComparator(token string) ([]comparator, error) {
+	m := comparatorOpRegex.FindStringSubmatch(token)
+	op, rest := m[1], m[2]
+	if rest == "" {
+		return nil, fmt.Errorf("missing version after '%s'", op)
+	}
+	p, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "^":
+		return caretRange(p), nil
+	case "~", "~>":
+		return tildeRange(p), nil
+	case ">":
+		if p.isAny() {
+			// ">*" can never be satisfied.
+			return []comparator{{op: "<", version: &Version{Prerelease: []string{"0"}}}}, nil
+		}
+		if p.minor == nil {
+			return []comparator{{op: ">=", version: p.bump(0)}}, nil
+		}
+		if p.patch == nil {
+			return []comparator{{op: ">=", version: p.bump(1)}}, nil
+		}
+		return []comparator{{op: ">", version: p.floor()}}, nil
+	case ">=":
+		return []comparator{{op: ">=", version: p.floor()}}, nil
+	case "<":
+		if p.isAny() {
+			return []comparator{{op: "<", version: &Version{Prerelease: []string{"0"}}}}, nil
+		}
+		return []comparator{{op: "<", version: p.floorWithPrerelease()}}, nil
+	case "<=":
+		if p.isAny() {
+			return []comparator{anyComparator()}, nil
+		}
+		if p.patch != nil {
+			return []comparator{{op: "<=", version: p.floor()}}, nil
+		}
+		return []comparator{{op: "<", version: p.ceiling()}}, nil
+	default:
+		return xRange(p), nil
+	}
+}
+
+func parseHyphenRange(from, to string) ([]comparator, error) {
+	lower, err := parsePartial(from)
 	if err != nil {
-		return semverParts{}, fmt.Errorf("invalid major version in %s: %w", version, err)
+		return nil, err
 	}
-	minor, err := strconv.Atoi(parts[1])
+	upper, err := parsePartial(to)
 	if err != nil {
-		return semverParts{}, fmt.Errorf("invalid minor version in %s: %w", version, err)
+		return nil, err
+	}
+	set := []comparator{{op: ">=", version: lower.floor()}}
+	switch {
+	case upper.isAny():
+	case upper.patch == nil:
+		set = append(set, comparator{op: "<", version: upper.ceiling()})
+	default:
+		set = append(set, comparator{op: "<=", version: upper.floor()})
+	}
+	return set, nil
+}
+
+// partialVersion is a possibly incomplete version such as "1", "1.2", "1.x" or "*".
+// A nil component means the component was omitted or given as a wildcard.
+type partialVersion struct {
+	major, minor, patch *uint64
+	prerelease          []string
+}
+
+func parsePartial(s string) (*partialVersion, error) {
+	if s == "" {
+		return &partialVersion{}, nil
+	}
+	m := partialRegex.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid version '%s'", s)
+	}
+	p := &partialVersion{}
+	components := []**uint64{&p.major, &p.minor, &p.patch}
+	for i, raw := range m[1:4] {
+		if raw == "" || raw == "*" || raw == "x" || raw == "X" {
+			break
+		}
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version '%s': %w", s, err)
+		}
+		*components[i] = &n
+	}
+	if m[4] != "" {
+		if p.patch == nil {
+			return nil, fmt.Errorf("invalid version '%s': pre-release requires a full version", s)
+		}
+		p.prerelease = strings.Split(m[4], ".")
+	}
+	return p, nil
+}
+
+func (p *partialVersion) isAny() bool {
+	return p.major == nil
+}
+
+func orZero(n *uint64) uint64 {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+// floor returns the lowest version matched by the partial, e.g. "1.2" -> 1.2.0.
+func (p *partialVersion) floor() *Version {
+	return &Version{Major: orZero(p.major), Minor: orZero(p.minor), Patch: orZero(p.patch), Prerelease: p.prerelease}
+}
+
+// floorWithPrerelease is floor for upper bounds: "<1.2" must exclude 1.2.0-alpha as well.
+func (p *partialVersion) floorWithPrerelease() *Version {
+	v := p.floor()
+	if p.patch == nil {
+		v.Prerelease = []string{"0"}
+	}
+	return v
+}
+
+// bump increments the component at index (0=major, 1=minor) and zeroes the rest.
+func (p *partialVersion) bump(index int) *Version {
+	if index == 0 {
+		return &Version{Major: orZero(p.major) + 1}
+	}
+	return &Version{Major: orZero(p.major), Minor: orZero(p.minor) + 1}
+}
+
+// ceiling returns the exclusive upper bound of a partial: "1" -> 2.0.0-0, "1.2" -> 1.3.0-0.
+func (p *partialVersion) ceiling() *Version {
+	var v *Version
+	if p.minor == nil {
+		v = p.bump(0)
+	} else {
+		v = p.bump(1)
+	}
+	v.Prerelease = []string{"0"}
+	return v
+}
+
+func anyComparator() comparator {
+	return comparator{op: ">=", version: &Version{}}
+}
+
+func xRange(p *partialVersion) []comparator {
+	if p.isAny() {
+		return []comparator{anyComparator()}
+	}
+	if p.patch != nil {
+		return []comparator{{op: "=", version: p.floor()}}
+	}
+	return []comparator{{op: ">=", version: p.floor()}, {op: "<", version: p.ceiling()}}
+}
+
+// tildeRange allows patch-level changes when a minor is given, minor-level otherwise.
+func tildeRange(p *partialVersion) []comparator {
+	if p.isAny() {
+		return []comparator{anyComparator()}
+	}
+	upper := &Version{Major: orZero(p.major), Minor: orZero(p.minor) + 1, Prerelease: []string{"0"}}
+	if p.minor == nil {
+		upper = &Version{Major: orZero(p.major) + 1, Prerelease: []string{"0"}}
+	}
+	return []comparator{{op: ">=", version: p.floor()}, {op: "<", version: upper}}
+}
+
+// caretRange allows changes that do not modify the left-most non-zero component.
+func caretRange(p *partialVersion) []comparator {
+	if p.isAny() {
+		return []comparator{anyComparator()}
+	}
+	major, minor, patch := orZero(p.major), orZero(p.minor), orZero(p.patch)
+	var upper *Version
+	switch {
+	case major > 0 || p.minor == nil:
+		upper = &Version{Major: major + 1}
+	case minor > 0 || p.patch == nil:
+		upper = &Version{Major: major, Minor: minor + 1}
+	default:
+		upper = &Version{Major: major, Minor: minor, Patch: patch + 1}
+	}
+	upper.Prerelease = []string{"0"}
+	return []comparator{{op: ">=", version: p.floor()}, {op: "<", version: upper}}
+}
+
+// MaxSatisfying returns the greatest version in versions that satisfies the constraint.
+func MaxSatisfying(versions []string, c *Constraint) (string, error) {
+	parsed := parseValidVersions(versions)
+	for i := len(parsed) - 1; i >= 0; i-- {
+		if c.Check(parsed[i]) {
+			return parsed[i].Raw, nil
+		}
 	}
+	return "", fmt.Errorf("no version satisfies '%s'", c.String())
+}
+
+// IsVersionRange reports whether spec is a range expression rather than
+// an exact version or the "latest" keyword.
+func IsVersionRange(spec string) bool {
+	if spec == "" || spec == "latest" {
+		return false
+	}
+	if _, err := ParseVersion(spec); err == nil {
+		return false
+	}
+	_, err := ParseConstraint(spec)
+	return err == nil
+}
 
-	// Patch may contain pre-release suffix; take numeric part only for comparison
-	patchStr := strings.SplitN(parts[2], "-", 2)[0]
-	patch, err := strconv.Atoi(patchStr)
+// ResolveVersionSpec picks a concrete version from the available versions.
+// spec may be empty or "latest" (greatest version), an exact version, or a range expression.
+func ResolveVersionSpec(available []string, spec string) (string, error) {
+	if spec == "" || spec == "latest" {
+		return LatestVersion(available)
+	}
+	if _, err := ParseVersion(spec); err == nil {
+		for _, v := range available {
+			if c, err := CompareVersions(v, spec); err == nil && c == 0 {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("version %s not found (available: %s)", spec, strings.Join(SortVersions(available), ", "))
+	}
+	constraint, err := ParseConstraint(spec)
+	if err != nil {
+		return "", err
+	}
+	version, err := MaxSatisfying(available, constraint)
 	if err != nil {
-		return semverParts{}, fmt.Errorf("invalid patch version in %s: %w", version, err)
+		return "", fmt.Errorf("%w (available: %s)", err, strings.Join(SortVersions(available), ", "))
 	}
+	return version, nil
+}
 
-	return semverParts{Major: major, Minor: minor, Patch: patch, Raw: version}, nil
+// SatisfiesVersionSpec reports whether version is acceptable for spec, using the
+// same interpretation as ResolveVersionSpec. Specs that are neither semver nor a
+// valid range only match the identical version string.
+func SatisfiesVersionSpec(version, spec string) bool {
+	v, err := ParseVersion(version)
+	if spec == "" || spec == "latest" {
+		return err == nil
+	}
+	if _, specErr := ParseVersion(spec); specErr == nil {
+		c, cmpErr := CompareVersions(version, spec)
+		return cmpErr == nil && c == 0
+	}
+	constraint, constraintErr := ParseConstraint(spec)
+	if constraintErr != nil {
+		return version == spec
+	}
+	return err == nil && constraint.Check(v)
 }
@@ -90,3 +90,156 @@ func TestNextMinorVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestVersion_Precedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected string
+	}{
+		{name: "release beats pre-release", versions: []string{"1.0.0-rc.1", "1.0.0", "1.0.0-beta"}, expected: "1.0.0"},
+		{name: "numeric pre-release identifiers", versions: []string{"1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0-alpha.1"}, expected: "1.0.0-alpha.10"},
+		{name: "alphanumeric beats numeric", versions: []string{"1.0.0-1", "1.0.0-alpha"}, expected: "1.0.0-alpha"},
+		{name: "longer pre-release wins tie", versions: []string{"1.0.0-alpha", "1.0.0-alpha.1"}, expected: "1.0.0-alpha.1"},
+		{name: "leading zeros are invalid", versions: []string{"01.0.0", "1.0.0"}, expected: "1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LatestVersion(tt.versions)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareVersions_SpecOrdering(t *testing.T) {
+	// Ordered list from semver.org section 11.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		cmp, err := CompareVersions(ordered[i], ordered[i+1])
+		require.NoError(t, err)
+		assert.Equal(t, -1, cmp, "%s should precede %s", ordered[i], ordered[i+1])
+	}
+
+	cmp, err := CompareVersions("1.0.0+build.1", "1.0.0+build.2")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp, "build metadata must be ignored")
+
+	assert.Equal(t, []string{"1.0.0-rc.1", "1.0.0", "1.2.0"}, SortVersions([]string{"1.2.0", "bad", "1.0.0", "1.0.0-rc.1"}))
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: "^1.2", matches: []string{"1.2.0", "1.9.9"}, rejects: []string{"1.1.9", "2.0.0", "2.0.0-beta", "1.5.0-beta"}},
+		{constraint: "^1.2.3", matches: []string{"1.2.3", "1.99.0"}, rejects: []string{"1.2.2", "2.0.0"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.3", "0.2.9"}, rejects: []string{"0.3.0", "0.2.2"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}, rejects: []string{"0.0.4"}},
+		{constraint: "~1.4.0", matches: []string{"1.4.0", "1.4.7"}, rejects: []string{"1.5.0", "1.3.9"}},
+		{constraint: "~1", matches: []string{"1.0.0", "1.9.0"}, rejects: []string{"2.0.0"}},
+		{constraint: ">=2 <3", matches: []string{"2.0.0", "2.9.9"}, rejects: []string{"1.9.9", "3.0.0", "3.0.0-alpha"}},
+		{constraint: ">= 2, < 3", matches: []string{"2.5.0"}, rejects: []string{"3.0.0"}},
+		{constraint: ">1.2", matches: []string{"1.3.0"}, rejects: []string{"1.2.9"}},
+		{constraint: "<=1.2", matches: []string{"1.2.9"}, rejects: []string{"1.3.0"}},
+		{constraint: "1.x", matches: []string{"1.0.0", "1.8.0"}, rejects: []string{"2.0.0"}},
+		{constraint: "1.2.*", matches: []string{"1.2.5"}, rejects: []string{"1.3.0"}},
+		{constraint: "*", matches: []string{"0.0.1", "9.9.9"}, rejects: []string{"1.0.0-beta"}},
+		{constraint: "1.2 - 2.3.4", matches: []string{"1.2.0", "2.3.4"}, rejects: []string{"2.3.5", "1.1.9"}},
+		{constraint: "1.2.3 - 2", matches: []string{"2.9.9"}, rejects: []string{"3.0.0"}},
+		{constraint: "^1.0.0 || ^3.0.0", matches: []string{"1.5.0", "3.1.0"}, rejects: []string{"2.0.0"}},
+		{constraint: ">=1.0.0-beta.2 <1.0.0", matches: []string{"1.0.0-beta.3", "1.0.0-rc.1"}, rejects: []string{"1.0.0-beta.1", "1.0.0"}},
+		{constraint: "=1.2.3", matches: []string{"1.2.3", "1.2.3+build"}, rejects: []string{"1.2.4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			require.NoError(t, err)
+			for _, v := range tt.matches {
+				sv, err := ParseVersion(v)
+				require.NoError(t, err)
+				assert.True(t, c.Check(sv), "%s should satisfy %s", v, tt.constraint)
+			}
+			for _, v := range tt.rejects {
+				sv, err := ParseVersion(v)
+				require.NoError(t, err)
+				assert.False(t, c.Check(sv), "%s should not satisfy %s", v, tt.constraint)
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	for _, expr := range []string{"^abc", ">=1.2.3.4", "1.x-beta", "~>"} {
+		_, err := ParseConstraint(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestResolveVersionSpec(t *testing.T) {
+	available := []string{"1.0.0", "1.2.0", "1.4.2", "2.0.0-rc.1", "2.0.0", "3.1.0"}
+
+	tests := []struct {
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{spec: "", expected: "3.1.0"},
+		{spec: "latest", expected: "3.1.0"},
+		{spec: "1.2.0", expected: "1.2.0"},
+		{spec: "v1.2.0", expected: "1.2.0"},
+		{spec: "^1.2", expected: "1.4.2"},
+		{spec: "~1.2.0", expected: "1.2.0"},
+		{spec: ">=2 <3", expected: "2.0.0"},
+		{spec: "1.3.0", wantErr: true},
+		{spec: "^4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			result, err := ResolveVersionSpec(available, tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestIsVersionRange(t *testing.T) {
+	assert.False(t, IsVersionRange(""))
+	assert.False(t, IsVersionRange("latest"))
+	assert.False(t, IsVersionRange("1.2.3"))
+	assert.True(t, IsVersionRange("^1.2"))
+	assert.True(t, IsVersionRange(">=2 <3"))
+	assert.True(t, IsVersionRange("1.x"))
+	assert.False(t, IsVersionRange("not a range"))
+}
+
+func TestNextAvailablePatchVersion(t *testing.T) {
+	next, err := NextAvailablePatchVersion("1.2.3", []string{"1.2.3", "1.2.4", "1.2.6"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.5", next)
+
+	_, err = NextAvailablePatchVersion("latest", nil)
+	assert.Error(t, err)
+}
+
+func TestSatisfiesVersionSpec(t *testing.T) {
+	assert.True(t, SatisfiesVersionSpec("1.4.0", "^1.2"))
+	assert.False(t, SatisfiesVersionSpec("2.0.0", "^1.2"))
+	assert.True(t, SatisfiesVersionSpec("1.2.0", "v1.2.0"))
+	assert.True(t, SatisfiesVersionSpec("2.0.0-rc.1", "latest"))
+	assert.False(t, SatisfiesVersionSpec("custom", "latest"))
+	assert.True(t, SatisfiesVersionSpec("custom", "custom"))
+	assert.False(t, SatisfiesVersionSpec("custom", "^1.0"))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
@@ -37,6 +38,37 @@ func VersionExists(serverDetails *config.ServerDetails, repoKey, slug, version s
 	return sm.SkillVersionExists(repoKey, slug, version)
 }
 
+// CopySkillVersion copies the files of a skill version (the zip and its detached signature, if any)
+// to the same path in another repository.
+func CopySkillVersion(sm artifactory.ArtifactoryServicesManager, fromRepo, toRepo, slug, version string) error {
+	params := services.NewMoveCopyParams()
+	params.Pattern = fmt.Sprintf("%s/%s/%s/*", fromRepo, slug, version)
+	params.Target = fmt.Sprintf("%s/%s/%s/", toRepo, slug, version)
+	params.Recursive = true
+	params.Flat = true
+	copied, failed, err := sm.Copy(params)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s/%s/%s to '%s': %w", fromRepo, slug, version, toRepo, err)
+	}
+	if failed > 0 || copied == 0 {
+		return fmt.Errorf("failed to copy %s/%s/%s to '%s': %d files copied, %d failed", fromRepo, slug, version, toRepo, copied, failed)
+	}
+	return nil
+}
+
+// GetFileSHA256 returns the SHA256 checksum Artifactory recorded for a file, e.g. "repo/slug/1.0.0/slug-1.0.0.zip".
+func GetFileSHA256(serverDetails *config.ServerDetails, repoPath string) (string, error) {
+	sm, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return "", err
+	}
+	info, err := sm.FileInfo(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file info of %s: %w", repoPath, err)
+	}
+	return info.Checksums.Sha256, nil
+}
+
 // ResolveRepo determines the skills repository to use.
 // Priority: flagValue (--repo) > JFROG_SKILLS_REPO env > auto-discover + interactive prompt.
 func ResolveRepo(serverDetails *config.ServerDetails, flagValue string, quiet bool) (string, error) {
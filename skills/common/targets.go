@@ -0,0 +1,256 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/ide/ideconsts"
+)
+
+const (
+	AgentNameClaude = "claude"
+	AgentNameCodex  = "codex"
+
+	// globalTargetSuffix selects the user-global skills directory of an agent, e.g. "claude:global".
+	globalTargetSuffix = ":global"
+)
+
+// AgentTarget describes where a coding agent or editor looks for skills.
+type AgentTarget struct {
+	Name        string
+	DisplayName string
+	// ProjectDir is the skills directory relative to the project root.
+	ProjectDir string
+	// UserDir is the skills directory relative to the user's home directory; empty when the tool has none.
+	UserDir string
+	// RuleFile is set for editors that don't load skills directories, only flat rule files.
+	// The skill is installed into the skills directory and a rule file pointing at it is written for the editor.
+	RuleFile *RuleFile
+}
+
+// RuleFile describes the rule files an editor loads.
+type RuleFile struct {
+	// ProjectDir and UserDir are the rules directories, relative to the project root and to the user's home directory.
+	ProjectDir string
+	UserDir    string
+	// Extension is the extension of the rule files, e.g. ".mdc".
+	Extension string
+	// Frontmatter returns the YAML frontmatter of a rule, given the description of the skill.
+	Frontmatter func(description string) string
+}
+
+// AgentTargets lists the supported install targets, keyed by name.
+// The editors share their names with the IDE setup commands (see ideconsts).
+var AgentTargets = map[string]*AgentTarget{
+	AgentNameClaude: {
+		Name:        AgentNameClaude,
+		DisplayName: "Claude Code",
+		ProjectDir:  filepath.Join(".claude", "skills"),
+		UserDir:     filepath.Join(".claude", "skills"),
+	},
+	AgentNameCodex: {
+		Name:        AgentNameCodex,
+		DisplayName: "Codex",
+		ProjectDir:  filepath.Join(".codex", "skills"),
+		UserDir:     filepath.Join(".codex", "skills"),
+	},
+	ideconsts.IDENameVSCode: {
+		Name:        ideconsts.IDENameVSCode,
+		DisplayName: "Visual Studio Code (GitHub Copilot)",
+		ProjectDir:  filepath.Join(".github", "skills"),
+		UserDir:     filepath.Join(".copilot", "skills"),
+	},
+	ideconsts.IDENameCursor: {
+		Name:        ideconsts.IDENameCursor,
+		DisplayName: "Cursor",
+		ProjectDir:  filepath.Join(".cursor", "skills"),
+		RuleFile: &RuleFile{
+			ProjectDir: filepath.Join(".cursor", "rules"),
+			Extension:  ".mdc",
+			// The agent loads the rule when the description matches the task.
+			Frontmatter: func(description string) string {
+				return fmt.Sprintf("description: %s\nalwaysApply: false\n", strconv.Quote(description))
+			},
+		},
+	},
+	ideconsts.IDENameWindsurf: {
+		Name:        ideconsts.IDENameWindsurf,
+		DisplayName: "Windsurf",
+		ProjectDir:  filepath.Join(".windsurf", "skills"),
+		RuleFile: &RuleFile{
+			ProjectDir: filepath.Join(".windsurf", "rules"),
+			Extension:  ".md",
+			Frontmatter: func(description string) string {
+				return fmt.Sprintf("trigger: model_decision\ndescription: %s\n", strconv.Quote(description))
+			},
+		},
+	},
+	ideconsts.IDENameKiro: {
+		Name:        ideconsts.IDENameKiro,
+		DisplayName: "Kiro",
+		ProjectDir:  filepath.Join(".kiro", "skills"),
+		UserDir:     filepath.Join(".kiro", "skills"),
+		RuleFile: &RuleFile{
+			ProjectDir: filepath.Join(".kiro", "steering"),
+			UserDir:    filepath.Join(".kiro", "steering"),
+			Extension:  ".md",
+			// Steering files have no description based inclusion, so the skill is always included.
+			Frontmatter: func(string) string {
+				return "inclusion: always\n"
+			},
+		},
+	},
+}
+
+// Content returns the rule file of a skill: the frontmatter, a pointer to the installed skill files,
+// which the instructions refer to by relative paths, and the SKILL.md instructions.
+func (r *RuleFile) Content(description, skillDir, instructions string) string {
+	return fmt.Sprintf("---\n%s---\n\nThe files of this skill are installed in `%s`. Resolve the paths below against this directory.\n\n%s",
+		r.Frontmatter(description), skillDir, strings.TrimLeft(instructions, "\r\n"))
+}
+
+// GetSupportedAgentTargets returns the sorted names of the supported install targets.
+func GetSupportedAgentTargets() []string {
+	names := make([]string, 0, len(AgentTargets))
+	for name := range AgentTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// InstallTarget is an agent target in either the project or the user's home directory.
+type InstallTarget struct {
+	Agent  *AgentTarget
+	Global bool
+}
+
+// ParseInstallTarget parses a target of the form "<agent>" (project-local) or "<agent>:global" (user-global).
+func ParseInstallTarget(value string) (InstallTarget, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	global := strings.HasSuffix(name, globalTargetSuffix)
+	name = strings.TrimSuffix(name, globalTargetSuffix)
+
+	agent, ok := AgentTargets[name]
+	if !ok {
+		return InstallTarget{}, fmt.Errorf("unknown install target '%s': expected one of %s, optionally followed by '%s'", value, strings.Join(GetSupportedAgentTargets(), ", "), globalTargetSuffix)
+	}
+	if global && agent.UserDir == "" {
+		return InstallTarget{}, fmt.Errorf("install target '%s' has no user-global skills directory; use '%s' to install into the project", value, agent.Name)
+	}
+	return InstallTarget{Agent: agent, Global: global}, nil
+}
+
+// ParseInstallTargets parses a comma-separated list of install targets, dropping duplicates.
+func ParseInstallTargets(value string) ([]InstallTarget, error) {
+	var targets []InstallTarget
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		target, err := ParseInstallTarget(part)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[target.String()] {
+			seen[target.String()] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// ParseTargetNames parses the target names recorded in the lockfile.
+func ParseTargetNames(names []string) ([]InstallTarget, error) {
+	return ParseInstallTargets(strings.Join(names, ","))
+}
+
+// TargetNames returns the names of the targets, as recorded in the lockfile.
+func TargetNames(targets []InstallTarget) []string {
+	if len(targets) == 0 {
+		return nil
+	}
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.String()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String returns the target name, e.g. "cursor" or "claude:global".
+func (t InstallTarget) String() string {
+	if t.Global {
+		return t.Agent.Name + globalTargetSuffix
+	}
+	return t.Agent.Name
+}
+
+// Dir returns the skills directory of the target. Project-local targets are resolved against projectRoot.
+func (t InstallTarget) Dir(projectRoot string) (string, error) {
+	return t.resolve(projectRoot, t.Agent.ProjectDir, t.Agent.UserDir)
+}
+
+// RuleFilePath returns the path of the rule file written for a skill, or an empty string when the
+// target loads skills directories directly.
+func (t InstallTarget) RuleFilePath(projectRoot, slug string) (string, error) {
+	if t.Agent.RuleFile == nil {
+		return "", nil
+	}
+	dir, err := t.resolve(projectRoot, t.Agent.RuleFile.ProjectDir, t.Agent.RuleFile.UserDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, slug+t.Agent.RuleFile.Extension), nil
+}
+
+// resolve resolves the directory of a global target against the user's home directory,
+// and of a project-local target against projectRoot.
+func (t InstallTarget) resolve(projectRoot, projectDir, userDir string) (string, error) {
+	if t.Global {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve the home directory for install target '%s': %w", t, err)
+		}
+		return filepath.Join(home, userDir), nil
+	}
+	if projectRoot == "" {
+		projectRoot = "."
+	}
+	return filepath.Join(projectRoot, projectDir), nil
+}
+
+// ListTargetSkills returns the skills the lockfile records as installed into install targets,
+// one per target directory that still holds an install manifest.
+func ListTargetSkills(projectRoot string, lockFile *LockFile) ([]InstalledSkill, error) {
+	var skills []InstalledSkill
+	for _, entry := range lockFile.Skills {
+		targets, err := ParseTargetNames(entry.Targets)
+		if err != nil {
+			return nil, fmt.Errorf("invalid targets of skill '%s' in the lockfile: %w", entry.Slug, err)
+		}
+		for _, target := range targets {
+			base, err := target.Dir(projectRoot)
+			if err != nil {
+				return nil, err
+			}
+			dir := filepath.Join(base, entry.Slug)
+			m, err := ReadManifest(dir)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			skills = append(skills, InstalledSkill{Dir: dir, Target: target.String(), Manifest: m})
+		}
+	}
+	sort.SliceStable(skills, func(i, j int) bool { return skills[i].Manifest.Slug < skills[j].Manifest.Slug })
+	return skills, nil
+}
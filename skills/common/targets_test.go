@@ -0,0 +1,86 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstallTargets(t *testing.T) {
+	targets, err := ParseInstallTargets("Claude, cursor,claude:global,claude")
+	require.NoError(t, err)
+	require.Len(t, targets, 3)
+	assert.Equal(t, "claude", targets[0].String())
+	assert.False(t, targets[0].Global)
+	assert.Equal(t, "claude:global", targets[2].String())
+	assert.True(t, targets[2].Global)
+	assert.Equal(t, []string{"claude", "claude:global", "cursor"}, TargetNames(targets))
+
+	targets, err = ParseInstallTargets("")
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+	assert.Nil(t, TargetNames(targets))
+
+	_, err = ParseInstallTargets("claude,emacs")
+	assert.ErrorContains(t, err, "unknown install target 'emacs'")
+	_, err = ParseInstallTargets("cursor:global")
+	assert.ErrorContains(t, err, "has no user-global skills directory")
+}
+
+func TestInstallTarget_Dir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	project, err := ParseInstallTarget("cursor")
+	require.NoError(t, err)
+	dir, err := project.Dir("/work/repo")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/work/repo", ".cursor", "skills"), dir)
+	dir, err = project.Dir("")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(".cursor", "skills"), dir)
+	rulePath, err := project.RuleFilePath("/work/repo", "my-skill")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/work/repo", ".cursor", "rules", "my-skill.mdc"), rulePath)
+
+	global, err := ParseInstallTarget("claude:global")
+	require.NoError(t, err)
+	dir, err = global.Dir("/work/repo")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".claude", "skills"), dir)
+	// Claude loads the skills directory, so no rule file is written.
+	rulePath, err = global.RuleFilePath("/work/repo", "my-skill")
+	require.NoError(t, err)
+	assert.Empty(t, rulePath)
+
+	kiro, err := ParseInstallTarget("kiro:global")
+	require.NoError(t, err)
+	rulePath, err = kiro.RuleFilePath("/work/repo", "my-skill")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".kiro", "steering", "my-skill.md"), rulePath)
+}
+
+func TestListTargetSkills(t *testing.T) {
+	project := t.TempDir()
+	entry := LockEntry{Slug: "my-skill", Version: "1.0.0", Repo: "skills-local", Targets: []string{"claude", "kiro"}}
+	dir := filepath.Join(project, ".claude", "skills", "my-skill")
+	writeFiles(t, dir, map[string]string{"SKILL.md": "my-skill"})
+	manifest := NewInstallManifest(entry, nil)
+	manifest.Target = "claude"
+	require.NoError(t, manifest.Save(dir))
+
+	// The kiro copy was removed by hand, so only the claude one is listed.
+	skills, err := ListTargetSkills(project, &LockFile{Skills: []LockEntry{entry}})
+	require.NoError(t, err)
+	require.Len(t, skills, 1)
+	assert.Equal(t, dir, skills[0].Dir)
+	assert.Equal(t, "claude", skills[0].Target)
+	assert.Equal(t, "1.0.0", skills[0].Manifest.Version)
+
+	entry.Targets = []string{"emacs"}
+	_, err = ListTargetSkills(project, &LockFile{Skills: []LockEntry{entry}})
+	assert.ErrorContains(t, err, "invalid targets of skill 'my-skill'")
+}
@@ -0,0 +1,194 @@
+package common
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const (
+	// SignatureSuffix is appended to a skill zip name to name its detached signature in the repository.
+	SignatureSuffix = ".sig"
+
+	// envTrustStore overrides the directory of trusted public keys.
+	envTrustStore = "JFROG_SKILLS_TRUST_STORE"
+	// envSignaturePolicy sets the signature policy when no --signature-policy flag is given.
+	envSignaturePolicy = "JFROG_SKILLS_SIGNATURE_POLICY"
+
+	trustedKeyExtension = ".pem"
+)
+
+// keyNameRegex keeps trusted key names safe to use as file names.
+var keyNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// SignaturePolicy controls how install treats skills whose detached signature
+// is missing or does not verify against the trust store.
+type SignaturePolicy string
+
+const (
+	// SignaturePolicyRequire fails the install.
+	SignaturePolicyRequire SignaturePolicy = "require"
+	// SignaturePolicyWarn logs a warning and continues.
+	SignaturePolicyWarn SignaturePolicy = "warn"
+	// SignaturePolicyOff skips signature verification.
+	SignaturePolicyOff SignaturePolicy = "off"
+)
+
+// ResolveSignaturePolicy returns the policy set by the flag value, falling back to
+// JFROG_SKILLS_SIGNATURE_POLICY and then to SignaturePolicyOff.
+func ResolveSignaturePolicy(flagValue string) (SignaturePolicy, error) {
+	value := flagValue
+	if value == "" {
+		value = os.Getenv(envSignaturePolicy)
+	}
+	switch policy := SignaturePolicy(strings.ToLower(value)); policy {
+	case "":
+		return SignaturePolicyOff, nil
+	case SignaturePolicyRequire, SignaturePolicyWarn, SignaturePolicyOff:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid signature policy '%s': expected one of require, warn or off", value)
+	}
+}
+
+// TrustedKey is a public key in the trust store.
+type TrustedKey struct {
+	Name string
+	Path string
+	key  crypto.PublicKey
+}
+
+// Fingerprint returns the SHA-256 of the key's DER-encoded public key.
+func (k *TrustedKey) Fingerprint() string {
+	der, err := x509.MarshalPKIXPublicKey(k.key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// TrustStore is a local directory of PEM-encoded public keys that skill signatures are verified against.
+// Each key is stored as <name>.pem.
+type TrustStore struct {
+	Dir  string
+	Keys []TrustedKey
+}
+
+// TrustStoreDir returns the trust store location: JFROG_SKILLS_TRUST_STORE if set,
+// otherwise skills/trusted-keys under the JFrog home directory.
+func TrustStoreDir() (string, error) {
+	if dir := os.Getenv(envTrustStore); dir != "" {
+		return dir, nil
+	}
+	home, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "skills", "trusted-keys"), nil
+}
+
+// LoadTrustStore reads the public keys in dir. A missing directory yields an empty trust store.
+func LoadTrustStore(dir string) (*TrustStore, error) {
+	ts := &TrustStore{Dir: dir}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ts, nil
+		}
+		return nil, fmt.Errorf("failed to read trust store %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != trustedKeyExtension {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		key, err := dsse.LoadPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key in trust store: %w", err)
+		}
+		ts.Keys = append(ts.Keys, TrustedKey{Name: strings.TrimSuffix(entry.Name(), trustedKeyExtension), Path: path, key: key})
+	}
+	sort.Slice(ts.Keys, func(i, j int) bool { return ts.Keys[i].Name < ts.Keys[j].Name })
+	return ts, nil
+}
+
+// Add validates the public key at keyPath and copies it into the trust store under name.
+func (ts *TrustStore) Add(keyPath, name string) (*TrustedKey, error) {
+	if !keyNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid key name '%s': must match pattern %s", name, keyNameRegex.String())
+	}
+	if ts.find(name) != nil {
+		return nil, fmt.Errorf("trust store already contains a key named '%s'", name)
+	}
+	key, err := dsse.LoadPublicKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G304 -- keyPath is a public key file provided by the user
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %s: %w", keyPath, err)
+	}
+	if err := os.MkdirAll(ts.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trust store %s: %w", ts.Dir, err)
+	}
+	path := filepath.Join(ts.Dir, name+trustedKeyExtension)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	ts.Keys = append(ts.Keys, TrustedKey{Name: name, Path: path, key: key})
+	return &ts.Keys[len(ts.Keys)-1], nil
+}
+
+// Remove deletes the key with the given name from the trust store.
+func (ts *TrustStore) Remove(name string) error {
+	key := ts.find(name)
+	if key == nil {
+		return fmt.Errorf("trust store %s has no key named '%s'", ts.Dir, name)
+	}
+	if err := os.Remove(key.Path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", key.Path, err)
+	}
+	ts.Keys = slices.DeleteFunc(ts.Keys, func(k TrustedKey) bool { return k.Name == name })
+	return nil
+}
+
+// VerifyAttestation checks that envelope was signed by one of the trusted keys and attests the
+// given skill version and digest. It returns the name of the key that verified it.
+func (ts *TrustStore) VerifyAttestation(envelope *dsse.Envelope, slug, version, sha256Hex string) (string, error) {
+	if len(ts.Keys) == 0 {
+		return "", fmt.Errorf("the trust store %s has no keys. Add one with 'jf skills trust add <public-key>'", ts.Dir)
+	}
+	var lastErr error
+	for _, key := range ts.Keys {
+		if lastErr = verifyAttestation(envelope, key.key, slug, version, sha256Hex); lastErr == nil {
+			return key.Name, nil
+		}
+	}
+	if len(ts.Keys) == 1 {
+		return "", lastErr
+	}
+	return "", fmt.Errorf("no trusted key verifies the signature (tried %d keys): %w", len(ts.Keys), lastErr)
+}
+
+func (ts *TrustStore) find(name string) *TrustedKey {
+	for i := range ts.Keys {
+		if ts.Keys[i].Name == name {
+			return &ts.Keys[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-artifactory/evidence/dsse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSignaturePolicy(t *testing.T) {
+	t.Setenv(envSignaturePolicy, "")
+	policy, err := ResolveSignaturePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SignaturePolicyOff, policy)
+
+	policy, err = ResolveSignaturePolicy("Require")
+	require.NoError(t, err)
+	assert.Equal(t, SignaturePolicyRequire, policy)
+
+	t.Setenv(envSignaturePolicy, "warn")
+	policy, err = ResolveSignaturePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SignaturePolicyWarn, policy)
+
+	// The flag takes precedence over the environment.
+	policy, err = ResolveSignaturePolicy("off")
+	require.NoError(t, err)
+	assert.Equal(t, SignaturePolicyOff, policy)
+
+	_, err = ResolveSignaturePolicy("strict")
+	assert.ErrorContains(t, err, "invalid signature policy 'strict'")
+}
+
+func TestTrustStore_AddListRemove(t *testing.T) {
+	keyDir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, pubPath := writeKeyPair(t, keyDir, "release", key)
+
+	storeDir := filepath.Join(t.TempDir(), "trusted-keys")
+	ts, err := LoadTrustStore(storeDir)
+	require.NoError(t, err)
+	assert.Empty(t, ts.Keys)
+
+	added, err := ts.Add(pubPath, "release")
+	require.NoError(t, err)
+	assert.Len(t, added.Fingerprint(), 64)
+
+	_, err = ts.Add(pubPath, "release")
+	assert.ErrorContains(t, err, "already contains a key named 'release'")
+	_, err = ts.Add(pubPath, "../escape")
+	assert.ErrorContains(t, err, "invalid key name")
+	_, err = ts.Add(filepath.Join(keyDir, "release.key"), "private")
+	assert.ErrorContains(t, err, "unsupported public key")
+
+	loaded, err := LoadTrustStore(storeDir)
+	require.NoError(t, err)
+	require.Len(t, loaded.Keys, 1)
+	assert.Equal(t, "release", loaded.Keys[0].Name)
+	assert.Equal(t, added.Fingerprint(), loaded.Keys[0].Fingerprint())
+
+	require.NoError(t, loaded.Remove("release"))
+	assert.Empty(t, loaded.Keys)
+	assert.ErrorContains(t, loaded.Remove("release"), "has no key named 'release'")
+	assert.NoFileExists(t, filepath.Join(storeDir, "release.pem"))
+}
+
+func TestTrustStore_VerifyAttestation(t *testing.T) {
+	keyDir := t.TempDir()
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPath, pubPath := writeKeyPair(t, keyDir, "signer", signer)
+	_, otherPub := writeKeyPair(t, keyDir, "other", other)
+
+	statement, err := NewInTotoStatement("my-skill-1.0.0.zip", "abc", "https://example.com/predicate", []byte(`{"skill":"my-skill","version":"1.0.0"}`))
+	require.NoError(t, err)
+	envelope, err := dsse.SignEnvelope(dsse.InTotoPayloadType, statement, privPath, "")
+	require.NoError(t, err)
+
+	ts := &TrustStore{Dir: t.TempDir()}
+	_, err = ts.VerifyAttestation(envelope, "my-skill", "1.0.0", "abc")
+	assert.ErrorContains(t, err, "has no keys")
+
+	_, err = ts.Add(otherPub, "a-other")
+	require.NoError(t, err)
+	_, err = ts.VerifyAttestation(envelope, "my-skill", "1.0.0", "abc")
+	assert.ErrorContains(t, err, "signature does not match")
+
+	_, err = ts.Add(pubPath, "b-signer")
+	require.NoError(t, err)
+	signedBy, err := ts.VerifyAttestation(envelope, "my-skill", "1.0.0", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "b-signer", signedBy)
+
+	_, err = ts.VerifyAttestation(envelope, "my-skill", "1.0.0", "def")
+	assert.ErrorContains(t, err, "does not cover the digest def")
+
+	_, err = ts.VerifyAttestation(envelope, "other-skill", "1.0.0", "abc")
+	assert.ErrorContains(t, err, "attestation is for skill 'my-skill' version '1.0.0', not 'other-skill' version '1.0.0'")
+}
+
+func TestLoadTrustStore_InvalidKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("garbage"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("ignored"), 0644))
+
+	_, err := LoadTrustStore(dir)
+	assert.ErrorContains(t, err, "invalid key in trust store")
+}
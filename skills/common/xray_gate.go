@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
@@ -20,8 +22,29 @@ const (
 	xrayPollInterval       = 5 * time.Second
 	envSkipSkillsScan      = "JFROG_CLI_SKIP_SKILLS_SCAN"
 	envScanTimeout         = "JFROG_CLI_SKILLS_SCAN_TIMEOUT"
+	envScanFailOn          = "JFROG_CLI_SKILLS_SCAN_FAIL_ON"
 )
 
+// Outcomes of the Xray scan gate, as recorded in the scan report.
+const (
+	XrayGateOutcomePassed  = "passed"
+	XrayGateOutcomeBlocked = "blocked"
+	// XrayGateOutcomeSkipped means the gate was bypassed with --skip-scan or JFROG_CLI_SKIP_SKILLS_SCAN.
+	XrayGateOutcomeSkipped = "skipped"
+	// XrayGateOutcomeUnscanned means Xray is not entitled or is disabled for the repository.
+	XrayGateOutcomeUnscanned = "unscanned"
+	// XrayGateOutcomeUnknown means the gate returned a status this client does not know.
+	XrayGateOutcomeUnknown = "unknown"
+	// XrayGateOutcomeTimeout means the scan did not complete within the scan timeout.
+	XrayGateOutcomeTimeout = "timeout"
+	// XrayGateOutcomeError means the gate API could not be called.
+	XrayGateOutcomeError = "error"
+)
+
+// xrayGateFailOnOutcomes are the outcomes that can be made to fail the command with --fail-on.
+// A blocked skill always fails it.
+var xrayGateFailOnOutcomes = []string{XrayGateOutcomeUnscanned, XrayGateOutcomeUnknown, XrayGateOutcomeTimeout, XrayGateOutcomeError}
+
 // XrayGateParams contains the parameters for the Xray scan gate check.
 type XrayGateParams struct {
 	ServerDetails       *config.ServerDetails
@@ -32,57 +55,200 @@ type XrayGateParams struct {
 	SkipScan            bool
 	AutoDeleteOnFailure bool
 	Quiet               bool
+	// FailOn lists the outcomes, besides blocked, that fail the gate (see ParseXrayGateFailOn).
+	FailOn []string
+	// ReportPath, when set, receives the gate result in ReportFormat ("json" or "sarif").
+	ReportPath   string
+	ReportFormat string
+	// ServicesManager, when set, is used instead of creating one from ServerDetails.
+	ServicesManager artifactory.ArtifactoryServicesManager
+}
+
+// XrayGateObservation is a single response of the gate endpoint.
+type XrayGateObservation struct {
+	Time   time.Time `json:"time"`
+	Status string    `json:"status,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// XrayGateResult is the full result of an Xray scan gate check.
+type XrayGateResult struct {
+	Slug    string `json:"slug"`
+	Version string `json:"version"`
+	RepoKey string `json:"repoKey"`
+	Path    string `json:"path"`
+	// Status is the last status returned by the gate endpoint, if any.
+	Status  string   `json:"status,omitempty"`
+	Outcome string   `json:"outcome"`
+	Reasons []string `json:"reasons,omitempty"`
+	// Failed reports whether the outcome failed the command under the configured policy.
+	Failed       bool                  `json:"failed"`
+	Deleted      bool                  `json:"deleted,omitempty"`
+	StartedAt    time.Time             `json:"startedAt"`
+	FinishedAt   time.Time             `json:"finishedAt"`
+	Polls        int                   `json:"polls"`
+	Observations []XrayGateObservation `json:"observations,omitempty"`
+}
+
+// ParseXrayGateFailOn parses a comma-separated list of gate outcomes that should fail the command.
+// An empty value falls back to JFROG_CLI_SKILLS_SCAN_FAIL_ON.
+func ParseXrayGateFailOn(value string) ([]string, error) {
+	if value == "" {
+		value = os.Getenv(envScanFailOn)
+	}
+	var failOn []string
+	for _, outcome := range strings.Split(value, ",") {
+		outcome = strings.ToLower(strings.TrimSpace(outcome))
+		if outcome == "" {
+			continue
+		}
+		if !slices.Contains(xrayGateFailOnOutcomes, outcome) {
+			return nil, fmt.Errorf("invalid --fail-on value '%s': expected a comma-separated list of %s", outcome, strings.Join(xrayGateFailOnOutcomes, ", "))
+		}
+		failOn = append(failOn, outcome)
+	}
+	return failOn, nil
 }
 
 // CheckXrayGate calls the Artifactory Skills Xray gate endpoint after publish.
 // It polls until a terminal status is reached, then acts based on the result.
-// Returns an error only if the scan detects malicious content (BLOCKED).
+// Returns an error if the scan detects malicious content (BLOCKED) or the outcome is listed in FailOn.
 func CheckXrayGate(params XrayGateParams) error {
+	_, err := RunXrayGate(params)
+	return err
+}
+
+// RunXrayGate runs the Xray scan gate, writes the scan report if requested and returns the result.
+// The returned error is set when the gate fails the command.
+func RunXrayGate(params XrayGateParams) (*XrayGateResult, error) {
+	result := &XrayGateResult{
+		Slug:      params.Slug,
+		Version:   params.Version,
+		RepoKey:   params.RepoKey,
+		Path:      params.ArtifactPath,
+		StartedAt: time.Now().UTC(),
+	}
+
+	var gateErr error
 	if params.SkipScan || envBool(envSkipSkillsScan) {
 		log.Info("Xray scan check skipped.")
-		return nil
+		result.setOutcome(XrayGateOutcomeSkipped, "scan skipped by --skip-scan or "+envSkipSkillsScan)
+	} else if sm, err := params.servicesManager(); err != nil {
+		log.Warn("Could not create service manager for Xray gate check:", err.Error())
+		result.setOutcome(XrayGateOutcomeError, "could not create service manager: "+err.Error())
+	} else {
+		gate := &xrayGate{params: params, client: sm, sm: sm, result: result, pollInterval: xrayPollInterval, timeout: resolveTimeout()}
+		gateErr = gate.run()
+	}
+	result.FinishedAt = time.Now().UTC()
+	gateErr = applyFailOn(result, params.FailOn, gateErr)
+
+	if params.ReportPath != "" {
+		if err := WriteXrayGateReport(result, params.ReportPath, params.ReportFormat); err != nil {
+			if gateErr != nil {
+				return result, fmt.Errorf("%w (%s)", gateErr, err.Error())
+			}
+			return result, err
+		}
+		log.Info("Xray scan report written to", params.ReportPath)
+	}
+	return result, gateErr
+}
+
+func (params XrayGateParams) servicesManager() (artifactory.ArtifactoryServicesManager, error) {
+	if params.ServicesManager != nil {
+		return params.ServicesManager, nil
 	}
+	return utils.CreateServiceManager(params.ServerDetails, 3, 0, false)
+}
+
+// applyFailOn fails the gate when its outcome is listed in failOn and records whether it failed.
+func applyFailOn(result *XrayGateResult, failOn []string, gateErr error) error {
+	if gateErr == nil && slices.Contains(failOn, result.Outcome) {
+		gateErr = fmt.Errorf("scan gate for skill %q v%s failed: outcome '%s' is not allowed by --fail-on (%s)",
+			result.Slug, result.Version, result.Outcome, strings.Join(result.Reasons, "; "))
+	}
+	result.Failed = gateErr != nil
+	return gateErr
+}
+
+func (r *XrayGateResult) setOutcome(outcome string, reasons ...string) {
+	r.Outcome = outcome
+	r.Reasons = append(r.Reasons, reasons...)
+}
 
-	sm, err := utils.CreateServiceManager(params.ServerDetails, 3, 0, false)
+// xrayStatusClient is the part of the services manager used to query the gate endpoint.
+type xrayStatusClient interface {
+	GetSkillXrayStatus(repoKey, artifactPath string) (*services.SkillXrayStatusResponse, error)
+}
+
+// xrayGate runs a single gate check and records what it observed in result.
+type xrayGate struct {
+	params       XrayGateParams
+	client       xrayStatusClient
+	sm           artifactory.ArtifactoryServicesManager
+	result       *XrayGateResult
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// getStatus queries the gate endpoint and records the response.
+func (g *xrayGate) getStatus() (string, error) {
+	observation := XrayGateObservation{Time: time.Now().UTC()}
+	resp, err := g.client.GetSkillXrayStatus(g.params.RepoKey, g.params.ArtifactPath)
 	if err != nil {
-		log.Warn("Could not create service manager for Xray gate check:", err.Error())
-		return nil
+		observation.Error = err.Error()
+	} else {
+		observation.Status = resp.Status
+		g.result.Status = resp.Status
 	}
+	g.result.Observations = append(g.result.Observations, observation)
+	if err != nil {
+		return "", err
+	}
+	return resp.Status, nil
+}
 
-	resp, err := sm.GetSkillXrayStatus(params.RepoKey, params.ArtifactPath)
+func (g *xrayGate) run() error {
+	params := g.params
+	status, err := g.getStatus()
 	if err != nil {
 		log.Warn("Xray gate check failed:", err.Error())
+		g.result.setOutcome(XrayGateOutcomeError, "gate API error: "+err.Error())
 		return nil
 	}
 
-	switch resp.Status {
+	switch status {
 	case services.SkillXrayStatusNotInEntitlement:
 		log.Debug("Xray entitlement not active. Skipping scan gate.")
+		g.result.setOutcome(XrayGateOutcomeUnscanned, "Xray entitlement is not active")
 		return nil
 	case services.SkillXrayStatusDisabledForRepo:
 		log.Info(fmt.Sprintf("Xray scanning is disabled for repository '%s'. Skipping scan.", params.RepoKey))
+		g.result.setOutcome(XrayGateOutcomeUnscanned, fmt.Sprintf("Xray scanning is disabled for repository '%s'", params.RepoKey))
 		return nil
 	case services.SkillXrayStatusApproved:
 		log.Info(fmt.Sprintf("[SUCCESS] Skill \"%s\" v%s passed security scan.", params.Slug, params.Version))
+		g.result.setOutcome(XrayGateOutcomePassed)
 		return nil
 	case services.SkillXrayStatusBlocked:
-		return handleBlocked(sm, params)
+		return g.blocked()
 	case services.SkillXrayStatusScanInProgress:
-		return pollUntilDone(sm, params)
+		return g.pollUntilDone()
 	default:
-		log.Warn(fmt.Sprintf("Unknown Xray gate status: %s. Skipping scan gate.", resp.Status))
+		log.Warn(fmt.Sprintf("Unknown Xray gate status: %s. Skipping scan gate.", status))
+		g.result.setOutcome(XrayGateOutcomeUnknown, fmt.Sprintf("unknown Xray gate status '%s'", status))
 		return nil
 	}
 }
 
-func pollUntilDone(sm artifactory.ArtifactoryServicesManager, params XrayGateParams) error {
+func (g *xrayGate) pollUntilDone() error {
+	params := g.params
 	log.Info("Scanning for malicious content...")
 
-	timeout := resolveTimeout()
-	ticker := time.NewTicker(xrayPollInterval)
+	ticker := time.NewTicker(g.pollInterval)
 	defer ticker.Stop()
-	deadline := time.After(timeout)
-	pollCount := 0
+	deadline := time.After(g.timeout)
 	startTime := time.Now()
 
 	// Use a spinner only for interactive terminals that are not in quiet mode.
@@ -98,7 +264,7 @@ func pollUntilDone(sm artifactory.ArtifactoryServicesManager, params XrayGatePar
 			mng.GetBarsWg().Add(1)
 			spinner = mng.NewUpdatableHeadlineBarWithSpinner(func() string {
 				elapsed := time.Since(startTime).Truncate(time.Second)
-				return fmt.Sprintf(" Scanning for malicious content... (%s elapsed, %d polls)", elapsed, pollCount)
+				return fmt.Sprintf(" Scanning for malicious content... (%s elapsed, %d polls)", elapsed, g.result.Polls)
 			})
 		}
 	}
@@ -121,48 +287,61 @@ func pollUntilDone(sm artifactory.ArtifactoryServicesManager, params XrayGatePar
 		select {
 		case <-deadline:
 			stopSpinner()
-			log.Warn(fmt.Sprintf("Xray scan did not complete within %s after %d polls. The scan may still be in progress on the server.", timeout, pollCount))
+			log.Warn(fmt.Sprintf("Xray scan did not complete within %s after %d polls. The scan may still be in progress on the server.", g.timeout, g.result.Polls))
+			g.result.setOutcome(XrayGateOutcomeTimeout, fmt.Sprintf("scan did not complete within %s after %d polls", g.timeout, g.result.Polls))
 			return nil
 		case <-ticker.C:
-			pollCount++
+			g.result.Polls++
 			if !useSpinner {
-				log.Debug(fmt.Sprintf("Xray scan poll attempt %d...", pollCount))
+				log.Debug(fmt.Sprintf("Xray scan poll attempt %d...", g.result.Polls))
 			}
-			resp, err := sm.GetSkillXrayStatus(params.RepoKey, params.ArtifactPath)
+			status, err := g.getStatus()
 			if err != nil {
 				log.Debug("Poll error (will retry):", err.Error())
 				continue
 			}
-			switch resp.Status {
+			switch status {
 			case services.SkillXrayStatusApproved:
 				stopSpinner()
 				log.Info(fmt.Sprintf("[SUCCESS] Skill \"%s\" v%s passed security scan.", params.Slug, params.Version))
+				g.result.setOutcome(XrayGateOutcomePassed)
 				return nil
 			case services.SkillXrayStatusBlocked:
 				stopSpinner()
-				return handleBlocked(sm, params)
+				return g.blocked()
 			case services.SkillXrayStatusScanInProgress:
 				continue
 			default:
 				stopSpinner()
-				log.Warn(fmt.Sprintf("Unexpected Xray gate status during polling: %s", resp.Status))
+				log.Warn(fmt.Sprintf("Unexpected Xray gate status during polling: %s", status))
+				g.result.setOutcome(XrayGateOutcomeUnknown, fmt.Sprintf("unexpected Xray gate status '%s' during polling", status))
 				return nil
 			}
 		}
 	}
 }
 
-func handleBlocked(sm artifactory.ArtifactoryServicesManager, params XrayGateParams) error {
+func (g *xrayGate) blocked() error {
+	g.result.setOutcome(XrayGateOutcomeBlocked, "identified as malicious by the Xray security scan")
+	deleted, err := handleBlocked(g.sm, g.params)
+	g.result.Deleted = deleted
+	return err
+}
+
+// handleBlocked reports a blocked skill and, if requested, deletes it. It returns whether the version was deleted.
+func handleBlocked(sm artifactory.ArtifactoryServicesManager, params XrayGateParams) (bool, error) {
 	log.Error(fmt.Sprintf("[VIOLATION] Skill \"%s\" v%s identified as malicious.", params.Slug, params.Version))
+	deleted := false
 	if params.AutoDeleteOnFailure {
 		deletePath := fmt.Sprintf("%s/%s/%s/", params.RepoKey, params.Slug, params.Version)
 		if err := deleteSkillVersionWithManager(sm, params.RepoKey, params.Slug, params.Version); err != nil {
 			log.Error(fmt.Sprintf("Failed to delete malicious skill artifact '%s' from '%s': %s", deletePath, params.RepoKey, err.Error()))
 		} else {
 			log.Info(fmt.Sprintf("Malicious artifact deleted: %s", deletePath))
+			deleted = true
 		}
 	}
-	return fmt.Errorf("skill %q v%s was blocked by Xray security scan", params.Slug, params.Version)
+	return deleted, fmt.Errorf("skill %q v%s was blocked by Xray security scan", params.Slug, params.Version)
 }
 
 // DeleteSkillVersion deletes the entire version directory for a skill.
@@ -1,18 +1,58 @@
 package common
 
 import (
+	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// fakeStatusClient returns the given statuses in order, repeating the last one. An empty status returns err.
+type fakeStatusClient struct {
+	statuses []string
+	err      error
+	calls    int
+}
+
+func (f *fakeStatusClient) GetSkillXrayStatus(repoKey, artifactPath string) (*services.SkillXrayStatusResponse, error) {
+	status := f.statuses[min(f.calls, len(f.statuses)-1)]
+	f.calls++
+	if status == "" {
+		return nil, f.err
+	}
+	return &services.SkillXrayStatusResponse{Status: status, RepoKey: repoKey, Path: artifactPath}, nil
+}
+
+func runFakeGate(client *fakeStatusClient) (*XrayGateResult, error) {
+	result := &XrayGateResult{}
+	gate := &xrayGate{
+		params:       XrayGateParams{RepoKey: "skills-local", Slug: "my-skill", Version: "1.0.0", Quiet: true},
+		client:       client,
+		result:       result,
+		pollInterval: time.Millisecond,
+		timeout:      50 * time.Millisecond,
+	}
+	return result, gate.run()
+}
+
 func TestCheckXrayGate_SkipScan(t *testing.T) {
 	err := CheckXrayGate(XrayGateParams{SkipScan: true})
 	assert.NoError(t, err)
 }
 
+func TestRunXrayGate_Report(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	result, err := RunXrayGate(XrayGateParams{Slug: "my-skill", Version: "1.0.0", SkipScan: true, ReportPath: reportPath})
+	require.NoError(t, err)
+	assert.Equal(t, XrayGateOutcomeSkipped, result.Outcome)
+	assert.False(t, result.Failed)
+	assert.FileExists(t, reportPath)
+}
+
 func TestCheckXrayGate_SkipViaEnv(t *testing.T) {
 	t.Setenv(envSkipSkillsScan, "true")
 	err := CheckXrayGate(XrayGateParams{})
@@ -43,7 +83,8 @@ func TestHandleBlocked_NoAutoDelete(t *testing.T) {
 		Version:             "1.0.0",
 		AutoDeleteOnFailure: false,
 	}
-	err := handleBlocked(nil, params)
+	deleted, err := handleBlocked(nil, params)
+	assert.False(t, deleted)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "blocked by Xray security scan")
 }
@@ -56,3 +97,66 @@ func TestXrayStatusConstants(t *testing.T) {
 	assert.Equal(t, "BLOCKED", services.SkillXrayStatusBlocked)
 	assert.Equal(t, "APPROVED", services.SkillXrayStatusApproved)
 }
+
+func TestParseXrayGateFailOn(t *testing.T) {
+	t.Setenv(envScanFailOn, "")
+	failOn, err := ParseXrayGateFailOn("")
+	require.NoError(t, err)
+	assert.Empty(t, failOn)
+
+	failOn, err = ParseXrayGateFailOn(" Timeout, error ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{XrayGateOutcomeTimeout, XrayGateOutcomeError}, failOn)
+
+	t.Setenv(envScanFailOn, "unknown")
+	failOn, err = ParseXrayGateFailOn("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{XrayGateOutcomeUnknown}, failOn)
+
+	_, err = ParseXrayGateFailOn("blocked")
+	assert.ErrorContains(t, err, "invalid --fail-on value 'blocked'")
+}
+
+func TestXrayGate_Outcomes(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		outcome  string
+		wantErr  bool
+	}{
+		{"approved", []string{services.SkillXrayStatusApproved}, XrayGateOutcomePassed, false},
+		{"blocked", []string{services.SkillXrayStatusBlocked}, XrayGateOutcomeBlocked, true},
+		{"polled until approved", []string{services.SkillXrayStatusScanInProgress, "", services.SkillXrayStatusApproved}, XrayGateOutcomePassed, false},
+		{"polled until blocked", []string{services.SkillXrayStatusScanInProgress, services.SkillXrayStatusBlocked}, XrayGateOutcomeBlocked, true},
+		{"not entitled", []string{services.SkillXrayStatusNotInEntitlement}, XrayGateOutcomeUnscanned, false},
+		{"disabled for repo", []string{services.SkillXrayStatusDisabledForRepo}, XrayGateOutcomeUnscanned, false},
+		{"unknown", []string{"SOMETHING_NEW"}, XrayGateOutcomeUnknown, false},
+		{"api error", []string{""}, XrayGateOutcomeError, false},
+		{"timeout", []string{services.SkillXrayStatusScanInProgress}, XrayGateOutcomeTimeout, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeStatusClient{statuses: tt.statuses, err: errors.New("connection refused")}
+			result, err := runFakeGate(client)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.outcome, result.Outcome)
+			assert.Len(t, result.Observations, client.calls)
+			assert.Equal(t, client.calls-1, result.Polls)
+		})
+	}
+}
+
+func TestApplyFailOn(t *testing.T) {
+	result := &XrayGateResult{Slug: "my-skill", Version: "1.0.0"}
+	result.setOutcome(XrayGateOutcomeTimeout, "scan did not complete within 5m0s after 60 polls")
+	assert.NoError(t, applyFailOn(result, nil, nil))
+	assert.False(t, result.Failed)
+
+	err := applyFailOn(result, []string{XrayGateOutcomeError, XrayGateOutcomeTimeout}, nil)
+	assert.ErrorContains(t, err, "outcome 'timeout' is not allowed by --fail-on (scan did not complete within 5m0s after 60 polls)")
+	assert.True(t, result.Failed)
+
+	blocked := &XrayGateResult{Outcome: XrayGateOutcomeBlocked}
+	assert.Error(t, applyFailOn(blocked, nil, errors.New("blocked")))
+	assert.True(t, blocked.Failed)
+}
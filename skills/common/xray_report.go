@@ -0,0 +1,157 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	XrayReportFormatJSON  = "json"
+	XrayReportFormatSARIF = "sarif"
+
+	sarifSchema       = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion      = "2.1.0"
+	sarifToolName     = "jfrog-skills-xray-gate"
+	sarifRuleIDPrefix = "skills-xray-gate/"
+)
+
+// sarifRuleDescriptions describes every outcome that is reported as a SARIF result. A passed gate has no results.
+var sarifRuleDescriptions = map[string]string{
+	XrayGateOutcomeBlocked:   "The skill was identified as malicious by the Xray security scan.",
+	XrayGateOutcomeSkipped:   "The Xray scan gate was skipped.",
+	XrayGateOutcomeUnscanned: "Xray did not scan the skill: it is not entitled or is disabled for the repository.",
+	XrayGateOutcomeUnknown:   "The Xray scan gate returned an unknown status.",
+	XrayGateOutcomeTimeout:   "The Xray scan did not complete within the scan timeout.",
+	XrayGateOutcomeError:     "The Xray scan gate could not be queried.",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool   `json:"executionSuccessful"`
+	StartTimeUTC        string `json:"startTimeUtc"`
+	EndTimeUTC          string `json:"endTimeUtc"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// ValidateXrayReportFormat checks that format is a supported scan report format. An empty format means JSON.
+func ValidateXrayReportFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "", XrayReportFormatJSON, XrayReportFormatSARIF:
+		return nil
+	default:
+		return fmt.Errorf("invalid scan report format '%s': expected %s or %s", format, XrayReportFormatJSON, XrayReportFormatSARIF)
+	}
+}
+
+// WriteXrayGateReport writes the gate result to path as JSON (the default) or SARIF 2.1.0.
+func WriteXrayGateReport(result *XrayGateResult, path, format string) error {
+	if err := ValidateXrayReportFormat(format); err != nil {
+		return err
+	}
+	var report interface{} = result
+	if strings.EqualFold(format, XrayReportFormatSARIF) {
+		report = toSarif(result)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write scan report %s: %w", path, err)
+	}
+	return nil
+}
+
+func toSarif(result *XrayGateResult) *sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{Name: sarifToolName, InformationURI: "https://jfrog.com/xray/", Rules: []sarifRule{}}},
+		Invocations: []sarifInvocation{{
+			ExecutionSuccessful: !result.Failed,
+			StartTimeUTC:        result.StartedAt.Format(time.RFC3339),
+			EndTimeUTC:          result.FinishedAt.Format(time.RFC3339),
+		}},
+		Results: []sarifResult{},
+	}
+
+	if description, ok := sarifRuleDescriptions[result.Outcome]; ok {
+		ruleID := sarifRuleIDPrefix + result.Outcome
+		run.Tool.Driver.Rules = []sarifRule{{ID: ruleID, ShortDescription: sarifMessage{Text: description}}}
+
+		level := "warning"
+		if result.Failed {
+			level = "error"
+		}
+		message := fmt.Sprintf("Skill %q v%s: %s", result.Slug, result.Version, description)
+		if len(result.Reasons) > 0 {
+			message += " " + strings.Join(result.Reasons, "; ") + "."
+		}
+		properties := map[string]interface{}{"polls": result.Polls}
+		if result.Status != "" {
+			properties["status"] = result.Status
+		}
+		if result.Deleted {
+			properties["deleted"] = true
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:     ruleID,
+			Level:      level,
+			Message:    sarifMessage{Text: message},
+			Locations:  []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: result.RepoKey + "/" + result.Path}}}},
+			Properties: properties,
+		})
+	}
+	return &sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGateResult(outcome string, failed bool) *XrayGateResult {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &XrayGateResult{
+		Slug:       "my-skill",
+		Version:    "1.0.0",
+		RepoKey:    "skills-local",
+		Path:       "my-skill/1.0.0/my-skill-1.0.0.zip",
+		Status:     "SCAN_IN_PROGRESS",
+		Failed:     failed,
+		StartedAt:  started,
+		FinishedAt: started.Add(time.Minute),
+		Polls:      12,
+	}
+	result.setOutcome(outcome, "scan did not complete within 1m0s after 12 polls")
+	return result
+}
+
+func TestWriteXrayGateReport_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, WriteXrayGateReport(testGateResult(XrayGateOutcomeTimeout, true), path, ""))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var result XrayGateResult
+	require.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, XrayGateOutcomeTimeout, result.Outcome)
+	assert.True(t, result.Failed)
+	assert.Equal(t, 12, result.Polls)
+	assert.Equal(t, "2026-01-02T03:04:05Z", result.StartedAt.Format(time.RFC3339))
+}
+
+func TestWriteXrayGateReport_SARIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	require.NoError(t, WriteXrayGateReport(testGateResult(XrayGateOutcomeTimeout, true), path, "SARIF"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.False(t, run.Invocations[0].ExecutionSuccessful)
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "skills-xray-gate/timeout", run.Results[0].RuleID)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Contains(t, run.Results[0].Message.Text, "after 12 polls")
+	assert.Equal(t, "skills-local/my-skill/1.0.0/my-skill-1.0.0.zip", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, run.Results[0].RuleID, run.Tool.Driver.Rules[0].ID)
+}
+
+func TestWriteXrayGateReport_SARIFPassed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	result := testGateResult(XrayGateOutcomePassed, false)
+	result.Reasons = nil
+	require.NoError(t, WriteXrayGateReport(result, path, XrayReportFormatSARIF))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(data, &log))
+	assert.True(t, log.Runs[0].Invocations[0].ExecutionSuccessful)
+	assert.Empty(t, log.Runs[0].Results)
+}
+
+func TestWriteXrayGateReport_InvalidFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	err := WriteXrayGateReport(testGateResult(XrayGateOutcomePassed, false), path, "xml")
+	assert.ErrorContains(t, err, "invalid scan report format 'xml'")
+	assert.NoFileExists(t, path)
+}